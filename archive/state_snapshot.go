@@ -0,0 +1,433 @@
+package archive
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/vishnushankarsg/metad/blockchain/storage"
+	"github.com/vishnushankarsg/metad/helper/common"
+	itrie "github.com/vishnushankarsg/metad/state/immutable-trie"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+const (
+	// stateSnapshotBlocks is the number of the latest blocks included in the state snapshot,
+	// so the hashes of the recent blocks are available to the BLOCKHASH opcode after the import
+	stateSnapshotBlocks = 256
+
+	// stateSnapshotLogInterval is the number of the accounts between the progress logs
+	stateSnapshotLogInterval = 10000
+)
+
+var (
+	errStateSnapshotChainNotEmpty = errors.New("state snapshot can only be restored to the empty chain")
+)
+
+type stateSnapshotBlockchain interface {
+	Genesis() types.Hash
+	Header() *types.Header
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	WriteSnapshotBlocks([]*types.Header, []*types.Block, *big.Int) error
+}
+
+// CreateStateSnapshot writes the state of the block with the given number (the latest one if not set),
+// preceded by the latest blocks, to the file at the given path.
+// The headers from the start of the epoch of the first block are written before the blocks,
+// so the validator set of the epoch can be rebuilt from them after the import.
+// The data is read from the databases of the node directly, so the node has to be stopped
+func CreateStateSnapshot(
+	db storage.Storage,
+	stateStorage itrie.Storage,
+	logger hclog.Logger,
+	number *uint64,
+	epochSize uint64,
+	outPath string,
+) (*StateSnapshotMetadata, uint64, error) {
+	metadata, headers, blocks, err := readStateSnapshotBlocks(db, number, epochSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// always create new file, throw error if the file exists
+	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	accounts, err := writeStateSnapshot(fs, stateStorage, logger, metadata, headers, blocks)
+	if closeErr := fs.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		if err := os.Remove(outPath); err != nil {
+			logger.Error("an error occurred while removing file", "err", err)
+		}
+
+		return nil, 0, err
+	}
+
+	return metadata, accounts, nil
+}
+
+// readStateSnapshotBlocks reads the block with the given number (the latest one if not set),
+// along with the blocks preceding it and the headers from the start of the epoch of the first block,
+// and builds the metadata of the state snapshot
+func readStateSnapshotBlocks(
+	db storage.Storage,
+	number *uint64,
+	epochSize uint64,
+) (*StateSnapshotMetadata, []*types.Header, []*types.Block, error) {
+	head, ok := db.ReadHeadNumber()
+	if !ok {
+		return nil, nil, nil, errors.New("head block not found")
+	}
+
+	to := head
+	if number != nil {
+		if *number > head {
+			return nil, nil, nil, fmt.Errorf("block %d not found, the latest block is %d", *number, head)
+		}
+
+		to = *number
+	}
+
+	genesis, ok := db.ReadCanonicalHash(0)
+	if !ok {
+		return nil, nil, nil, errors.New("genesis block not found")
+	}
+
+	from := uint64(0)
+	if to >= stateSnapshotBlocks {
+		from = to - stateSnapshotBlocks + 1
+	}
+
+	// the genesis header is known to the importing node already
+	headers := make([]*types.Header, 0)
+
+	for i := common.Max(epochStart(from, epochSize), 1); i < from; i++ {
+		header, err := readCanonicalHeader(db, i)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		headers = append(headers, header)
+	}
+
+	blocks := make([]*types.Block, 0, to-from+1)
+
+	for i := from; i <= to; i++ {
+		block, err := readCanonicalBlock(db, i)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	last := blocks[len(blocks)-1]
+
+	td, ok := db.ReadTotalDifficulty(last.Hash())
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("total difficulty of block %d not found", to)
+	}
+
+	return &StateSnapshotMetadata{
+		Genesis:         genesis,
+		Number:          to,
+		Hash:            last.Hash(),
+		StateRoot:       last.Header.StateRoot,
+		TotalDifficulty: td,
+		Blocks:          uint64(len(blocks)),
+		Headers:         uint64(len(headers)),
+	}, headers, blocks, nil
+}
+
+// epochStart returns the number of the first block of the epoch the given block belongs to,
+// the epochs are not taken into account if the epoch size is not set
+func epochStart(number, epochSize uint64) uint64 {
+	if epochSize == 0 {
+		return number
+	}
+
+	return number / epochSize * epochSize
+}
+
+// readCanonicalHeader reads the header of the canonical chain with the given number
+func readCanonicalHeader(db storage.Storage, number uint64) (*types.Header, error) {
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("header of block %d not found: %w", number, err)
+	}
+
+	// the stored hash is used, as the hash of the header depends on the consensus
+	header.Hash = hash
+
+	return header, nil
+}
+
+// readCanonicalBlock reads the block of the canonical chain with the given number
+func readCanonicalBlock(db storage.Storage, number uint64) (*types.Block, error) {
+	header, err := readCanonicalHeader(db, number)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := db.ReadBody(header.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("body of block %d not found: %w", number, err)
+	}
+
+	return &types.Block{
+		Header:       header,
+		Transactions: body.Transactions,
+		Uncles:       body.Uncles,
+	}, nil
+}
+
+// writeStateSnapshot writes the metadata, the headers, the blocks and the accounts of the state snapshot,
+// and returns the number of the written accounts
+func writeStateSnapshot(
+	fs *os.File,
+	stateStorage itrie.Storage,
+	logger hclog.Logger,
+	metadata *StateSnapshotMetadata,
+	headers []*types.Header,
+	blocks []*types.Block,
+) (uint64, error) {
+	writer := bufio.NewWriter(fs)
+
+	if _, err := writer.Write(metadata.MarshalRLP()); err != nil {
+		return 0, err
+	}
+
+	for _, header := range headers {
+		if _, err := writer.Write(header.MarshalRLP()); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, block := range blocks {
+		if _, err := writer.Write(block.MarshalRLP()); err != nil {
+			return 0, err
+		}
+	}
+
+	logger.Info("Wrote blocks to state snapshot", "number", metadata.Number, "headers", len(headers), "blocks", len(blocks))
+
+	var (
+		accounts uint64
+		arena    fastrlp.Arena
+	)
+
+	err := itrie.DumpState(metadata.StateRoot, stateStorage, func(account *itrie.DumpAccount) error {
+		if _, err := writer.Write(account.MarshalRLPWith(&arena).MarshalTo(nil)); err != nil {
+			return err
+		}
+
+		arena.Reset()
+
+		if accounts++; accounts%stateSnapshotLogInterval == 0 {
+			logger.Info("Wrote accounts to state snapshot", "accounts", accounts)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to dump state %s: %w", metadata.StateRoot, err)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return 0, err
+	}
+
+	logger.Info("Wrote state snapshot", "number", metadata.Number, "accounts", accounts)
+
+	return accounts, nil
+}
+
+// RestoreStateSnapshot imports the state and the blocks of the state snapshot to the empty chain,
+// so only the blocks after the snapshot have to be synced
+func RestoreStateSnapshot(
+	chain stateSnapshotBlockchain,
+	stateStorage itrie.Storage,
+	logger hclog.Logger,
+	filePath string,
+) error {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer fp.Close()
+
+	stream := newBlockStream(fp)
+
+	metadata, err := stream.getStateSnapshotMetadata()
+	if err != nil {
+		return err
+	}
+
+	if metadata == nil {
+		return errors.New("expected metadata in state snapshot but doesn't exist")
+	}
+
+	if metadata.Genesis != chain.Genesis() {
+		return fmt.Errorf(
+			"the hash of genesis block (%s) does not match blockchain genesis (%s)",
+			metadata.Genesis,
+			chain.Genesis(),
+		)
+	}
+
+	// check whether the snapshot has been restored already
+	if header, ok := chain.GetHeaderByNumber(metadata.Number); ok && header.Hash == metadata.Hash {
+		return nil
+	}
+
+	if chain.Header().Number != 0 {
+		return errStateSnapshotChainNotEmpty
+	}
+
+	headers := make([]*types.Header, 0, metadata.Headers)
+
+	for i := uint64(0); i < metadata.Headers; i++ {
+		header, err := stream.nextHeader()
+		if err != nil {
+			return err
+		}
+
+		if header == nil {
+			return fmt.Errorf("expected %d headers in state snapshot but found %d", metadata.Headers, i)
+		}
+
+		headers = append(headers, header)
+	}
+
+	blocks := make([]*types.Block, 0, metadata.Blocks)
+
+	for i := uint64(0); i < metadata.Blocks; i++ {
+		block, err := stream.nextBlock()
+		if err != nil {
+			return err
+		}
+
+		if block == nil {
+			return fmt.Errorf("expected %d blocks in state snapshot but found %d", metadata.Blocks, i)
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	if len(blocks) == 0 || blocks[len(blocks)-1].Hash() != metadata.Hash {
+		return fmt.Errorf("state snapshot doesn't contain block %s", metadata.Hash)
+	}
+
+	importer := itrie.NewStateImporter(stateStorage)
+
+	var accounts uint64
+
+	for {
+		account, err := stream.nextDumpAccount()
+		if err != nil {
+			return err
+		}
+
+		if account == nil {
+			break
+		}
+
+		if err := importer.Import(account); err != nil {
+			return err
+		}
+
+		if accounts++; accounts%stateSnapshotLogInterval == 0 {
+			logger.Info("Imported accounts from state snapshot", "accounts", accounts)
+		}
+	}
+
+	root, err := importer.Commit()
+	if err != nil {
+		return err
+	}
+
+	if root != metadata.StateRoot || root != blocks[len(blocks)-1].Header.StateRoot {
+		return fmt.Errorf("%w: state root %s doesn't match expected %s", itrie.ErrInvalidStateDump, root, metadata.StateRoot)
+	}
+
+	if err := chain.WriteSnapshotBlocks(headers, blocks, metadata.TotalDifficulty); err != nil {
+		return err
+	}
+
+	logger.Info("Restored state snapshot", "number", metadata.Number, "hash", metadata.Hash, "accounts", accounts)
+
+	return nil
+}
+
+// getStateSnapshotMetadata consumes some bytes from input and returns parsed StateSnapshotMetadata
+func (b *blockStream) getStateSnapshotMetadata() (*StateSnapshotMetadata, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	metadata := &StateSnapshotMetadata{}
+	if err := metadata.UnmarshalRLP(b.buffer[:size]); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// nextHeader consumes some bytes from input and returns parsed header
+func (b *blockStream) nextHeader() (*types.Header, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	header := &types.Header{}
+	if err := header.UnmarshalRLP(b.buffer[:size]); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// nextDumpAccount consumes some bytes from input and returns parsed account of the state dump
+func (b *blockStream) nextDumpAccount() (*itrie.DumpAccount, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	account := &itrie.DumpAccount{}
+	if err := types.UnmarshalRlp(account.UnmarshalRLPFrom, b.buffer[:size]); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
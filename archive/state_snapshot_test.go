@@ -0,0 +1,231 @@
+package archive
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/blockchain/storage"
+	"github.com/vishnushankarsg/metad/blockchain/storage/memory"
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/state"
+	itrie "github.com/vishnushankarsg/metad/state/immutable-trie"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+type mockSnapshotChain struct {
+	genesis types.Hash
+	head    *types.Header
+	headers []*types.Header
+	blocks  []*types.Block
+	td      *big.Int
+}
+
+func (m *mockSnapshotChain) Genesis() types.Hash {
+	return m.genesis
+}
+
+func (m *mockSnapshotChain) Header() *types.Header {
+	return m.head
+}
+
+func (m *mockSnapshotChain) GetHeaderByNumber(num uint64) (*types.Header, bool) {
+	for _, b := range m.blocks {
+		if b.Number() == num {
+			return b.Header, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m *mockSnapshotChain) WriteSnapshotBlocks(headers []*types.Header, blocks []*types.Block, td *big.Int) error {
+	m.headers = headers
+	m.blocks = blocks
+	m.head = blocks[len(blocks)-1].Header
+	m.td = td
+
+	return nil
+}
+
+// newTestSnapshotChain writes the chain of the given length, with the state of each block
+// changing the balance of the account, and returns the blockchain storage and the state storage
+func newTestSnapshotChain(t *testing.T, length uint64) (storage.Storage, itrie.Storage) {
+	t.Helper()
+
+	db, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	stateStorage := itrie.NewMemoryStorage()
+	st := itrie.NewState(stateStorage)
+
+	var (
+		parent = types.ZeroHash
+		root   = types.EmptyRootHash
+		td     = big.NewInt(0)
+	)
+
+	for i := uint64(0); i < length; i++ {
+		snap, err := st.NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		_, rootBytes := snap.Commit([]*state.Object{
+			{
+				Address:  types.StringToAddress("1"),
+				Balance:  new(big.Int).SetUint64(i),
+				Root:     types.EmptyRootHash,
+				CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+				Storage: []*state.StorageObject{
+					{Key: types.StringToHash("1").Bytes(), Val: types.BytesToHash(big.NewInt(int64(i + 1)).Bytes()).Bytes()},
+				},
+			},
+		})
+		root = types.BytesToHash(rootBytes)
+
+		header := &types.Header{
+			Number:       i,
+			ParentHash:   parent,
+			StateRoot:    root,
+			Difficulty:   1,
+			TxRoot:       types.EmptyRootHash,
+			ReceiptsRoot: types.EmptyRootHash,
+			Sha3Uncles:   types.EmptyUncleHash,
+		}
+		header.ComputeHash()
+
+		td.Add(td, big.NewInt(1))
+
+		require.NoError(t, db.WriteHeader(header))
+		require.NoError(t, db.WriteCanonicalHash(i, header.Hash))
+		require.NoError(t, db.WriteTotalDifficulty(header.Hash, td))
+		require.NoError(t, db.WriteBody(header.Hash, &types.Body{}))
+		require.NoError(t, db.WriteHeadHash(header.Hash))
+		require.NoError(t, db.WriteHeadNumber(i))
+
+		parent = header.Hash
+	}
+
+	return db, stateStorage
+}
+
+func TestStateSnapshot_CreateRestore(t *testing.T) {
+	t.Parallel()
+
+	db, stateStorage := newTestSnapshotChain(t, 300)
+	logger := hclog.NewNullLogger()
+
+	genesis, ok := db.ReadCanonicalHash(0)
+	require.True(t, ok)
+
+	number := uint64(280)
+	outPath := filepath.Join(t.TempDir(), "state.snap")
+
+	metadata, accounts, err := CreateStateSnapshot(db, stateStorage, logger, &number, 100, outPath)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), accounts)
+	assert.Equal(t, number, metadata.Number)
+	assert.Equal(t, genesis, metadata.Genesis)
+	assert.Equal(t, big.NewInt(281), metadata.TotalDifficulty)
+	assert.Equal(t, uint64(stateSnapshotBlocks), metadata.Blocks)
+	// the blocks start at 25, the headers from the start of the epoch, excluding the genesis
+	assert.Equal(t, uint64(24), metadata.Headers)
+
+	// the file already exists
+	_, _, err = CreateStateSnapshot(db, stateStorage, logger, &number, 100, outPath)
+	assert.Error(t, err)
+
+	t.Run("should restore the state and the blocks", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockSnapshotChain{genesis: genesis, head: &types.Header{Number: 0}}
+		newStateStorage := itrie.NewMemoryStorage()
+
+		require.NoError(t, RestoreStateSnapshot(chain, newStateStorage, logger, outPath))
+
+		require.Len(t, chain.headers, 24)
+		require.Len(t, chain.blocks, stateSnapshotBlocks)
+		assert.Equal(t, uint64(1), chain.headers[0].Number)
+		assert.Equal(t, chain.headers[23].Hash, chain.blocks[0].Header.ParentHash)
+		assert.Equal(t, metadata.Hash, chain.head.Hash)
+		assert.Equal(t, metadata.TotalDifficulty, chain.td)
+
+		snap, err := itrie.NewState(newStateStorage).NewSnapshotAt(metadata.StateRoot)
+		require.NoError(t, err)
+
+		account, err := snap.GetAccount(types.StringToAddress("1"))
+		require.NoError(t, err)
+		assert.Equal(t, new(big.Int).SetUint64(number), account.Balance)
+		assert.Equal(t,
+			types.BytesToHash(big.NewInt(int64(number+1)).Bytes()),
+			snap.GetStorage(types.StringToAddress("1"), account.Root, types.StringToHash("1")),
+		)
+
+		// restoring the same snapshot again is a no-op
+		require.NoError(t, RestoreStateSnapshot(chain, newStateStorage, logger, outPath))
+	})
+
+	t.Run("should not restore to the chain which isn't empty", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockSnapshotChain{genesis: genesis, head: &types.Header{Number: 1}}
+
+		err := RestoreStateSnapshot(chain, itrie.NewMemoryStorage(), logger, outPath)
+		assert.ErrorIs(t, err, errStateSnapshotChainNotEmpty)
+	})
+
+	t.Run("should not restore to the chain with different genesis", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockSnapshotChain{genesis: types.StringToHash("1"), head: &types.Header{Number: 0}}
+
+		assert.Error(t, RestoreStateSnapshot(chain, itrie.NewMemoryStorage(), logger, outPath))
+	})
+}
+
+func TestStateSnapshot_CreateMissingBlock(t *testing.T) {
+	t.Parallel()
+
+	db, stateStorage := newTestSnapshotChain(t, 5)
+
+	number := uint64(10)
+
+	_, _, err := CreateStateSnapshot(db, stateStorage, hclog.NewNullLogger(), &number, 0,
+		filepath.Join(t.TempDir(), "state.snap"))
+	assert.Error(t, err)
+}
+
+func TestStateSnapshot_CreateEpochHeaders(t *testing.T) {
+	t.Parallel()
+
+	db, stateStorage := newTestSnapshotChain(t, 300)
+
+	number := uint64(280)
+
+	testTable := []struct {
+		name      string
+		epochSize uint64
+		headers   uint64
+	}{
+		{"no epochs", 0, 0},
+		{"epoch starting before the blocks", 10, 5},
+		{"epoch starting with the blocks", 25, 0},
+		{"epoch starting at the genesis", 1000, 24},
+	}
+
+	for _, test := range testTable {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			metadata, _, err := CreateStateSnapshot(db, stateStorage, hclog.NewNullLogger(), &number,
+				test.epochSize, filepath.Join(t.TempDir(), "state.snap"))
+			require.NoError(t, err)
+			assert.Equal(t, test.headers, metadata.Headers)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package archive
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/umbracle/fastrlp"
@@ -59,3 +60,95 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	return nil
 }
+
+// StateSnapshotMetadata is the data stored in the beginning of the state snapshot
+type StateSnapshotMetadata struct {
+	// Genesis is the hash of the genesis block of the chain
+	Genesis types.Hash
+	// Number and Hash are of the block the state was taken at
+	Number uint64
+	Hash   types.Hash
+	// StateRoot is the root of the state of the block
+	StateRoot types.Hash
+	// TotalDifficulty is the total difficulty of the block
+	TotalDifficulty *big.Int
+	// Blocks is the number of the blocks preceding the accounts in the snapshot, ending with the block itself
+	Blocks uint64
+	// Headers is the number of the headers preceding the blocks in the snapshot,
+	// starting with the header of the epoch start, so the validator set can be rebuilt
+	Headers uint64
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (m *StateSnapshotMetadata) MarshalRLP() []byte {
+	return m.MarshalRLPTo(nil)
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (m *StateSnapshotMetadata) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(m.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (m *StateSnapshotMetadata) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(m.Genesis.Bytes()))
+	vv.Set(arena.NewUint(m.Number))
+	vv.Set(arena.NewBytes(m.Hash.Bytes()))
+	vv.Set(arena.NewBytes(m.StateRoot.Bytes()))
+	vv.Set(arena.NewBigInt(m.TotalDifficulty))
+	vv.Set(arena.NewUint(m.Blocks))
+	vv.Set(arena.NewUint(m.Headers))
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (m *StateSnapshotMetadata) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(m.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (m *StateSnapshotMetadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 7 {
+		return fmt.Errorf("incorrect number of elements to decode StateSnapshotMetadata, expected 7 but found %d",
+			len(elems))
+	}
+
+	if err = elems[0].GetHash(m.Genesis[:]); err != nil {
+		return err
+	}
+
+	if m.Number, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetHash(m.Hash[:]); err != nil {
+		return err
+	}
+
+	if err = elems[3].GetHash(m.StateRoot[:]); err != nil {
+		return err
+	}
+
+	m.TotalDifficulty = new(big.Int)
+	if err = elems[4].GetBigInt(m.TotalDifficulty); err != nil {
+		return err
+	}
+
+	if m.Blocks, err = elems[5].GetUint64(); err != nil {
+		return err
+	}
+
+	if m.Headers, err = elems[6].GetUint64(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -19,8 +19,10 @@ import (
 )
 
 const (
-	BlockGasTargetDivisor uint64 = 1024 // The bound divisor of the gas limit, used in update calculations
-	defaultCacheSize      int    = 100  // The default size for Blockchain LRU cache structures
+	BlockGasTargetDivisor    uint64 = 1024 // The bound divisor of the gas limit, used in update calculations
+	BaseFeeChangeDenominator uint64 = 8    // The bound divisor of the base fee, used in update calculations (EIP-1559)
+	ElasticityMultiplier     uint64 = 2    // The bound of the block gas usage relative to the gas target (EIP-1559)
+	defaultCacheSize         int    = 100  // The default size for Blockchain LRU cache structures
 )
 
 var (
@@ -35,6 +37,10 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrInvalidBaseFee       = errors.New("invalid block base fee")
+	ErrInvalidGasLimit      = errors.New("invalid block gas limit")
+	ErrInvalidHeader        = errors.New("invalid block header")
+	ErrInvalidTD            = errors.New("invalid total difficulty")
 )
 
 // Blockchain is a blockchain reference
@@ -70,6 +76,8 @@ type Blockchain struct {
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
+	txSenderIndex bool // Flag indicating if the transactions are indexed by their sender and nonce
+
 	writeLock sync.Mutex
 }
 
@@ -390,6 +398,65 @@ func (b *Blockchain) calculateGasLimit(parentGasLimit uint64) uint64 {
 	return common.Max(blockGasTarget, common.Max(parentGasLimit-delta, 0))
 }
 
+// CalculateBaseFee returns the base fee of the block built on top of the parent (EIP-1559).
+// The gas target is derived from the block gas target, or the parent gas limit if it is not set
+func (b *Blockchain) CalculateBaseFee(parent *types.Header) uint64 {
+	// There is no base fee before the EIP-1559 fork
+	if forks := b.config.Params.Forks; forks == nil || !forks.IsEIP1559(parent.Number+1) {
+		return 0
+	}
+
+	// The first EIP-1559 block starts with the initial base fee
+	if parent.BaseFee == 0 {
+		if b.config.Genesis.BaseFee != 0 {
+			return b.config.Genesis.BaseFee
+		}
+
+		return chain.GenesisBaseFee
+	}
+
+	parentGasTarget := b.calculateGasTarget(parent.GasLimit)
+	if parentGasTarget == 0 || parent.GasUsed == parentGasTarget {
+		// The parent block used exactly the gas target, the base fee remains unchanged
+		return parent.BaseFee
+	}
+
+	if parent.GasUsed > parentGasTarget {
+		// The parent block used more gas than its target, the base fee should increase
+		baseFeeDelta := calcBaseFeeDelta(parent.GasUsed-parentGasTarget, parentGasTarget, parent.BaseFee)
+
+		return parent.BaseFee + common.Max(baseFeeDelta, 1)
+	}
+
+	// The parent block used less gas than its target, the base fee should decrease.
+	// It never drops to zero, so the post-EIP-1559 headers always carry it
+	baseFeeDelta := calcBaseFeeDelta(parentGasTarget-parent.GasUsed, parentGasTarget, parent.BaseFee)
+	if baseFeeDelta >= parent.BaseFee {
+		return 1
+	}
+
+	return parent.BaseFee - baseFeeDelta
+}
+
+// calculateGasTarget returns the gas usage target of a block with the given gas limit
+func (b *Blockchain) calculateGasTarget(gasLimit uint64) uint64 {
+	if blockGasTarget := b.Config().BlockGasTarget; blockGasTarget != 0 {
+		gasLimit = common.Min(gasLimit, blockGasTarget)
+	}
+
+	return gasLimit / ElasticityMultiplier
+}
+
+// calcBaseFeeDelta calculates the base fee change, baseFee * gasUsedDelta / gasTarget / changeDenominator
+func calcBaseFeeDelta(gasUsedDelta, parentGasTarget, baseFee uint64) uint64 {
+	delta := new(big.Int).SetUint64(baseFee)
+	delta.Mul(delta, new(big.Int).SetUint64(gasUsedDelta))
+	delta.Div(delta, new(big.Int).SetUint64(parentGasTarget))
+	delta.Div(delta, new(big.Int).SetUint64(BaseFeeChangeDenominator))
+
+	return delta.Uint64()
+}
+
 // writeGenesis wrapper for the genesis write function
 func (b *Blockchain) writeGenesis(genesis *chain.Genesis) error {
 	header := genesis.GenesisHeader()
@@ -652,6 +719,136 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 	return nil
 }
 
+// WriteSnapshotBlocks writes the headers and the latest blocks of the imported state snapshot,
+// ending with its head, without executing the blocks. The state of the head block has to be imported already,
+// and the chain can't have any blocks except the genesis.
+// The first header is the trusted anchor of the snapshot, the start of the epoch the validator set
+// is rebuilt from. Each following header is verified by the consensus against its parent before it's written,
+// and the transactions and the uncles of the blocks have to be the ones their headers commit to
+func (b *Blockchain) WriteSnapshotBlocks(headers []*types.Header, blocks []*types.Block, headTD *big.Int) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if len(blocks) == 0 {
+		return ErrNoBlock
+	}
+
+	if b.Header().Number != 0 {
+		return errors.New("state snapshot can only be written to the empty chain")
+	}
+
+	all := make([]*types.Header, 0, len(headers)+len(blocks))
+	all = append(all, headers...)
+
+	for _, block := range blocks {
+		all = append(all, block.Header)
+	}
+
+	for i := 1; i < len(all); i++ {
+		if all[i].Number-1 != all[i-1].Number {
+			return ErrInvalidBlockSequence
+		}
+
+		if all[i].ParentHash != all[i-1].Hash {
+			return ErrParentHashMismatch
+		}
+	}
+
+	// the bodies are served by the peers, only the headers are authenticated
+	for _, block := range blocks {
+		if err := b.verifyBodyRoots(block); err != nil {
+			return fmt.Errorf("invalid body of block %d: %w", block.Number(), err)
+		}
+	}
+
+	// the total difficulties of the previous headers are derived from the one of the head
+	tds := make([]*big.Int, len(all))
+	td := new(big.Int).Set(headTD)
+
+	for i := len(all) - 1; i >= 0; i-- {
+		tds[i] = new(big.Int).Set(td)
+		td.Sub(td, new(big.Int).SetUint64(all[i].Difficulty))
+	}
+
+	// what is left is the total difficulty of the parent of the first header,
+	// which can't be lower than the one of the genesis, the head of the empty chain
+	if all[0].Number == 0 {
+		if td.Sign() != 0 {
+			return ErrInvalidTD
+		}
+	} else if genesisTD := b.CurrentTD(); genesisTD == nil || td.Cmp(genesisTD) < 0 {
+		return ErrInvalidTD
+	}
+
+	for i, header := range all {
+		if header.Number == 0 {
+			if header.Hash != b.genesis {
+				return fmt.Errorf("the hash of genesis block (%s) does not match blockchain genesis (%s)",
+					header.Hash, b.genesis)
+			}
+
+			continue
+		}
+
+		// the anchor has no parent in the chain, unless it follows the genesis
+		if i > 0 || header.Number == 1 {
+			if err := b.consensus.VerifyHeader(header); err != nil {
+				return fmt.Errorf("failed to verify the header %d: %w", header.Number, err)
+			}
+		}
+
+		if err := b.db.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if err := b.db.WriteTotalDifficulty(header.Hash, tds[i]); err != nil {
+			return err
+		}
+
+		if err := b.db.WriteCanonicalHash(header.Number, header.Hash); err != nil {
+			return err
+		}
+
+		// the consensus keeps track of the validator set
+		if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
+			return err
+		}
+	}
+
+	for _, block := range blocks {
+		if block.Number() == 0 {
+			continue
+		}
+
+		if err := b.writeBody(block); err != nil {
+			return err
+		}
+	}
+
+	head := all[len(all)-1]
+
+	if err := b.db.WriteHeadHash(head.Hash); err != nil {
+		return err
+	}
+
+	if err := b.db.WriteHeadNumber(head.Number); err != nil {
+		return err
+	}
+
+	b.setCurrentHeader(head, tds[len(tds)-1])
+
+	evnt := &Event{Type: EventHead, Source: "snapshot"}
+	evnt.AddNewHeader(head)
+	evnt.SetDifficulty(tds[len(tds)-1])
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Info("state snapshot blocks written", "head", head.Number, "hash", head.Hash,
+		"headers", len(headers), "blocks", len(blocks))
+
+	return nil
+}
+
 // VerifyPotentialBlock does the minimal block verification without consulting the
 // consensus layer. Should only be used if consensus checks are done
 // outside the method call
@@ -665,9 +862,14 @@ func (b *Blockchain) VerifyPotentialBlock(block *types.Block) error {
 // VerifyFinalizedBlock verifies that the block is valid by performing a series of checks.
 // It is assumed that the block status is sealed (committed)
 func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error) {
+	// The header can't be verified without its parent
+	if _, ok := b.readHeader(block.ParentHash()); !ok {
+		return nil, ErrParentNotFound
+	}
+
 	// Make sure the consensus layer verifies this block header
 	if err := b.consensus.VerifyHeader(block.Header); err != nil {
-		return nil, fmt.Errorf("failed to verify the header: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHeader, err)
 	}
 
 	// Do the initial block verification
@@ -707,6 +909,7 @@ func (b *Blockchain) verifyBlock(block *types.Block) ([]*types.Receipt, error) {
 // - The hashes match up
 // - The block numbers match up
 // - The block gas limit / used matches up
+// - The block base fee matches up
 func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 	// Grab the parent block
 	parentHash := childBlock.ParentHash()
@@ -746,7 +949,12 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 
 	// Make sure the gas limit is within correct bounds
 	if gasLimitErr := b.verifyGasLimit(childBlock.Header, parent); gasLimitErr != nil {
-		return fmt.Errorf("invalid gas limit, %w", gasLimitErr)
+		return fmt.Errorf("%w, %v", ErrInvalidGasLimit, gasLimitErr)
+	}
+
+	// Make sure the base fee is calculated correctly
+	if expectedBaseFee := b.CalculateBaseFee(parent); childBlock.Header.BaseFee != expectedBaseFee {
+		return fmt.Errorf("%w: have %d, want %d", ErrInvalidBaseFee, childBlock.Header.BaseFee, expectedBaseFee)
 	}
 
 	return nil
@@ -757,6 +965,27 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 // - The receipts match up
 // - The execution result matches up
 func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, error) {
+	if err := b.verifyBodyRoots(block); err != nil {
+		return nil, err
+	}
+
+	// Execute the transactions in the block and grab the result
+	blockResult, executeErr := b.executeBlockTransactions(block)
+	if executeErr != nil {
+		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	}
+
+	// Verify the local execution result with the proposed block data
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return blockResult.Receipts, nil
+}
+
+// verifyBodyRoots verifies that the uncles and the transactions of the block
+// are the ones its header commits to
+func (b *Blockchain) verifyBodyRoots(block *types.Block) error {
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -765,7 +994,7 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.Sha3Uncles,
 		))
 
-		return nil, ErrInvalidSha3Uncles
+		return ErrInvalidSha3Uncles
 	}
 
 	// Make sure the transactions root matches up
@@ -776,21 +1005,10 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.TxRoot,
 		))
 
-		return nil, ErrInvalidTxRoot
-	}
-
-	// Execute the transactions in the block and grab the result
-	blockResult, executeErr := b.executeBlockTransactions(block)
-	if executeErr != nil {
-		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
-	}
-
-	// Verify the local execution result with the proposed block data
-	if err := blockResult.verifyBlockResult(block); err != nil {
-		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+		return ErrInvalidTxRoot
 	}
 
-	return blockResult.Receipts, nil
+	return nil
 }
 
 // verifyBlockResult verifies that the block transaction execution result
@@ -1031,16 +1249,25 @@ func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
 		return
 	}
 
+	baseFee := new(big.Int).SetUint64(block.Header.BaseFee)
+
 	gasPrices := make([]*big.Int, len(block.Transactions))
 	for i, transaction := range block.Transactions {
-		gasPrices[i] = transaction.GasPrice
+		gasPrices[i] = transaction.EffectiveGasPrice(baseFee)
 	}
 
 	b.updateGasPriceAvg(gasPrices)
 }
 
+// EnableTxSenderIndex enables the index of the transactions by their sender and nonce
+// for the blocks written from now on
+func (b *Blockchain) EnableTxSenderIndex() {
+	b.txSenderIndex = true
+}
+
 // writeBody writes the block body to the DB.
-// Additionally, it also updates the txn lookup, for txnHash -> block lookups
+// Additionally, it also updates the txn lookup, for txnHash -> block lookups,
+// and the sender lookup, for (sender, nonce) -> txnHash lookups (if enabled)
 func (b *Blockchain) writeBody(block *types.Block) error {
 	// Recover 'from' field in tx before saving
 	// Because the block passed from the consensus layer doesn't have from field in tx,
@@ -1059,6 +1286,15 @@ func (b *Blockchain) writeBody(block *types.Block) error {
 		if err := b.db.WriteTxLookup(txn.Hash, block.Hash()); err != nil {
 			return err
 		}
+
+		// state transactions have no sender, the others are never indexed under the zero address
+		if !b.txSenderIndex || txn.Type == types.StateTx || txn.From == types.ZeroAddress {
+			continue
+		}
+
+		if err := b.db.WriteTxSenderLookup(txn.From, txn.Nonce, txn.Hash); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -1071,6 +1307,12 @@ func (b *Blockchain) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return v, ok
 }
 
+// ReadTxSenderLookup returns the hash of the transaction of the given sender and nonce.
+// The transactions are only indexed if the sender index is enabled
+func (b *Blockchain) ReadTxSenderLookup(sender types.Address, nonce uint64) (types.Hash, bool) {
+	return b.db.ReadTxSenderLookup(sender, nonce)
+}
+
 // recoverFromFieldsInBlock recovers 'from' fields in the transactions of the given block
 // return error if the invalid signature found
 func (b *Blockchain) recoverFromFieldsInBlock(block *types.Block) error {
@@ -7,15 +7,18 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/vishnushankarsg/metad/state"
 	"github.com/hashicorp/go-hclog"
+	"github.com/vishnushankarsg/metad/state"
 
-	"github.com/vishnushankarsg/metad/chain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnushankarsg/metad/chain"
 
 	"github.com/vishnushankarsg/metad/blockchain/storage"
 	"github.com/vishnushankarsg/metad/blockchain/storage/memory"
+	itrie "github.com/vishnushankarsg/metad/state/immutable-trie"
 	"github.com/vishnushankarsg/metad/types"
+	"github.com/vishnushankarsg/metad/types/buildroot"
 )
 
 func TestGenesis(t *testing.T) {
@@ -658,6 +661,53 @@ func TestBlockchainWriteBody(t *testing.T) {
 
 		assert.Equal(t, addr, readBody.Transactions[0].From)
 	})
+
+	t.Run("should index transactions by sender and nonce if enabled", func(t *testing.T) {
+		t.Parallel()
+
+		tx := &types.Transaction{
+			Nonce: 5,
+			Value: big.NewInt(10),
+			V:     big.NewInt(1),
+			From:  addr,
+		}
+
+		stateTx := &types.Transaction{
+			Type:  types.StateTx,
+			Nonce: 6,
+			V:     big.NewInt(1),
+		}
+
+		block := &types.Block{
+			Header: &types.Header{},
+			Transactions: []*types.Transaction{
+				tx,
+				stateTx,
+			},
+		}
+
+		tx.ComputeHash()
+		stateTx.ComputeHash()
+		block.Header.ComputeHash()
+
+		chain := newChain(t, map[types.Hash]types.Address{})
+
+		assert.NoError(t, chain.writeBody(block))
+
+		_, ok := chain.ReadTxSenderLookup(addr, 5)
+		assert.False(t, ok)
+
+		chain.EnableTxSenderIndex()
+
+		assert.NoError(t, chain.writeBody(block))
+
+		hash, ok := chain.ReadTxSenderLookup(addr, 5)
+		assert.True(t, ok)
+		assert.Equal(t, tx.Hash, hash)
+
+		_, ok = chain.ReadTxSenderLookup(types.ZeroAddress, 6)
+		assert.False(t, ok)
+	})
 }
 
 func Test_recoverFromFieldsInBlock(t *testing.T) {
@@ -966,6 +1016,77 @@ func TestCalculateGasLimit(t *testing.T) {
 	}
 }
 
+func TestCalculateBaseFee(t *testing.T) {
+	tests := []struct {
+		name            string
+		blockGasTarget  uint64
+		genesisBaseFee  uint64
+		eip1559Block    uint64
+		parent          *types.Header
+		expectedBaseFee uint64
+	}{
+		{
+			name:            "should not have base fee before the EIP-1559 fork",
+			eip1559Block:    10,
+			parent:          &types.Header{Number: 5, GasLimit: 20000000},
+			expectedBaseFee: 0,
+		},
+		{
+			name:            "should start with the default base fee",
+			parent:          &types.Header{Number: 0, GasLimit: 20000000},
+			expectedBaseFee: chain.GenesisBaseFee,
+		},
+		{
+			name:            "should start with the genesis base fee",
+			genesisBaseFee:  500,
+			parent:          &types.Header{Number: 0, GasLimit: 20000000},
+			expectedBaseFee: 500,
+		},
+		{
+			name:            "should not alter base fee when the gas target is used",
+			parent:          &types.Header{Number: 1, GasLimit: 20000000, GasUsed: 10000000, BaseFee: 1000},
+			expectedBaseFee: 1000,
+		},
+		{
+			name:            "should increase base fee when the gas used is above the target",
+			parent:          &types.Header{Number: 1, GasLimit: 20000000, GasUsed: 20000000, BaseFee: 1000},
+			expectedBaseFee: 1125,
+		},
+		{
+			name:            "should decrease base fee when the gas used is below the target",
+			parent:          &types.Header{Number: 1, GasLimit: 20000000, GasUsed: 0, BaseFee: 1000},
+			expectedBaseFee: 875,
+		},
+		{
+			name:            "should derive the gas target from the block gas target",
+			blockGasTarget:  10000000,
+			parent:          &types.Header{Number: 1, GasLimit: 20000000, GasUsed: 5000000, BaseFee: 1000},
+			expectedBaseFee: 1000,
+		},
+		{
+			name:            "should not drop base fee to zero",
+			parent:          &types.Header{Number: 1, GasLimit: 20000000, GasUsed: 0, BaseFee: 1},
+			expectedBaseFee: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewTestBlockchain(t, nil)
+
+			b.config.Genesis.BaseFee = tt.genesisBaseFee
+			b.config.Params = &chain.Params{
+				Forks: &chain.Forks{
+					EIP1559: chain.NewFork(tt.eip1559Block),
+				},
+				BlockGasTarget: tt.blockGasTarget,
+			}
+
+			assert.Equal(t, tt.expectedBaseFee, b.CalculateBaseFee(tt.parent))
+		})
+	}
+}
+
 // TestGasPriceAverage tests the average gas price of the
 // blockchain
 func TestGasPriceAverage(t *testing.T) {
@@ -1335,3 +1456,353 @@ func TestBlockchain_VerifyBlockBody(t *testing.T) {
 		assert.ErrorIs(t, err, errUnableToExecute)
 	})
 }
+
+func TestBlockchain_WriteSnapshotBlocks(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+
+	// the total difficulty of the head is the sum of the difficulties of all the blocks
+	headTD := big.NewInt(0)
+	for _, header := range headers {
+		headTD.Add(headTD, new(big.Int).SetUint64(header.Difficulty))
+	}
+
+	t.Run("should write the headers and the blocks as the head of the empty chain", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		_, err := b.advanceHead(headers[0])
+		require.NoError(t, err)
+
+		verified := []uint64{}
+		processed := []uint64{}
+
+		verifier, ok := b.consensus.(*MockVerifier)
+		require.True(t, ok)
+
+		verifier.HookVerifyHeader(func(header *types.Header) error {
+			// the parent is written before the header is verified
+			_, ok := b.GetHeaderByNumber(header.Number - 1)
+			require.True(t, ok)
+
+			verified = append(verified, header.Number)
+
+			return nil
+		})
+		verifier.HookProcessHeaders(func(headers []*types.Header) error {
+			for _, header := range headers {
+				processed = append(processed, header.Number)
+			}
+
+			return nil
+		})
+
+		require.NoError(t, b.WriteSnapshotBlocks(headers[3:5], HeadersToBlocks(headers[5:]), headTD))
+
+		assert.Equal(t, headers[9].Hash, b.Header().Hash)
+		assert.Equal(t, headTD, b.CurrentTD())
+
+		// all the headers except the anchor are verified
+		assert.Equal(t, []uint64{4, 5, 6, 7, 8, 9}, verified)
+		assert.Equal(t, []uint64{3, 4, 5, 6, 7, 8, 9}, processed)
+
+		for _, header := range headers[3:] {
+			h, ok := b.GetHeaderByNumber(header.Number)
+			require.True(t, ok)
+			assert.Equal(t, header.Hash, h.Hash)
+
+			td, ok := b.GetTD(header.Hash)
+			require.True(t, ok)
+			// the difficulty of the header equals its number
+			assert.Equal(t, new(big.Int).SetUint64(header.Number*(header.Number+1)/2), td)
+		}
+
+		// the chain isn't empty anymore
+		assert.Error(t, b.WriteSnapshotBlocks(nil, HeadersToBlocks(headers[5:]), headTD))
+	})
+
+	t.Run("should index the transactions by the recovered sender", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+		b.EnableTxSenderIndex()
+
+		_, err := b.advanceHead(headers[0])
+		require.NoError(t, err)
+
+		// the transactions of the snapshot are decoded without the sender
+		sender := types.StringToAddress("1")
+		tx := (&types.Transaction{Nonce: 3, Value: big.NewInt(1), V: big.NewInt(1)}).ComputeHash()
+
+		b.txSigner = &mockSigner{txFromByTxHash: map[types.Hash]types.Address{tx.Hash: sender}}
+
+		// the head block carries the transaction, its header commits to it
+		head := headers[9].Copy()
+		head.TxRoot = buildroot.CalculateTransactionsRoot([]*types.Transaction{tx})
+		head.ComputeHash()
+
+		blocks := HeadersToBlocks(append(append([]*types.Header{}, headers[5:9]...), head))
+		blocks[len(blocks)-1].Transactions = []*types.Transaction{tx}
+
+		require.NoError(t, b.WriteSnapshotBlocks(headers[3:5], blocks, headTD))
+
+		hash, ok := b.ReadTxSenderLookup(sender, 3)
+		assert.True(t, ok)
+		assert.Equal(t, tx.Hash, hash)
+
+		_, ok = b.ReadTxSenderLookup(types.ZeroAddress, 3)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject the body not matching its header", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		_, err := b.advanceHead(headers[0])
+		require.NoError(t, err)
+
+		// the transaction the header doesn't commit to
+		blocks := HeadersToBlocks(headers[5:])
+		blocks[2].Transactions = []*types.Transaction{
+			(&types.Transaction{Nonce: 1, Value: big.NewInt(1), V: big.NewInt(1)}).ComputeHash(),
+		}
+
+		assert.ErrorIs(t, b.WriteSnapshotBlocks(headers[3:5], blocks, headTD), ErrInvalidTxRoot)
+
+		// the uncle the header doesn't commit to
+		blocks = HeadersToBlocks(headers[5:])
+		blocks[2].Uncles = []*types.Header{headers[1]}
+
+		assert.ErrorIs(t, b.WriteSnapshotBlocks(headers[3:5], blocks, headTD), ErrInvalidSha3Uncles)
+
+		// nothing is written
+		assert.Equal(t, uint64(0), b.Header().Number)
+
+		_, ok := b.GetHeaderByNumber(4)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject the total difficulty the headers don't add up to", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		_, err := b.advanceHead(headers[0])
+		require.NoError(t, err)
+
+		// lower than the difficulties of the headers from the genesis
+		lowTD := new(big.Int).Sub(headTD, big.NewInt(4))
+		assert.ErrorIs(t, b.WriteSnapshotBlocks(headers[3:5], HeadersToBlocks(headers[5:]), lowTD), ErrInvalidTD)
+
+		// not the sum of the difficulties of the headers from the genesis
+		highTD := new(big.Int).Add(headTD, big.NewInt(1))
+		assert.ErrorIs(t, b.WriteSnapshotBlocks(nil, HeadersToBlocks(headers), highTD), ErrInvalidTD)
+
+		// nothing is written
+		assert.Equal(t, uint64(0), b.Header().Number)
+	})
+
+	t.Run("should reject the header the consensus fails to verify", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		_, err := b.advanceHead(headers[0])
+		require.NoError(t, err)
+
+		verifier, ok := b.consensus.(*MockVerifier)
+		require.True(t, ok)
+
+		errInvalidSeal := errors.New("invalid seal")
+
+		verifier.HookVerifyHeader(func(header *types.Header) error {
+			if header.Number == 7 {
+				return errInvalidSeal
+			}
+
+			return nil
+		})
+
+		assert.ErrorIs(t, b.WriteSnapshotBlocks(headers[3:5], HeadersToBlocks(headers[5:]), headTD), errInvalidSeal)
+
+		// the head isn't moved
+		assert.Equal(t, uint64(0), b.Header().Number)
+
+		_, ok = b.GetHeaderByNumber(7)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject the blocks not forming the chain", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		_, err := b.advanceHead(headers[0])
+		require.NoError(t, err)
+
+		blocks := HeadersToBlocks([]*types.Header{headers[5], headers[7]})
+		assert.ErrorIs(t, b.WriteSnapshotBlocks(nil, blocks, headTD), ErrInvalidBlockSequence)
+
+		// the headers have to be followed by the blocks
+		assert.ErrorIs(t, b.WriteSnapshotBlocks(headers[2:4], HeadersToBlocks(headers[5:]), headTD),
+			ErrInvalidBlockSequence)
+
+		assert.ErrorIs(t, b.WriteSnapshotBlocks(headers[3:5], nil, headTD), ErrNoBlock)
+	})
+}
+
+// TestBlockchain_ImportBlockBeforeEIP1559 makes sure the blocks of the existing chains,
+// which have the London fork enabled from the genesis but no EIP-1559 fork,
+// are still imported without any base fee and without burning the transaction fees
+func TestBlockchain_ImportBlockBeforeEIP1559(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("1")
+		receiver = types.StringToAddress("2")
+		coinbase = types.StringToAddress("3")
+		gasPrice = big.NewInt(10)
+	)
+
+	forks := *chain.AllForksEnabled
+	forks.EIP1559 = chain.NewFork(2)
+
+	params := &chain.Params{Forks: &forks}
+	executor := state.NewExecutor(params, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+
+	genesis := &chain.Genesis{
+		GasLimit: 5000000,
+		Alloc: map[types.Address]*chain.GenesisAccount{
+			sender: {Balance: big.NewInt(1000000000)},
+		},
+	}
+
+	genesisRoot, err := executor.WriteGenesis(genesis.Alloc, types.ZeroHash)
+	require.NoError(t, err)
+
+	genesis.StateRoot = genesisRoot
+
+	db, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	b, err := NewBlockchain(
+		hclog.NewNullLogger(),
+		db,
+		&chain.Chain{Genesis: genesis, Params: params},
+		&MockVerifier{},
+		executor,
+		&mockSigner{},
+	)
+	require.NoError(t, err)
+	require.NoError(t, b.ComputeGenesis())
+
+	executor.GetHash = b.GetHashHelper
+
+	// buildBlock builds the block on top of the head, the way the block producer did
+	buildBlock := func(baseFee uint64, txs []*types.Transaction) *types.Block {
+		t.Helper()
+
+		parent := b.Header()
+
+		block := &types.Block{
+			Header: &types.Header{
+				ParentHash: parent.Hash,
+				Number:     parent.Number + 1,
+				GasLimit:   parent.GasLimit,
+				Miner:      coinbase.Bytes(),
+				Sha3Uncles: types.EmptyUncleHash,
+				TxRoot:     buildroot.CalculateTransactionsRoot(txs),
+				BaseFee:    baseFee,
+			},
+			Transactions: txs,
+		}
+
+		txn, err := executor.ProcessBlock(parent.StateRoot, block, coinbase)
+		require.NoError(t, err)
+
+		_, root := txn.Commit()
+
+		block.Header.StateRoot = root
+		block.Header.GasUsed = txn.TotalGas()
+		block.Header.ReceiptsRoot = buildroot.CalculateReceiptsRoot(txn.Receipts())
+		block.Header.ComputeHash()
+
+		return block
+	}
+
+	// the block made before the EIP-1559 fork doesn't carry any base fee
+	preForkBlock := buildBlock(0, []*types.Transaction{
+		{
+			Nonce:    0,
+			From:     sender,
+			To:       &receiver,
+			Value:    big.NewInt(1),
+			Gas:      state.TxGas,
+			GasPrice: gasPrice,
+		},
+	})
+
+	fullBlock, err := b.VerifyFinalizedBlock(preForkBlock)
+	require.NoError(t, err)
+	require.NoError(t, b.WriteFullBlock(fullBlock, "test"))
+
+	// the whole fee is paid to the coinbase, nothing is burned
+	snap, err := executor.State().NewSnapshotAt(b.Header().StateRoot)
+	require.NoError(t, err)
+
+	coinbaseAccount, err := snap.GetAccount(coinbase)
+	require.NoError(t, err)
+	assert.Equal(t, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(state.TxGas)), coinbaseAccount.Balance)
+
+	// the first block after the fork has to carry the initial base fee
+	_, err = b.VerifyFinalizedBlock(buildBlock(0, nil))
+	assert.ErrorIs(t, err, ErrInvalidBaseFee)
+
+	_, err = b.VerifyFinalizedBlock(buildBlock(chain.GenesisBaseFee, nil))
+	assert.NoError(t, err)
+}
+
+func TestBlockchain_VerifyFinalizedBlock_Errors(t *testing.T) {
+	t.Parallel()
+
+	b := NewTestBlockchain(t, nil)
+	head := b.Header()
+
+	verifier, ok := b.consensus.(*MockVerifier)
+	require.True(t, ok)
+
+	verifyCalled := false
+
+	verifier.HookVerifyHeader(func(header *types.Header) error {
+		verifyCalled = true
+
+		return errors.New("invalid seal")
+	})
+
+	// the block with an unknown parent can't be judged, the consensus isn't asked
+	orphan := &types.Block{
+		Header: &types.Header{
+			ParentHash: types.StringToHash("unknown"),
+			Number:     head.Number + 2,
+		},
+	}
+
+	_, err := b.VerifyFinalizedBlock(orphan)
+	assert.ErrorIs(t, err, ErrParentNotFound)
+	assert.False(t, verifyCalled)
+
+	// the header rejected by the consensus is reported as invalid
+	block := &types.Block{
+		Header: &types.Header{
+			ParentHash: head.Hash,
+			Number:     head.Number + 1,
+		},
+	}
+
+	_, err = b.VerifyFinalizedBlock(block)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+	assert.True(t, verifyCalled)
+}
@@ -39,6 +39,9 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// TX_SENDER_LOOKUP_PREFIX is the prefix for transaction lookups by sender and nonce
+	TX_SENDER_LOOKUP_PREFIX = []byte("n")
 )
 
 // Sub-prefixes
@@ -267,6 +270,35 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// WriteTxSenderLookup maps the sender and the nonce of the transaction to its hash
+func (s *KeyValueStorage) WriteTxSenderLookup(sender types.Address, nonce uint64, hash types.Hash) error {
+	ar := &fastrlp.Arena{}
+	vr := ar.NewBytes(hash.Bytes())
+
+	return s.write2(TX_SENDER_LOOKUP_PREFIX, s.senderNonceKey(sender, nonce), vr)
+}
+
+// ReadTxSenderLookup reads the transaction hash using its sender and nonce
+func (s *KeyValueStorage) ReadTxSenderLookup(sender types.Address, nonce uint64) (types.Hash, bool) {
+	parser := &fastrlp.Parser{}
+
+	v := s.read2(TX_SENDER_LOOKUP_PREFIX, s.senderNonceKey(sender, nonce), parser)
+	if v == nil {
+		return types.Hash{}, false
+	}
+
+	hash, err := v.GetBytes(nil, 32)
+	if err != nil {
+		return types.Hash{}, false
+	}
+
+	return types.BytesToHash(hash), true
+}
+
+func (s *KeyValueStorage) senderNonceKey(sender types.Address, nonce uint64) []byte {
+	return append(sender.Bytes(), s.encodeUint(nonce)...)
+}
+
 // WRITE OPERATIONS //
 
 func (s *KeyValueStorage) writeRLP(p, k []byte, raw types.RLPMarshaler) error {
@@ -37,6 +37,9 @@ type Storage interface {
 	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
 
+	WriteTxSenderLookup(sender types.Address, nonce uint64, hash types.Hash) error
+	ReadTxSenderLookup(sender types.Address, nonce uint64) (types.Hash, bool)
+
 	Close() error
 }
 
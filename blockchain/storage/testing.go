@@ -48,6 +48,9 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("testReceipts", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("testTxSenderLookup", func(t *testing.T) {
+		testTxSenderLookup(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -442,6 +445,36 @@ func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	}
 }
 
+func testTxSenderLookup(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	if err := s.WriteTxSenderLookup(addr1, 1, hash1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.WriteTxSenderLookup(addr1, 2, hash2); err != nil {
+		t.Fatal(err)
+	}
+
+	for nonce, expected := range map[uint64]types.Hash{1: hash1, 2: hash2} {
+		hash, ok := s.ReadTxSenderLookup(addr1, nonce)
+		if !ok {
+			t.Fatalf("lookup of nonce %d not found", nonce)
+		}
+
+		if hash != expected {
+			t.Fatalf("lookup of nonce %d is incorrect", nonce)
+		}
+	}
+
+	if _, ok := s.ReadTxSenderLookup(addr2, 1); ok {
+		t.Fatal("lookup of unknown sender found")
+	}
+}
+
 // Storage delegators
 
 type readCanonicalHashDelegate func(uint64) (types.Hash, bool)
@@ -465,6 +498,8 @@ type writeReceiptsDelegate func(types.Hash, []*types.Receipt) error
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
 type writeTxLookupDelegate func(types.Hash, types.Hash) error
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type writeTxSenderLookupDelegate func(types.Address, uint64, types.Hash) error
+type readTxSenderLookupDelegate func(types.Address, uint64) (types.Hash, bool)
 type closeDelegate func() error
 
 type MockStorage struct {
@@ -487,6 +522,8 @@ type MockStorage struct {
 	readReceiptsFn         readReceiptsDelegate
 	writeTxLookupFn        writeTxLookupDelegate
 	readTxLookupFn         readTxLookupDelegate
+	writeTxSenderLookupFn  writeTxSenderLookupDelegate
+	readTxSenderLookupFn   readTxSenderLookupDelegate
 	closeFn                closeDelegate
 }
 
@@ -722,6 +759,30 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) WriteTxSenderLookup(sender types.Address, nonce uint64, hash types.Hash) error {
+	if m.writeTxSenderLookupFn != nil {
+		return m.writeTxSenderLookupFn(sender, nonce, hash)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteTxSenderLookup(fn writeTxSenderLookupDelegate) {
+	m.writeTxSenderLookupFn = fn
+}
+
+func (m *MockStorage) ReadTxSenderLookup(sender types.Address, nonce uint64) (types.Hash, bool) {
+	if m.readTxSenderLookupFn != nil {
+		return m.readTxSenderLookupFn(sender, nonce)
+	}
+
+	return types.Hash{}, true
+}
+
+func (m *MockStorage) HookReadTxSenderLookup(fn readTxSenderLookupDelegate) {
+	m.readTxSenderLookupFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
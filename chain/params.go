@@ -50,10 +50,12 @@ type Forks struct {
 	Constantinople *Fork `json:"constantinople,omitempty"`
 	Petersburg     *Fork `json:"petersburg,omitempty"`
 	Istanbul       *Fork `json:"istanbul,omitempty"`
+	Berlin         *Fork `json:"berlin,omitempty"`
 	London         *Fork `json:"london,omitempty"`
 	EIP150         *Fork `json:"EIP150,omitempty"`
 	EIP158         *Fork `json:"EIP158,omitempty"`
 	EIP155         *Fork `json:"EIP155,omitempty"`
+	EIP1559        *Fork `json:"EIP1559,omitempty"`
 }
 
 func (f *Forks) active(ff *Fork, block uint64) bool {
@@ -80,6 +82,10 @@ func (f *Forks) IsPetersburg(block uint64) bool {
 	return f.active(f.Petersburg, block)
 }
 
+func (f *Forks) IsBerlin(block uint64) bool {
+	return f.active(f.Berlin, block)
+}
+
 func (f *Forks) IsLondon(block uint64) bool {
 	return f.active(f.London, block)
 }
@@ -96,6 +102,13 @@ func (f *Forks) IsEIP155(block uint64) bool {
 	return f.active(f.EIP155, block)
 }
 
+// IsEIP1559 returns whether the dynamic fee market (EIP-1559) is active at the given block.
+// It is a separate fork from London, since the existing chains have London enabled from the genesis
+// and their historical blocks don't carry any base fee
+func (f *Forks) IsEIP1559(block uint64) bool {
+	return f.active(f.EIP1559, block)
+}
+
 func (f *Forks) At(block uint64) ForksInTime {
 	return ForksInTime{
 		Homestead:      f.active(f.Homestead, block),
@@ -103,10 +116,12 @@ func (f *Forks) At(block uint64) ForksInTime {
 		Constantinople: f.active(f.Constantinople, block),
 		Petersburg:     f.active(f.Petersburg, block),
 		Istanbul:       f.active(f.Istanbul, block),
+		Berlin:         f.active(f.Berlin, block),
 		London:         f.active(f.London, block),
 		EIP150:         f.active(f.EIP150, block),
 		EIP158:         f.active(f.EIP158, block),
 		EIP155:         f.active(f.EIP155, block),
+		EIP1559:        f.active(f.EIP1559, block),
 	}
 }
 
@@ -132,10 +147,12 @@ type ForksInTime struct {
 	Constantinople,
 	Petersburg,
 	Istanbul,
+	Berlin,
 	London,
 	EIP150,
 	EIP158,
-	EIP155 bool
+	EIP155,
+	EIP1559 bool
 }
 
 var AllForksEnabled = &Forks{
@@ -147,5 +164,7 @@ var AllForksEnabled = &Forks{
 	Constantinople: NewFork(0),
 	Petersburg:     NewFork(0),
 	Istanbul:       NewFork(0),
+	Berlin:         NewFork(0),
 	London:         NewFork(0),
+	EIP1559:        NewFork(0),
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/vishnushankarsg/metad/command"
 	"github.com/spf13/cobra"
 
+	"github.com/vishnushankarsg/metad/command/backup/statesnapshot"
 	"github.com/vishnushankarsg/metad/command/helper"
 )
 
@@ -20,6 +21,11 @@ func GetCommand() *cobra.Command {
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
 
+	backupCmd.AddCommand(
+		// backup state-snapshot
+		statesnapshot.GetCommand(),
+	)
+
 	return backupCmd
 }
 
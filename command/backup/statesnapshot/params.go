@@ -0,0 +1,117 @@
+package statesnapshot
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	"github.com/vishnushankarsg/metad/archive"
+	chainLeveldb "github.com/vishnushankarsg/metad/blockchain/storage/leveldb"
+	"github.com/vishnushankarsg/metad/command"
+	itrie "github.com/vishnushankarsg/metad/state/immutable-trie"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+const (
+	dataDirFlag   = "data-dir"
+	outFlag       = "out"
+	numberFlag    = "number"
+	epochSizeFlag = "epoch-size"
+)
+
+var (
+	params = &stateSnapshotParams{}
+)
+
+var (
+	errDecodeNumber = errors.New("unable to decode block number")
+)
+
+type stateSnapshotParams struct {
+	dataDir string
+	out     string
+
+	numberRaw string
+	number    *uint64
+	epochSize uint64
+
+	metadata *archive.StateSnapshotMetadata
+	accounts uint64
+}
+
+func (p *stateSnapshotParams) validateFlags() error {
+	if p.numberRaw == "" {
+		return nil
+	}
+
+	number, err := types.ParseUint64orHex(&p.numberRaw)
+	if err != nil {
+		return errDecodeNumber
+	}
+
+	p.number = &number
+
+	return nil
+}
+
+func (p *stateSnapshotParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		outFlag,
+	}
+}
+
+func (p *stateSnapshotParams) createStateSnapshot() (err error) {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "state-snapshot",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	db, err := chainLeveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if closeErr := db.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	trieDB, err := leveldb.OpenFile(filepath.Join(p.dataDir, "trie"), &opt.Options{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+
+	stateStorage := itrie.NewKV(trieDB)
+
+	defer func() {
+		if closeErr := stateStorage.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	p.metadata, p.accounts, err = archive.CreateStateSnapshot(
+		db,
+		stateStorage,
+		logger,
+		p.number,
+		p.epochSize,
+		p.out,
+	)
+
+	return err
+}
+
+func (p *stateSnapshotParams) getResult() command.CommandResult {
+	return &StateSnapshotResult{
+		Number:    p.metadata.Number,
+		Hash:      p.metadata.Hash.String(),
+		StateRoot: p.metadata.StateRoot.String(),
+		Accounts:  p.accounts,
+		Out:       p.out,
+	}
+}
@@ -0,0 +1,32 @@
+package statesnapshot
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/vishnushankarsg/metad/command/helper"
+)
+
+type StateSnapshotResult struct {
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	StateRoot string `json:"state_root"`
+	Accounts  uint64 `json:"accounts"`
+	Out       string `json:"out"`
+}
+
+func (r *StateSnapshotResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE SNAPSHOT]\n")
+	buffer.WriteString("Exported state snapshot file successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("Block Number|%d", r.Number),
+		fmt.Sprintf("Block Hash|%s", r.Hash),
+		fmt.Sprintf("State Root|%s", r.StateRoot),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,72 @@
+package statesnapshot
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/vishnushankarsg/metad/consensus/ibft"
+)
+
+func GetCommand() *cobra.Command {
+	stateSnapshotCmd := &cobra.Command{
+		Use: "state-snapshot",
+		Short: "Create the state snapshot file from the data directory of the stopped node, " +
+			"which a new node can be bootstrapped from using the server --restore-state flag",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(stateSnapshotCmd)
+	helper.SetRequiredFlags(stateSnapshotCmd, params.getRequiredFlags())
+
+	return stateSnapshotCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the export path for the state snapshot",
+	)
+
+	cmd.Flags().StringVar(
+		&params.numberRaw,
+		numberFlag,
+		"",
+		"the height of the block the state is exported at (the latest block by default)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.epochSize,
+		epochSizeFlag,
+		ibft.DefaultEpochSize,
+		"the epoch size of the chain, the headers from the start of the epoch are exported "+
+			"so the validator set can be rebuilt (0 for no headers)",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.createStateSnapshot(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
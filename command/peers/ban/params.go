@@ -0,0 +1,70 @@
+package ban
+
+import (
+	"context"
+	"time"
+
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/vishnushankarsg/metad/server/proto"
+)
+
+var (
+	params = &banParams{}
+)
+
+const (
+	peerIDFlag   = "peer-id"
+	durationFlag = "duration"
+	reasonFlag   = "reason"
+)
+
+const (
+	defaultBanDuration = time.Hour
+	defaultBanReason   = "banned by the operator"
+)
+
+type banParams struct {
+	peerID   string
+	duration time.Duration
+	reason   string
+
+	bannedPeer *proto.BannedPeer
+}
+
+func (p *banParams) getRequiredFlags() []string {
+	return []string{
+		peerIDFlag,
+	}
+}
+
+func (p *banParams) banPeer(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	bannedPeer, err := systemClient.PeersBan(
+		context.Background(),
+		&proto.PeersBanRequest{
+			Id:       p.peerID,
+			Duration: uint64(p.duration / time.Second),
+			Reason:   p.reason,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.bannedPeer = bannedPeer
+
+	return nil
+}
+
+func (p *banParams) getResult() command.CommandResult {
+	return &PeersBanResult{
+		ID:     p.bannedPeer.Id,
+		Until:  time.Unix(p.bannedPeer.Until, 0).UTC(),
+		Reason: p.bannedPeer.Reason,
+	}
+}
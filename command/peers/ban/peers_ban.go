@@ -0,0 +1,56 @@
+package ban
+
+import (
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	peersBanCmd := &cobra.Command{
+		Use:   "ban",
+		Short: "Bans the specified peer for the duration, using the libp2p ID of the peer node",
+		Run:   runCommand,
+	}
+
+	setFlags(peersBanCmd)
+	helper.SetRequiredFlags(peersBanCmd, params.getRequiredFlags())
+
+	return peersBanCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.peerID,
+		peerIDFlag,
+		"",
+		"libp2p node ID of a specific peer within p2p network",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.duration,
+		durationFlag,
+		defaultBanDuration,
+		"the duration of the ban, the connections with the peer are refused until it expires",
+	)
+
+	cmd.Flags().StringVar(
+		&params.reason,
+		reasonFlag,
+		defaultBanReason,
+		"the reason of the ban",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.banPeer(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,29 @@
+package ban
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/vishnushankarsg/metad/command/helper"
+)
+
+type PeersBanResult struct {
+	ID     string    `json:"id"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason"`
+}
+
+func (r *PeersBanResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PEER BANNED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("ID|%s", r.ID),
+		fmt.Sprintf("Banned until|%s", r.Until.Format(time.RFC3339)),
+		fmt.Sprintf("Reason|%s", r.Reason),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,57 @@
+package listbanned
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/vishnushankarsg/metad/server/proto"
+)
+
+type BannedPeer struct {
+	ID     string    `json:"id"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason"`
+}
+
+type PeersListBannedResult struct {
+	Peers []BannedPeer `json:"peers"`
+}
+
+func newPeersListBannedResult(peers []*proto.BannedPeer) *PeersListBannedResult {
+	resultPeers := make([]BannedPeer, len(peers))
+	for i, p := range peers {
+		resultPeers[i] = BannedPeer{
+			ID:     p.Id,
+			Until:  time.Unix(p.Until, 0).UTC(),
+			Reason: p.Reason,
+		}
+	}
+
+	return &PeersListBannedResult{
+		Peers: resultPeers,
+	}
+}
+
+func (r *PeersListBannedResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BANNED PEERS]\n")
+
+	if len(r.Peers) == 0 {
+		buffer.WriteString("No banned peers")
+	} else {
+		buffer.WriteString(fmt.Sprintf("Number of banned peers: %d\n\n", len(r.Peers)))
+
+		rows := make([]string, len(r.Peers))
+		for i, p := range r.Peers {
+			rows[i] = fmt.Sprintf("[%d]|%s|until %s|%s", i, p.ID, p.Until.Format(time.RFC3339), p.Reason)
+		}
+		buffer.WriteString(helper.FormatKV(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -3,8 +3,11 @@ package peers
 import (
 	"github.com/vishnushankarsg/metad/command/helper"
 	"github.com/vishnushankarsg/metad/command/peers/add"
+	"github.com/vishnushankarsg/metad/command/peers/ban"
 	"github.com/vishnushankarsg/metad/command/peers/list"
+	"github.com/vishnushankarsg/metad/command/peers/listbanned"
 	"github.com/vishnushankarsg/metad/command/peers/status"
+	"github.com/vishnushankarsg/metad/command/peers/unban"
 	"github.com/spf13/cobra"
 )
 
@@ -29,5 +32,11 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		list.GetCommand(),
 		// peers add
 		add.GetCommand(),
+		// peers ban
+		ban.GetCommand(),
+		// peers unban
+		unban.GetCommand(),
+		// peers list-banned
+		listbanned.GetCommand(),
 	)
 }
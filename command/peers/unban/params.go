@@ -0,0 +1,49 @@
+package unban
+
+import (
+	"context"
+
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/vishnushankarsg/metad/server/proto"
+)
+
+var (
+	params = &unbanParams{}
+)
+
+const (
+	peerIDFlag = "peer-id"
+)
+
+type unbanParams struct {
+	peerID string
+}
+
+func (p *unbanParams) getRequiredFlags() []string {
+	return []string{
+		peerIDFlag,
+	}
+}
+
+func (p *unbanParams) unbanPeer(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	_, err = systemClient.PeersUnban(
+		context.Background(),
+		&proto.PeersUnbanRequest{
+			Id: p.peerID,
+		},
+	)
+
+	return err
+}
+
+func (p *unbanParams) getResult() command.CommandResult {
+	return &PeersUnbanResult{
+		ID: p.peerID,
+	}
+}
@@ -0,0 +1,24 @@
+package unban
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/vishnushankarsg/metad/command/helper"
+)
+
+type PeersUnbanResult struct {
+	ID string `json:"id"`
+}
+
+func (r *PeersUnbanResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PEER UNBANNED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("ID|%s", r.ID),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,24 @@
+package audit
+
+import (
+	"github.com/vishnushankarsg/metad/command/secrets/audit/verify"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Top level command for the audit log of the file vault secrets manager. Only accepts subcommands.",
+	}
+
+	registerSubcommands(auditCmd)
+
+	return auditCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// secrets audit verify
+		verify.GetCommand(),
+	)
+}
@@ -0,0 +1,65 @@
+package verify
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/secrets"
+)
+
+func GetCommand() *cobra.Command {
+	auditVerifyCmd := &cobra.Command{
+		Use: "verify",
+		Short: "Verifies the audit log of the file vault secrets manager is complete " +
+			"and none of its entries has been modified. Replacing the whole vault file by its older copy is not detected",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(auditVerifyCmd)
+
+	return auditVerifyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Metachain  data holding the default vault file",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file of the file vault",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the file containing the passphrase of the vault, "+
+			"if omitted, the passphrase is read from the "+secrets.PassphraseEnv+" environment variable or the terminal",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.verifyAuditLog(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,120 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/vishnushankarsg/metad/secrets/encryptedlocal"
+	"github.com/vishnushankarsg/metad/secrets/filevault"
+)
+
+const (
+	dataDirFlag        = "data-dir"
+	configFlag         = "config"
+	passphraseFileFlag = "passphrase-file"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+var (
+	errInvalidParams = errors.New("no config file or data directory passed in")
+)
+
+type verifyParams struct {
+	dataDir        string
+	configPath     string
+	passphraseFile string
+
+	vaultFile string
+	report    *filevault.AuditReport
+}
+
+func (vp *verifyParams) validateFlags() error {
+	if vp.dataDir == "" && vp.configPath == "" {
+		return errInvalidParams
+	}
+
+	return nil
+}
+
+// openVault opens the vault file configured by the config file, or the default one of the data directory
+func (vp *verifyParams) openVault() (*filevault.FileVaultSecretsManager, error) {
+	config := &secrets.SecretsManagerConfig{Type: secrets.FileVault}
+
+	if vp.configPath != "" {
+		var err error
+
+		if config, err = secrets.ReadConfig(vp.configPath); err != nil {
+			return nil, fmt.Errorf("unable to read config file, %w", err)
+		}
+
+		if config.Type != secrets.FileVault {
+			return nil, fmt.Errorf("the audit log is kept by the %s secrets manager only", secrets.FileVault)
+		}
+	}
+
+	managerParams := &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra:  make(map[string]interface{}),
+	}
+
+	if vp.dataDir != "" {
+		managerParams.Extra[secrets.Path] = vp.dataDir
+	}
+
+	if vp.passphraseFile != "" {
+		passphrase, err := encryptedlocal.ReadPassphrase(vp.passphraseFile)
+		if err != nil {
+			return nil, err
+		}
+
+		managerParams.Extra[secrets.Passphrase] = passphrase
+	}
+
+	manager, err := filevault.SecretsManagerFactory(config, managerParams)
+	if err != nil {
+		return nil, err
+	}
+
+	vaultManager, ok := manager.(*filevault.FileVaultSecretsManager)
+	if !ok {
+		return nil, errors.New("invalid type assertion")
+	}
+
+	return vaultManager, nil
+}
+
+func (vp *verifyParams) verifyAuditLog() error {
+	vaultManager, err := vp.openVault()
+	if err != nil {
+		return err
+	}
+
+	defer vaultManager.Close()
+
+	vp.vaultFile = vaultManager.Path()
+	vp.report, err = vaultManager.VerifyAuditLog()
+
+	return err
+}
+
+func (vp *verifyParams) getResult() command.CommandResult {
+	result := &AuditVerifyResult{
+		VaultFile: vp.vaultFile,
+		Entries:   vp.report.Entries,
+		Head:      vp.report.Head.String(),
+	}
+
+	if vp.report.First != nil {
+		result.First = newAuditEntryResult(vp.report.First)
+		result.Last = newAuditEntryResult(vp.report.Last)
+	}
+
+	return result
+}
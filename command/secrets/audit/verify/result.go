@@ -0,0 +1,66 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/vishnushankarsg/metad/secrets/filevault"
+)
+
+type AuditEntryResult struct {
+	Sequence  uint64 `json:"sequence"`
+	Time      string `json:"time"`
+	Caller    string `json:"caller"`
+	Operation string `json:"operation"`
+	Name      string `json:"name"`
+	Error     string `json:"error,omitempty"`
+}
+
+func newAuditEntryResult(entry *filevault.AuditEntry) *AuditEntryResult {
+	return &AuditEntryResult{
+		Sequence:  entry.Sequence,
+		Time:      entry.Time.Format(time.RFC3339),
+		Caller:    entry.Caller,
+		Operation: entry.Operation,
+		Name:      entry.Name,
+		Error:     entry.Error,
+	}
+}
+
+func (r *AuditEntryResult) String() string {
+	if r.Error != "" {
+		return fmt.Sprintf("#%d %s %s %s by %s, failed: %s", r.Sequence, r.Time, r.Operation, r.Name, r.Caller, r.Error)
+	}
+
+	return fmt.Sprintf("#%d %s %s %s by %s", r.Sequence, r.Time, r.Operation, r.Name, r.Caller)
+}
+
+type AuditVerifyResult struct {
+	VaultFile string            `json:"vault_file"`
+	Entries   uint64            `json:"entries"`
+	Head      string            `json:"head"`
+	First     *AuditEntryResult `json:"first,omitempty"`
+	Last      *AuditEntryResult `json:"last,omitempty"`
+}
+
+func (r *AuditVerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := make([]string, 0, 5)
+	vals = append(vals, fmt.Sprintf("Vault file|%s", r.VaultFile))
+	vals = append(vals, fmt.Sprintf("Verified entries|%d", r.Entries))
+	vals = append(vals, fmt.Sprintf("Head|%s", r.Head))
+
+	if r.First != nil {
+		vals = append(vals, fmt.Sprintf("First entry|%s", r.First))
+		vals = append(vals, fmt.Sprintf("Last entry|%s", r.Last))
+	}
+
+	buffer.WriteString("\n[SECRETS AUDIT VERIFY]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/vishnushankarsg/metad/command"
 	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/vishnushankarsg/metad/secrets/encryptedlocal"
 	"github.com/vishnushankarsg/metad/secrets/helper"
 )
 
@@ -16,6 +17,8 @@ const (
 	networkFlag            = "network"
 	numFlag                = "num"
 	insecureLocalStoreFlag = "insecure"
+	encryptedFlag          = "encrypted"
+	passphraseFileFlag     = "passphrase-file"
 )
 
 var (
@@ -23,7 +26,8 @@ var (
 	errInvalidParams                  = errors.New("no config file or data directory passed in")
 	errUnsupportedType                = errors.New("unsupported secrets manager")
 	errSecureLocalStoreNotImplemented = errors.New(
-		"use a secrets backend, or supply an --insecure flag " +
+		"use a secrets backend, supply an --encrypted flag to encrypt the private keys with a passphrase, " +
+			"or supply an --insecure flag " +
 			"to store the private keys locally on the filesystem, " +
 			"avoid doing so in production")
 )
@@ -35,6 +39,9 @@ type initParams struct {
 	generatesBLS       bool
 	generatesNetwork   bool
 	insecureLocalStore bool
+	encrypted          bool
+	passphraseFile     string
+	passphrase         string
 
 	secretsManager secrets.SecretsManager
 	secretsConfig  *secrets.SecretsManagerConfig
@@ -48,6 +55,23 @@ func (ip *initParams) validateFlags() error {
 	return nil
 }
 
+// readPassphrase reads the passphrase of the encrypted local store once,
+// so it is shared by all the initialized data directories
+func (ip *initParams) readPassphrase() error {
+	if !ip.encrypted {
+		return nil
+	}
+
+	passphrase, err := encryptedlocal.ReadPassphrase(ip.passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	ip.passphrase = passphrase
+
+	return nil
+}
+
 func (ip *initParams) initSecrets() error {
 	if err := ip.initSecretsManager(); err != nil {
 		return err
@@ -95,6 +119,18 @@ func (ip *initParams) parseConfig() error {
 }
 
 func (ip *initParams) initLocalSecretsManager() error {
+	if ip.encrypted {
+		// setup encrypted local secrets manager
+		encryptedLocal, err := helper.SetupEncryptedLocalSecretsManager(ip.dataDir, ip.passphrase)
+		if err != nil {
+			return err
+		}
+
+		ip.secretsManager = encryptedLocal
+
+		return nil
+	}
+
 	if !ip.insecureLocalStore {
 		//Storing secrets on a local file system should only be allowed with --insecure flag,
 		//to raise awareness that it should be only used in development/testing environments.
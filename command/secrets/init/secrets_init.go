@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/secrets"
 )
 
 const (
@@ -91,6 +92,24 @@ func setFlags(cmd *cobra.Command) {
 		false,
 		"the flag indicating should the secrets stored on the local storage be encrypted",
 	)
+
+	cmd.Flags().BoolVar(
+		&basicParams.encrypted,
+		encryptedFlag,
+		false,
+		"the flag indicating whether the secrets stored on the local storage are encrypted with a passphrase",
+	)
+
+	cmd.Flags().StringVar(
+		&basicParams.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the file containing the passphrase of the encrypted local storage, "+
+			"if omitted, the passphrase is read from the "+secrets.PassphraseEnv+" environment variable or the terminal",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(encryptedFlag, insecureLocalStoreFlag)
+	cmd.MarkFlagsMutuallyExclusive(encryptedFlag, configFlag)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -98,7 +117,11 @@ func runPreRun(_ *cobra.Command, _ []string) error {
 		return errInvalidNum
 	}
 
-	return basicParams.validateFlags()
+	if err := basicParams.validateFlags(); err != nil {
+		return err
+	}
+
+	return basicParams.readPassphrase()
 }
 
 func runCommand(cmd *cobra.Command, _ []string) {
@@ -144,6 +167,8 @@ func getParamsList() []initParams {
 			generatesBLS:       basicParams.generatesBLS,
 			generatesNetwork:   basicParams.generatesNetwork,
 			insecureLocalStore: basicParams.insecureLocalStore,
+			encrypted:          basicParams.encrypted,
+			passphrase:         basicParams.passphrase,
 		}
 	}
 
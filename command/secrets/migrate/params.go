@@ -0,0 +1,74 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/helper/common"
+	"github.com/vishnushankarsg/metad/secrets/encryptedlocal"
+	"github.com/vishnushankarsg/metad/secrets/helper"
+)
+
+const (
+	dataDirFlag        = "data-dir"
+	passphraseFileFlag = "passphrase-file"
+)
+
+var (
+	params = &migrateParams{}
+)
+
+var (
+	errInvalidParams = errors.New("no data directory passed in")
+)
+
+type migrateParams struct {
+	dataDir        string
+	passphraseFile string
+
+	migrated []string
+}
+
+func (mp *migrateParams) validateFlags() error {
+	if mp.dataDir == "" {
+		return errInvalidParams
+	}
+
+	if !common.DirectoryExists(mp.dataDir) {
+		dataDirAbs, _ := filepath.Abs(mp.dataDir)
+
+		return fmt.Errorf("the data directory provided does not exist: %s", dataDirAbs)
+	}
+
+	return nil
+}
+
+func (mp *migrateParams) migrateSecrets() error {
+	passphrase, err := encryptedlocal.ReadPassphrase(mp.passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	secretsManager, err := helper.SetupEncryptedLocalSecretsManager(mp.dataDir, passphrase)
+	if err != nil {
+		return err
+	}
+
+	encryptedLocal, ok := secretsManager.(*encryptedlocal.EncryptedLocalSecretsManager)
+	if !ok {
+		return errors.New("invalid type assertion")
+	}
+
+	mp.migrated, err = encryptedLocal.MigrateLocalSecrets()
+
+	return err
+}
+
+func (mp *migrateParams) getResult() command.CommandResult {
+	return &SecretsMigrateResult{
+		DataDir:  mp.dataDir,
+		Migrated: mp.migrated,
+	}
+}
@@ -0,0 +1,35 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/vishnushankarsg/metad/command/helper"
+)
+
+type SecretsMigrateResult struct {
+	DataDir  string   `json:"data_dir"`
+	Migrated []string `json:"migrated"`
+}
+
+func (r *SecretsMigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := make([]string, 0, len(r.Migrated)+1)
+	vals = append(vals, fmt.Sprintf("Data directory|%s", r.DataDir))
+
+	for _, name := range r.Migrated {
+		vals = append(vals, fmt.Sprintf("Encrypted secret|%s", name))
+	}
+
+	buffer.WriteString("\n[SECRETS MIGRATE]\n")
+
+	if len(r.Migrated) == 0 {
+		buffer.WriteString("No plaintext secrets found\n")
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,58 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/secrets"
+)
+
+func GetCommand() *cobra.Command {
+	secretsMigrateCmd := &cobra.Command{
+		Use: "migrate",
+		Short: "Encrypts the plaintext private keys of the local FS secrets manager " +
+			"with a passphrase and removes the plaintext files",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsMigrateCmd)
+
+	return secretsMigrateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Metachain  data holding the plaintext secrets",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the file containing the passphrase of the encrypted local storage, "+
+			"if omitted, the passphrase is read from the "+secrets.PassphraseEnv+" environment variable or the terminal",
+	)
+
+	_ = cmd.MarkFlagRequired(dataDirFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.migrateSecrets(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/vishnushankarsg/metad/command"
 	"github.com/vishnushankarsg/metad/helper/common"
 	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/vishnushankarsg/metad/secrets/encryptedlocal"
 	"github.com/vishnushankarsg/metad/secrets/helper"
 	"github.com/vishnushankarsg/metad/types"
 )
@@ -19,6 +20,9 @@ const (
 	validatorFlag = "validator"
 	blsFlag       = "bls"
 	nodeIDFlag    = "node-id"
+
+	encryptedFlag      = "encrypted"
+	passphraseFileFlag = "passphrase-file"
 )
 
 var (
@@ -35,6 +39,9 @@ type outputParams struct {
 	dataDir    string
 	configPath string
 
+	encrypted      bool
+	passphraseFile string
+
 	outputNodeID    bool
 	outputValidator bool
 	outputBLS       bool
@@ -134,6 +141,22 @@ func (op *outputParams) initLocalSecretsManager() error {
 		return fmt.Errorf(strings.Join(errs, "\n"))
 	}
 
+	if op.encrypted {
+		passphrase, err := encryptedlocal.ReadPassphrase(op.passphraseFile)
+		if err != nil {
+			return err
+		}
+
+		encryptedLocal, err := helper.SetupEncryptedLocalSecretsManager(op.dataDir, passphrase)
+		if err != nil {
+			return err
+		}
+
+		op.secretsManager = encryptedLocal
+
+		return nil
+	}
+
 	local, err := helper.SetupLocalSecretsManager(op.dataDir)
 	if err != nil {
 		return err
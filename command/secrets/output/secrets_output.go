@@ -2,6 +2,7 @@ package output
 
 import (
 	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/secrets"
 	"github.com/spf13/cobra"
 )
 
@@ -58,7 +59,23 @@ func setFlags(cmd *cobra.Command) {
 			"from the provided secrets manager",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.encrypted,
+		encryptedFlag,
+		false,
+		"the flag indicating whether the secrets stored on the local storage are encrypted with a passphrase",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the file containing the passphrase of the encrypted local storage, "+
+			"if omitted, the passphrase is read from the "+secrets.PassphraseEnv+" environment variable or the terminal",
+	)
+
 	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(encryptedFlag, configFlag)
 	cmd.MarkFlagsMutuallyExclusive(nodeIDFlag, validatorFlag, blsFlag)
 }
 
@@ -0,0 +1,141 @@
+package remotesigner
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/vishnushankarsg/metad/secrets/encryptedlocal"
+	"github.com/vishnushankarsg/metad/secrets/helper"
+	"github.com/vishnushankarsg/metad/secrets/remotesigner"
+)
+
+const (
+	dataDirFlag        = "data-dir"
+	configFlag         = "config"
+	encryptedFlag      = "encrypted"
+	passphraseFileFlag = "passphrase-file"
+	grpcAddressFlag    = "grpc-address"
+	tokenFlag          = "token"
+	tlsCertFileFlag    = "tls-cert-file"
+	tlsKeyFileFlag     = "tls-key-file"
+)
+
+const (
+	defaultGRPCAddress = "127.0.0.1:9640"
+)
+
+var (
+	params = &remoteSignerParams{}
+)
+
+var (
+	errInvalidConfig   = errors.New("invalid secrets configuration")
+	errInvalidParams   = errors.New("no config file or data directory passed in")
+	errUnsupportedType = errors.New("unsupported secrets manager")
+	errRemoteBackend   = errors.New("the remote signer can't be backed by another remote signer")
+)
+
+type remoteSignerParams struct {
+	dataDir        string
+	configPath     string
+	encrypted      bool
+	passphraseFile string
+
+	grpcAddress string
+	token       string
+	tlsCertFile string
+	tlsKeyFile  string
+
+	secretsManager secrets.SecretsManager
+	grpcServer     *grpc.Server
+}
+
+func (rp *remoteSignerParams) validateFlags() error {
+	if rp.dataDir == "" && rp.configPath == "" {
+		return errInvalidParams
+	}
+
+	return nil
+}
+
+func (rp *remoteSignerParams) initSecretsManager() error {
+	var err error
+
+	if rp.configPath != "" {
+		secretsConfig, readErr := secrets.ReadConfig(rp.configPath)
+		if readErr != nil {
+			return errInvalidConfig
+		}
+
+		if !secrets.SupportedServiceManager(secretsConfig.Type) {
+			return errUnsupportedType
+		}
+
+		if secretsConfig.Type == secrets.RemoteSigner {
+			return errRemoteBackend
+		}
+
+		rp.secretsManager, err = helper.InitCloudSecretsManager(secretsConfig)
+
+		return err
+	}
+
+	if rp.encrypted {
+		passphrase, err := encryptedlocal.ReadPassphrase(rp.passphraseFile)
+		if err != nil {
+			return err
+		}
+
+		rp.secretsManager, err = helper.SetupEncryptedLocalSecretsManager(rp.dataDir, passphrase)
+
+		return err
+	}
+
+	rp.secretsManager, err = helper.SetupLocalSecretsManager(rp.dataDir)
+
+	return err
+}
+
+// startServer starts the signing service backed by the secrets manager
+func (rp *remoteSignerParams) startServer(logger hclog.Logger) error {
+	serverOptions := []grpc.ServerOption{}
+
+	if rp.tlsCertFile != "" {
+		tlsCredentials, err := credentials.NewServerTLSFromFile(rp.tlsCertFile, rp.tlsKeyFile)
+		if err != nil {
+			return fmt.Errorf("unable to load TLS certificate, %w", err)
+		}
+
+		serverOptions = append(serverOptions, grpc.Creds(tlsCredentials))
+	} else {
+		logger.Warn("serving the remote signer without TLS")
+	}
+
+	listener, err := net.Listen("tcp", rp.grpcAddress)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s, %w", rp.grpcAddress, err)
+	}
+
+	rp.grpcServer = grpc.NewServer(serverOptions...)
+	remotesigner.NewServer(logger, rp.secretsManager, rp.token).Register(rp.grpcServer)
+
+	go func() {
+		if err := rp.grpcServer.Serve(listener); err != nil {
+			logger.Error("remote signer stopped", "err", err)
+		}
+	}()
+
+	logger.Info("remote signer started", "addr", listener.Addr().String())
+
+	return nil
+}
+
+func (rp *remoteSignerParams) stopServer() {
+	rp.grpcServer.GracefulStop()
+}
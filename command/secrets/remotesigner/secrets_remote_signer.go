@@ -0,0 +1,122 @@
+package remotesigner
+
+import (
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/vishnushankarsg/metad/secrets"
+)
+
+func GetCommand() *cobra.Command {
+	secretsRemoteSignerCmd := &cobra.Command{
+		Use: "remote-signer",
+		Short: "Runs a signing service for the remote signer secrets manager, " +
+			"signing by the keys of the provided Secrets Manager without exposing them",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsRemoteSignerCmd)
+
+	return secretsRemoteSignerCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Metachain  data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.encrypted,
+		encryptedFlag,
+		false,
+		"the flag indicating whether the secrets stored on the local storage are encrypted with a passphrase",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the file containing the passphrase of the encrypted local storage, "+
+			"if omitted, the passphrase is read from the "+secrets.PassphraseEnv+" environment variable or the terminal",
+	)
+
+	cmd.Flags().StringVar(
+		&params.grpcAddress,
+		grpcAddressFlag,
+		defaultGRPCAddress,
+		"the GRPC interface of the signing service",
+	)
+
+	cmd.Flags().StringVar(
+		&params.token,
+		tokenFlag,
+		"",
+		"the token the clients authenticate with, if omitted, the clients are not authenticated",
+	)
+
+	cmd.Flags().StringVar(
+		&params.tlsCertFile,
+		tlsCertFileFlag,
+		"",
+		"the path to the TLS certificate of the signing service, if omitted, TLS is disabled",
+	)
+
+	cmd.Flags().StringVar(
+		&params.tlsKeyFile,
+		tlsKeyFileFlag,
+		"",
+		"the path to the TLS private key of the signing service",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(encryptedFlag, configFlag)
+	cmd.MarkFlagsRequiredTogether(tlsCertFileFlag, tlsKeyFileFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Output: os.Stderr,
+		Level:  hclog.Info,
+	})
+
+	if err := params.initSecretsManager(); err != nil {
+		outputter.SetError(err)
+		outputter.WriteOutput()
+
+		return
+	}
+
+	if err := params.startServer(logger); err != nil {
+		outputter.SetError(err)
+		outputter.WriteOutput()
+
+		return
+	}
+
+	if err := helper.HandleSignals(params.stopServer, outputter); err != nil {
+		outputter.SetError(err)
+		outputter.WriteOutput()
+	}
+}
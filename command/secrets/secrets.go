@@ -2,9 +2,12 @@ package secrets
 
 import (
 	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/vishnushankarsg/metad/command/secrets/audit"
 	"github.com/vishnushankarsg/metad/command/secrets/generate"
 	initCmd "github.com/vishnushankarsg/metad/command/secrets/init"
+	"github.com/vishnushankarsg/metad/command/secrets/migrate"
 	"github.com/vishnushankarsg/metad/command/secrets/output"
+	"github.com/vishnushankarsg/metad/command/secrets/remotesigner"
 	"github.com/spf13/cobra"
 )
 
@@ -29,5 +32,11 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		generate.GetCommand(),
 		// secrets output public data
 		output.GetCommand(),
+		// secrets migrate plaintext secrets to the encrypted local store
+		migrate.GetCommand(),
+		// secrets remote-signer signing service
+		remotesigner.GetCommand(),
+		// secrets audit of the file vault
+		audit.GetCommand(),
 	)
 }
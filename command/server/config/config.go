@@ -25,6 +25,7 @@ type Config struct {
 	TxPool                   *TxPool    `json:"tx_pool" yaml:"tx_pool"`
 	LogLevel                 string     `json:"log_level" yaml:"log_level"`
 	RestoreFile              string     `json:"restore_file" yaml:"restore_file"`
+	RestoreStateFile         string     `json:"restore_state_file" yaml:"restore_state_file"`
 	Headers                  *Headers   `json:"headers" yaml:"headers"`
 	LogFilePath              string     `json:"log_to" yaml:"log_to"`
 	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
@@ -33,6 +34,15 @@ type Config struct {
 
 	Relayer               bool   `json:"relayer" yaml:"relayer"`
 	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
+
+	Prune       bool   `json:"prune" yaml:"prune"`
+	PruneRetain uint64 `json:"prune_retain" yaml:"prune_retain"`
+
+	SnapSync bool `json:"snap_sync" yaml:"snap_sync"`
+
+	MaxSenderTxs uint64 `json:"max_sender_txs" yaml:"max_sender_txs"`
+
+	TxSenderIndex bool `json:"tx_sender_index" yaml:"tx_sender_index"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -53,9 +63,13 @@ type Network struct {
 
 // TxPool defines the TxPool configuration params
 type TxPool struct {
-	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
-	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
-	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceLimit         uint64   `json:"price_limit" yaml:"price_limit"`
+	MaxSlots           uint64   `json:"max_slots" yaml:"max_slots"`
+	MaxAccountEnqueued uint64   `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceBump          uint64   `json:"price_bump" yaml:"price_bump"`
+	Journal            string   `json:"journal,omitempty" yaml:"journal,omitempty"`
+	EnqueuedLifetime   uint64   `json:"enqueued_lifetime" yaml:"enqueued_lifetime"`
+	PrioritySenders    []string `json:"priority_senders,omitempty" yaml:"priority_senders,omitempty"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -78,6 +92,9 @@ const (
 	// DefaultNumBlockConfirmations minimal number of child blocks required for the parent block to be considered final
 	// on ethereum epoch lasts for 32 blocks. more details: https://www.alchemy.com/overviews/ethereum-commitment-levels
 	DefaultNumBlockConfirmations uint64 = 64
+
+	// DefaultPruneRetain is the number of the latest block states kept when the state pruning is enabled
+	DefaultPruneRetain uint64 = 128
 )
 
 // DefaultConfig returns the default server configuration
@@ -104,9 +121,12 @@ func DefaultConfig() *Config {
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			PriceBump:          10,
+			EnqueuedLifetime:   3 * 60 * 60, // 3 hours
 		},
-		LogLevel:    "INFO",
-		RestoreFile: "",
+		LogLevel:         "INFO",
+		RestoreFile:      "",
+		RestoreStateFile: "",
 		Headers: &Headers{
 			AccessControlAllowOrigins: []string{"*"},
 		},
@@ -115,6 +135,11 @@ func DefaultConfig() *Config {
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
 		Relayer:                  false,
 		NumBlockConfirmations:    DefaultNumBlockConfirmations,
+		Prune:                    false,
+		PruneRetain:              DefaultPruneRetain,
+		SnapSync:                 false,
+		MaxSenderTxs:             0,
+		TxSenderIndex:            false,
 	}
 }
 
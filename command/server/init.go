@@ -20,6 +20,7 @@ import (
 
 var (
 	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errInvalidPruneRetain     = errors.New("at least one block state must be retained when pruning")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -49,6 +50,14 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initPruning(); err != nil {
+		return err
+	}
+
+	if err := p.initPrioritySenders(); err != nil {
+		return err
+	}
+
 	if p.isDevMode {
 		p.initDevMode()
 	}
@@ -69,6 +78,29 @@ func (p *serverParams) initDataDirLocation() error {
 	return nil
 }
 
+func (p *serverParams) initPruning() error {
+	if p.rawConfig.Prune && p.rawConfig.PruneRetain == 0 {
+		return errInvalidPruneRetain
+	}
+
+	return nil
+}
+
+func (p *serverParams) initPrioritySenders() error {
+	p.prioritySenders = make([]types.Address, 0, len(p.rawConfig.TxPool.PrioritySenders))
+
+	for _, rawAddr := range p.rawConfig.TxPool.PrioritySenders {
+		addr := types.Address{}
+		if err := addr.UnmarshalText([]byte(rawAddr)); err != nil {
+			return fmt.Errorf("invalid priority sender address %s: %w", rawAddr, err)
+		}
+
+		p.prioritySenders = append(p.prioritySenders, addr)
+	}
+
+	return nil
+}
+
 func (p *serverParams) initLogFileLocation() {
 	if p.isLogFileLocationSet() {
 		p.logFileLocation = p.rawConfig.LogFilePath
@@ -3,12 +3,14 @@ package server
 import (
 	"errors"
 	"net"
+	"time"
 
 	"github.com/vishnushankarsg/metad/chain"
 	"github.com/vishnushankarsg/metad/command/server/config"
 	"github.com/vishnushankarsg/metad/network"
 	"github.com/vishnushankarsg/metad/secrets"
 	"github.com/vishnushankarsg/metad/server"
+	"github.com/vishnushankarsg/metad/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/multiformats/go-multiaddr"
 )
@@ -30,9 +32,13 @@ const (
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	priceBumpFlag                = "price-bump"
+	txJournalFlag                = "tx-journal"
+	enqueuedLifetimeFlag         = "enqueued-lifetime"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
+	restoreStateFlag             = "restore-state"
 	devIntervalFlag              = "dev-interval"
 	devFlag                      = "dev"
 	corsOriginFlag               = "access-control-allow-origins"
@@ -40,6 +46,16 @@ const (
 
 	relayerFlag               = "relayer"
 	numBlockConfirmationsFlag = "num-block-confirmations"
+
+	pruneFlag       = "prune"
+	pruneRetainFlag = "prune-retain"
+
+	snapSyncFlag = "snap-sync"
+
+	prioritySendersFlag = "priority-senders"
+	maxSenderTxsFlag    = "max-sender-txs"
+
+	txSenderIndexFlag = "tx-sender-index"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -83,6 +99,8 @@ type serverParams struct {
 
 	corsAllowedOrigins []string
 
+	prioritySenders []types.Address
+
 	ibftBaseTimeoutLegacy uint64
 
 	genesisConfig *chain.Chain
@@ -134,6 +152,14 @@ func (p *serverParams) getRestoreFilePath() *string {
 	return nil
 }
 
+func (p *serverParams) getRestoreStateFilePath() *string {
+	if p.rawConfig.RestoreStateFile != "" {
+		return &p.rawConfig.RestoreStateFile
+	}
+
+	return nil
+}
+
 func (p *serverParams) setRawGRPCAddress(grpcAddress string) {
 	p.rawConfig.GRPCAddr = grpcAddress
 }
@@ -176,13 +202,27 @@ func (p *serverParams) generateConfig() *server.Config {
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
+		PriceBump:          p.rawConfig.TxPool.PriceBump,
+		TxJournal:          p.rawConfig.TxPool.Journal,
+		EnqueuedLifetime:   time.Duration(p.rawConfig.TxPool.EnqueuedLifetime) * time.Second,
+		PrioritySenders:    p.prioritySenders,
 		SecretsManager:     p.secretsConfig,
 		RestoreFile:        p.getRestoreFilePath(),
+		RestoreStateFile:   p.getRestoreStateFilePath(),
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
 		JSONLogFormat:      p.rawConfig.JSONLogFormat,
 		LogFilePath:        p.logFileLocation,
 
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+
+		Prune:       p.rawConfig.Prune,
+		PruneRetain: p.rawConfig.PruneRetain,
+
+		SnapSync: p.rawConfig.SnapSync,
+
+		MaxSenderTxs: p.rawConfig.MaxSenderTxs,
+
+		TxSenderIndex: p.rawConfig.TxSenderIndex,
 	}
 }
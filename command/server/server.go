@@ -118,6 +118,14 @@ func setFlags(cmd *cobra.Command) {
 		"the path to the archive blockchain data to restore on initialization",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.RestoreStateFile,
+		restoreStateFlag,
+		"",
+		"the path to the state snapshot to restore on initialization of the empty chain, "+
+			"only the blocks after the snapshot are synced afterwards",
+	)
+
 	cmd.Flags().BoolVar(
 		&params.rawConfig.ShouldSeal,
 		sealFlag,
@@ -185,6 +193,41 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PriceBump,
+		priceBumpFlag,
+		defaultConfig.TxPool.PriceBump,
+		"minimum gas price increase (in percent) required to replace a transaction with the same nonce",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.TxPool.Journal,
+		txJournalFlag,
+		defaultConfig.TxPool.Journal,
+		"the path of the journal keeping the local transactions of the pool across restarts (disabled if empty)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.EnqueuedLifetime,
+		enqueuedLifetimeFlag,
+		defaultConfig.TxPool.EnqueuedLifetime,
+		"the maximum time (in seconds) the enqueued transactions of an inactive account are kept in the pool (disabled if 0)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.TxPool.PrioritySenders,
+		prioritySendersFlag,
+		defaultConfig.TxPool.PrioritySenders,
+		"the addresses whose transactions are exempt from the price limit and eviction, and are executed first",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.MaxSenderTxs,
+		maxSenderTxsFlag,
+		defaultConfig.MaxSenderTxs,
+		"the maximum number of transactions of a single sender included in a block (unlimited if 0)",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.corsAllowedOrigins,
 		corsOriginFlag,
@@ -228,6 +271,37 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Prune,
+		pruneFlag,
+		defaultConfig.Prune,
+		"run the node in the full mode, pruning the states of the old blocks "+
+			"(the archive mode, keeping all the states, is used otherwise)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.PruneRetain,
+		pruneRetainFlag,
+		defaultConfig.PruneRetain,
+		fmt.Sprintf("number of the latest block states kept in the full mode (only used with --%s)", pruneFlag),
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.SnapSync,
+		snapSyncFlag,
+		defaultConfig.SnapSync,
+		"download the state of a recent block from the peers on the first start, "+
+			"instead of executing all the blocks",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxSenderIndex,
+		txSenderIndexFlag,
+		defaultConfig.TxSenderIndex,
+		"index the transactions of the new blocks by their sender and nonce "+
+			"(required by eth_getTransactionBySenderAndNonce)",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
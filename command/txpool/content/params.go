@@ -0,0 +1,66 @@
+package content
+
+import (
+	"context"
+
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/command/helper"
+	txpoolOp "github.com/vishnushankarsg/metad/txpool/proto"
+)
+
+var (
+	params = &contentParams{}
+)
+
+const (
+	fromFlag   = "from"
+	offsetFlag = "offset"
+	limitFlag  = "limit"
+)
+
+type contentParams struct {
+	from   string
+	offset uint64
+	limit  uint64
+
+	content *txpoolOp.ContentFromResp
+}
+
+func (p *contentParams) getRequiredFlags() []string {
+	return []string{
+		fromFlag,
+	}
+}
+
+func (p *contentParams) initContent(grpcAddress string) error {
+	client, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	content, err := client.ContentFrom(
+		context.Background(),
+		&txpoolOp.ContentFromReq{
+			From:   p.from,
+			Offset: p.offset,
+			Limit:  p.limit,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.content = content
+
+	return nil
+}
+
+func (p *contentParams) getResult() command.CommandResult {
+	return &TxPoolContentResult{
+		From:    p.from,
+		Offset:  p.offset,
+		Total:   p.content.Total,
+		Pending: newTxPoolTransactions(p.content.Pending),
+		Queued:  newTxPoolTransactions(p.content.Queued),
+	}
+}
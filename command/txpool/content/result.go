@@ -0,0 +1,87 @@
+package content
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/vishnushankarsg/metad/command/helper"
+	txpoolOp "github.com/vishnushankarsg/metad/txpool/proto"
+)
+
+type TxPoolTransaction struct {
+	Hash     string `json:"hash"`
+	Nonce    uint64 `json:"nonce"`
+	Gas      uint64 `json:"gas"`
+	GasPrice string `json:"gas_price"`
+	To       string `json:"to"`
+	Value    string `json:"value"`
+}
+
+func newTxPoolTransactions(txs []*txpoolOp.TxnPoolTransaction) []TxPoolTransaction {
+	result := make([]TxPoolTransaction, len(txs))
+
+	for i, tx := range txs {
+		result[i] = TxPoolTransaction{
+			Hash:     tx.Hash,
+			Nonce:    tx.Nonce,
+			Gas:      tx.Gas,
+			GasPrice: tx.GasPrice,
+			To:       tx.To,
+			Value:    tx.Value,
+		}
+	}
+
+	return result
+}
+
+type TxPoolContentResult struct {
+	From    string              `json:"from"`
+	Offset  uint64              `json:"offset"`
+	Total   uint64              `json:"total"`
+	Pending []TxPoolTransaction `json:"pending"`
+	Queued  []TxPoolTransaction `json:"queued"`
+}
+
+func (r *TxPoolContentResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL CONTENT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Sender|%s", r.From),
+		fmt.Sprintf("Transactions in pool|%d", r.Total),
+		fmt.Sprintf("Shown|%d (offset %d)", len(r.Pending)+len(r.Queued), r.Offset),
+	}))
+	buffer.WriteString("\n")
+
+	writeTransactions(&buffer, "PENDING", r.Pending)
+	writeTransactions(&buffer, "QUEUED", r.Queued)
+
+	return buffer.String()
+}
+
+func writeTransactions(buffer *bytes.Buffer, title string, txs []TxPoolTransaction) {
+	buffer.WriteString(fmt.Sprintf("\n[%s]\n", title))
+
+	if len(txs) == 0 {
+		buffer.WriteString("No transactions found\n")
+
+		return
+	}
+
+	for _, tx := range txs {
+		to := tx.To
+		if to == "" {
+			to = "contract creation"
+		}
+
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("Nonce|%d", tx.Nonce),
+			fmt.Sprintf("Hash|%s", tx.Hash),
+			fmt.Sprintf("To|%s", to),
+			fmt.Sprintf("Value|%s", tx.Value),
+			fmt.Sprintf("Gas|%d", tx.Gas),
+			fmt.Sprintf("Gas Price|%s", tx.GasPrice),
+		}))
+		buffer.WriteString("\n\n")
+	}
+}
@@ -0,0 +1,56 @@
+package content
+
+import (
+	"github.com/vishnushankarsg/metad/command"
+	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	txPoolContentCmd := &cobra.Command{
+		Use:   "content",
+		Short: "Returns the transactions of the specified sender in the transaction pool, ordered by nonce",
+		Run:   runCommand,
+	}
+
+	setFlags(txPoolContentCmd)
+	helper.SetRequiredFlags(txPoolContentCmd, params.getRequiredFlags())
+
+	return txPoolContentCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.from,
+		fromFlag,
+		"",
+		"the address of the transactions sender",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.offset,
+		offsetFlag,
+		0,
+		"the number of the sender's transactions (ordered by nonce) to skip",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.limit,
+		limitFlag,
+		0,
+		"the maximum number of the returned transactions, capped by the pool",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initContent(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
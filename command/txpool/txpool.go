@@ -2,6 +2,7 @@ package txpool
 
 import (
 	"github.com/vishnushankarsg/metad/command/helper"
+	"github.com/vishnushankarsg/metad/command/txpool/content"
 	"github.com/vishnushankarsg/metad/command/txpool/status"
 	"github.com/vishnushankarsg/metad/command/txpool/subscribe"
 	"github.com/spf13/cobra"
@@ -26,5 +27,7 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		status.GetCommand(),
 		// txpool subscribe
 		subscribe.GetCommand(),
+		// txpool content
+		content.GetCommand(),
 	)
 }
@@ -78,6 +78,9 @@ type Params struct {
 	BlockTime      uint64
 
 	NumBlockConfirmations uint64
+
+	// MaxSenderTxs is the maximum number of transactions of a single sender in a block, unlimited if zero
+	MaxSenderTxs uint64
 }
 
 // Factory is the factory function to create a discovery consensus
@@ -11,6 +11,7 @@ import (
 	"github.com/vishnushankarsg/metad/consensus/ibft/signer"
 	"github.com/vishnushankarsg/metad/helper/hex"
 	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/txpool"
 	"github.com/vishnushankarsg/metad/types"
 )
 
@@ -196,6 +197,7 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 	}
 
 	header.GasLimit = gasLimit
+	header.BaseFee = i.blockchain.CalculateBaseFee(parent)
 
 	if err := i.currentHooks.ModifyHeader(header, i.currentSigner.Address()); err != nil {
 		return nil, err
@@ -301,6 +303,9 @@ type txExeResult struct {
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
 	WriteFailedReceipt(txn *types.Transaction) error
+	Receipts() []*types.Receipt
+	Checkpoint() state.TransitionCheckpoint
+	RevertToCheckpoint(checkpoint state.TransitionCheckpoint)
 }
 
 func (i *backendIBFT) writeTransactions(
@@ -319,6 +324,9 @@ func (i *backendIBFT) writeTransactions(
 		successful = 0
 		failed     = 0
 		skipped    = 0
+
+		// the number of the executed transactions of each sender
+		senderTxs = make(map[types.Address]uint64)
 	)
 
 	defer func() {
@@ -331,6 +339,11 @@ func (i *backendIBFT) writeTransactions(
 		)
 	}()
 
+	// the bundles targeting the block are executed before the txpool transactions
+	bundleTxs := i.writeBundles(gasLimit, blockNumber, transition)
+	executed = append(executed, bundleTxs...)
+	successful += len(bundleTxs)
+
 	i.txpool.Prepare()
 
 write:
@@ -339,9 +352,18 @@ write:
 		case <-writeCtx.Done():
 			return
 		default:
+			tx := i.txpool.Peek()
+
+			if tx != nil && i.maxSenderTxs > 0 && senderTxs[tx.From] >= i.maxSenderTxs {
+				// the remaining transactions of the sender are left for the next blocks
+				skipped++
+
+				continue
+			}
+
 			// execute transactions one by one
 			result, ok := i.writeTransaction(
-				i.txpool.Peek(),
+				tx,
 				transition,
 				gasLimit,
 			)
@@ -350,11 +372,10 @@ write:
 				break write
 			}
 
-			tx := result.tx
-
 			switch result.status {
 			case success:
 				executed = append(executed, tx)
+				senderTxs[tx.From]++
 				successful++
 			case fail:
 				failed++
@@ -370,6 +391,51 @@ write:
 	return
 }
 
+// writeBundles executes the bundles targeting the block, in the submission order.
+// The bundle is dropped as a whole if any of its transactions fails or reverts
+func (i *backendIBFT) writeBundles(
+	gasLimit,
+	blockNumber uint64,
+	transition transitionInterface,
+) (executed []*types.Transaction) {
+	for _, bundle := range i.txpool.Bundles(blockNumber) {
+		checkpoint := transition.Checkpoint()
+
+		if err := writeBundle(bundle, transition, gasLimit); err != nil {
+			transition.RevertToCheckpoint(checkpoint)
+			i.txpool.DropBundle(bundle)
+
+			i.logger.Debug("dropped bundle", "hash", bundle.Hash, "err", err)
+
+			continue
+		}
+
+		executed = append(executed, bundle.Txs...)
+	}
+
+	return executed
+}
+
+// writeBundle executes the transactions of the bundle one by one
+func writeBundle(bundle *txpool.Bundle, transition transitionInterface, gasLimit uint64) error {
+	for _, tx := range bundle.Txs {
+		if tx.ExceedsBlockGasLimit(gasLimit) {
+			return txpool.ErrBlockLimitExceeded
+		}
+
+		if err := transition.Write(tx); err != nil {
+			return err
+		}
+
+		receipts := transition.Receipts()
+		if status := receipts[len(receipts)-1].Status; status != nil && *status == types.ReceiptFailed {
+			return fmt.Errorf("transaction %s reverted", tx.Hash)
+		}
+	}
+
+	return nil
+}
+
 func (i *backendIBFT) writeTransaction(
 	tx *types.Transaction,
 	transition transitionInterface,
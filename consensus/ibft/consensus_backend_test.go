@@ -1,10 +1,16 @@
 package ibft
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/txpool"
+	"github.com/vishnushankarsg/metad/types"
 )
 
 // TestIBFTBackend_CalculateHeaderTimestamp verifies that the header timestamp
@@ -111,3 +117,96 @@ func TestIBFTBackend_RoundUpTime(t *testing.T) {
 		})
 	}
 }
+
+type bundleTxPoolMock struct {
+	txPoolInterface
+
+	bundles []*txpool.Bundle
+	dropped []*txpool.Bundle
+}
+
+func (m *bundleTxPoolMock) Bundles(uint64) []*txpool.Bundle {
+	return m.bundles
+}
+
+func (m *bundleTxPoolMock) DropBundle(bundle *txpool.Bundle) {
+	m.dropped = append(m.dropped, bundle)
+}
+
+// bundleTransitionMock writes the transactions into the list,
+// the transactions with zero gas price are reverted
+type bundleTransitionMock struct {
+	written    []*types.Transaction
+	receipts   []*types.Receipt
+	checkpoint int
+}
+
+func (m *bundleTransitionMock) Write(tx *types.Transaction) error {
+	receipt := &types.Receipt{TxHash: tx.Hash}
+	if tx.GasPrice.Sign() == 0 {
+		receipt.SetStatus(types.ReceiptFailed)
+	} else {
+		receipt.SetStatus(types.ReceiptSuccess)
+	}
+
+	m.written = append(m.written, tx)
+	m.receipts = append(m.receipts, receipt)
+
+	return nil
+}
+
+func (m *bundleTransitionMock) WriteFailedReceipt(*types.Transaction) error {
+	return nil
+}
+
+func (m *bundleTransitionMock) Receipts() []*types.Receipt {
+	return m.receipts
+}
+
+func (m *bundleTransitionMock) Checkpoint() state.TransitionCheckpoint {
+	m.checkpoint = len(m.written)
+
+	return state.TransitionCheckpoint{}
+}
+
+func (m *bundleTransitionMock) RevertToCheckpoint(state.TransitionCheckpoint) {
+	m.written = m.written[:m.checkpoint]
+	m.receipts = m.receipts[:m.checkpoint]
+}
+
+func TestIBFTBackend_WriteBundles(t *testing.T) {
+	t.Parallel()
+
+	newBundleTx := func(nonce, gasPrice uint64) *types.Transaction {
+		return &types.Transaction{
+			Nonce:    nonce,
+			Gas:      21000,
+			GasPrice: new(big.Int).SetUint64(gasPrice),
+		}
+	}
+
+	reverted := &txpool.Bundle{
+		Txs: []*types.Transaction{newBundleTx(0, 1), newBundleTx(1, 0)},
+	}
+	tooLarge := &txpool.Bundle{
+		Txs: []*types.Transaction{{Nonce: 0, Gas: 100000, GasPrice: big.NewInt(1)}},
+	}
+	valid := &txpool.Bundle{
+		Txs: []*types.Transaction{newBundleTx(0, 1), newBundleTx(1, 1)},
+	}
+
+	pool := &bundleTxPoolMock{bundles: []*txpool.Bundle{valid, reverted, tooLarge}}
+	transition := &bundleTransitionMock{}
+
+	i := &backendIBFT{
+		logger: hclog.NewNullLogger(),
+		txpool: pool,
+	}
+
+	executed := i.writeBundles(50000, 1, transition)
+
+	// the whole bundle is dropped if any of its transactions fails
+	assert.Equal(t, valid.Txs, executed)
+	assert.Equal(t, valid.Txs, transition.written)
+	assert.Equal(t, []*txpool.Bundle{reverted, tooLarge}, pool.dropped)
+}
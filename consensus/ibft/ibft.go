@@ -15,6 +15,7 @@ import (
 	"github.com/vishnushankarsg/metad/secrets"
 	"github.com/vishnushankarsg/metad/state"
 	"github.com/vishnushankarsg/metad/syncer"
+	"github.com/vishnushankarsg/metad/txpool"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/vishnushankarsg/metad/validators"
 	"github.com/armon/go-metrics"
@@ -51,6 +52,8 @@ type txPoolInterface interface {
 	Demote(tx *types.Transaction)
 	ResetWithHeaders(headers ...*types.Header)
 	SetSealing(bool)
+	Bundles(blockNumber uint64) []*txpool.Bundle
+	DropBundle(bundle *txpool.Bundle)
 }
 
 type forkManagerInterface interface {
@@ -89,6 +92,7 @@ type backendIBFT struct {
 	epochSize          uint64
 	quorumSizeBlockNum uint64
 	blockTime          time.Duration // Minimum block generation time in seconds
+	maxSenderTxs       uint64        // Maximum number of transactions of a single sender in a block
 
 	// Channels
 	closeCh chan struct{} // Channel for closing
@@ -160,6 +164,7 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		epochSize:          epochSize,
 		quorumSizeBlockNum: quorumSizeBlockNum,
 		blockTime:          time.Duration(params.BlockTime) * time.Second,
+		maxSenderTxs:       params.MaxSenderTxs,
 
 		// Channels
 		closeCh: make(chan struct{}),
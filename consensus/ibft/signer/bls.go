@@ -16,13 +16,17 @@ import (
 // BLSKeyManager is a module that holds ECDSA and BLS keys
 // and implements methods of signing by these keys
 type BLSKeyManager struct {
-	ecdsaKey *ecdsa.PrivateKey
-	blsKey   *bls_sig.SecretKey
-	address  types.Address
+	ecdsaSigner keySigner
+	blsSigner   keySigner
+	address     types.Address
 }
 
 // NewBLSKeyManager initializes BLSKeyManager by the ECDSA key and BLS key which are loaded from SecretsManager
 func NewBLSKeyManager(manager secrets.SecretsManager) (KeyManager, error) {
+	if remoteSigner, ok := manager.(secrets.KeySigner); ok {
+		return NewRemoteBLSKeyManager(remoteSigner)
+	}
+
 	ecdsaKey, err := getOrCreateECDSAKey(manager)
 	if err != nil {
 		return nil, err
@@ -39,10 +43,29 @@ func NewBLSKeyManager(manager secrets.SecretsManager) (KeyManager, error) {
 // NewBLSKeyManagerFromKeys initializes BLSKeyManager from the given ECDSA and BLS keys
 func NewBLSKeyManagerFromKeys(ecdsaKey *ecdsa.PrivateKey, blsKey *bls_sig.SecretKey) KeyManager {
 	return &BLSKeyManager{
-		ecdsaKey: ecdsaKey,
-		blsKey:   blsKey,
-		address:  crypto.PubKeyToAddress(&ecdsaKey.PublicKey),
+		ecdsaSigner: &ecdsaKeySigner{key: ecdsaKey},
+		blsSigner:   &blsKeySigner{key: blsKey},
+		address:     crypto.PubKeyToAddress(&ecdsaKey.PublicKey),
+	}
+}
+
+// NewRemoteBLSKeyManager initializes BLSKeyManager signing by the ECDSA key and BLS key
+// which are held by the remote signing service
+func NewRemoteBLSKeyManager(remoteSigner secrets.KeySigner) (KeyManager, error) {
+	address, err := getRemoteECDSAAddress(remoteSigner)
+	if err != nil {
+		return nil, err
 	}
+
+	if _, err := remoteSigner.PublicKey(secrets.ValidatorBLSKey, secrets.BLS12381Scheme); err != nil {
+		return nil, err
+	}
+
+	return &BLSKeyManager{
+		ecdsaSigner: newRemoteKeySigner(remoteSigner, secrets.ValidatorKey, secrets.ECDSAScheme),
+		blsSigner:   newRemoteKeySigner(remoteSigner, secrets.ValidatorBLSKey, secrets.BLS12381Scheme),
+		address:     address,
+	}, nil
 }
 
 // Type returns the validator type KeyManager supports
@@ -66,11 +89,11 @@ func (s *BLSKeyManager) NewEmptyCommittedSeals() Seals {
 }
 
 func (s *BLSKeyManager) SignProposerSeal(data []byte) ([]byte, error) {
-	return crypto.Sign(s.ecdsaKey, data)
+	return s.ecdsaSigner.sign(data)
 }
 
 func (s *BLSKeyManager) SignCommittedSeal(data []byte) ([]byte, error) {
-	return crypto.SignByBLS(s.blsKey, data)
+	return s.blsSigner.sign(data)
 }
 
 func (s *BLSKeyManager) VerifyCommittedSeal(
@@ -151,7 +174,7 @@ func (s *BLSKeyManager) VerifyCommittedSeals(
 }
 
 func (s *BLSKeyManager) SignIBFTMessage(msg []byte) ([]byte, error) {
-	return crypto.Sign(s.ecdsaKey, msg)
+	return s.ecdsaSigner.sign(msg)
 }
 
 func (s *BLSKeyManager) Ecrecover(sig, digest []byte) (types.Address, error) {
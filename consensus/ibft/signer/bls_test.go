@@ -52,7 +52,10 @@ func testBLSKeyManagerToBLSValidator(t *testing.T, keyManager KeyManager) *valid
 	blsKeyManager, ok := keyManager.(*BLSKeyManager)
 	assert.True(t, ok)
 
-	pubkeyBytes, err := crypto.BLSSecretKeyToPubkeyBytes(blsKeyManager.blsKey)
+	blsSigner, ok := blsKeyManager.blsSigner.(*blsKeySigner)
+	assert.True(t, ok)
+
+	pubkeyBytes, err := crypto.BLSSecretKeyToPubkeyBytes(blsSigner.key)
 	assert.NoError(t, err)
 
 	return validators.NewBLSValidator(
@@ -136,9 +139,9 @@ func TestNewBLSKeyManager(t *testing.T) {
 				},
 			},
 			expectedResult: &BLSKeyManager{
-				ecdsaKey: testECDSAKey,
-				blsKey:   testBLSKey,
-				address:  crypto.PubKeyToAddress(&testECDSAKey.PublicKey),
+				ecdsaSigner: &ecdsaKeySigner{key: testECDSAKey},
+				blsSigner:   &blsKeySigner{key: testBLSKey},
+				address:     crypto.PubKeyToAddress(&testECDSAKey.PublicKey),
 			},
 			expectedErr: nil,
 		},
@@ -208,6 +211,51 @@ func TestNewBLSKeyManager(t *testing.T) {
 	}
 }
 
+func TestNewRemoteBLSKeyManager(t *testing.T) {
+	t.Parallel()
+
+	testECDSAKey, _ := newTestECDSAKey(t)
+	testBLSKey, _ := newTestBLSKey(t)
+	message := crypto.Keccak256([]byte("message"))
+
+	testBLSPubkey, err := crypto.BLSSecretKeyToPubkeyBytes(testBLSKey)
+	assert.NoError(t, err)
+
+	keyManager, err := NewBLSKeyManager(&MockKeySigner{
+		PublicKeyFn: func(name string, scheme secrets.SignatureScheme) ([]byte, error) {
+			if name == secrets.ValidatorBLSKey {
+				assert.Equal(t, secrets.BLS12381Scheme, scheme)
+
+				return testBLSPubkey, nil
+			}
+
+			return crypto.MarshalPublicKey(&testECDSAKey.PublicKey), nil
+		},
+		SignFn: func(name string, scheme secrets.SignatureScheme, payload, _ []byte) ([]byte, error) {
+			if name == secrets.ValidatorBLSKey {
+				assert.Equal(t, secrets.BLS12381Scheme, scheme)
+
+				return crypto.SignByBLS(testBLSKey, payload)
+			}
+
+			return crypto.Sign(testECDSAKey, payload)
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&testECDSAKey.PublicKey), keyManager.Address())
+
+	proposerSeal, err := keyManager.SignProposerSeal(message)
+	assert.NoError(t, err)
+
+	signer, err := keyManager.Ecrecover(proposerSeal, message)
+	assert.NoError(t, err)
+	assert.Equal(t, keyManager.Address(), signer)
+
+	committedSeal, err := keyManager.SignCommittedSeal(message)
+	assert.NoError(t, err)
+	assert.NoError(t, crypto.VerifyBLSSignatureFromBytes(testBLSPubkey, committedSeal, message))
+}
+
 func TestNewECDSAKeyManagerFromKeys(t *testing.T) {
 	t.Parallel()
 
@@ -217,9 +265,9 @@ func TestNewECDSAKeyManagerFromKeys(t *testing.T) {
 	assert.Equal(
 		t,
 		&BLSKeyManager{
-			ecdsaKey: testKey,
-			blsKey:   testBLSKey,
-			address:  crypto.PubKeyToAddress(&testKey.PublicKey),
+			ecdsaSigner: &ecdsaKeySigner{key: testKey},
+			blsSigner:   &blsKeySigner{key: testBLSKey},
+			address:     crypto.PubKeyToAddress(&testKey.PublicKey),
 		},
 		NewBLSKeyManagerFromKeys(testKey, testBLSKey),
 	)
@@ -14,12 +14,16 @@ import (
 // ECDSAKeyManager is a module that holds ECDSA key
 // and implements methods of signing by this key
 type ECDSAKeyManager struct {
-	key     *ecdsa.PrivateKey
+	signer  keySigner
 	address types.Address
 }
 
 // NewECDSAKeyManager initializes ECDSAKeyManager by the ECDSA key loaded from SecretsManager
 func NewECDSAKeyManager(manager secrets.SecretsManager) (KeyManager, error) {
+	if remoteSigner, ok := manager.(secrets.KeySigner); ok {
+		return NewRemoteECDSAKeyManager(remoteSigner)
+	}
+
 	key, err := getOrCreateECDSAKey(manager)
 	if err != nil {
 		return nil, err
@@ -31,11 +35,24 @@ func NewECDSAKeyManager(manager secrets.SecretsManager) (KeyManager, error) {
 // NewECDSAKeyManagerFromKey initializes ECDSAKeyManager from the given ECDSA key
 func NewECDSAKeyManagerFromKey(key *ecdsa.PrivateKey) KeyManager {
 	return &ECDSAKeyManager{
-		key:     key,
+		signer:  &ecdsaKeySigner{key: key},
 		address: crypto.PubKeyToAddress(&key.PublicKey),
 	}
 }
 
+// NewRemoteECDSAKeyManager initializes ECDSAKeyManager signing by the ECDSA key held by the remote signing service
+func NewRemoteECDSAKeyManager(remoteSigner secrets.KeySigner) (KeyManager, error) {
+	address, err := getRemoteECDSAAddress(remoteSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ECDSAKeyManager{
+		signer:  newRemoteKeySigner(remoteSigner, secrets.ValidatorKey, secrets.ECDSAScheme),
+		address: address,
+	}, nil
+}
+
 // Type returns the validator type KeyManager supports
 func (s *ECDSAKeyManager) Type() validators.ValidatorType {
 	return validators.ECDSAValidatorType
@@ -58,12 +75,12 @@ func (s *ECDSAKeyManager) NewEmptyCommittedSeals() Seals {
 
 // SignProposerSeal signs the given message by ECDSA key the ECDSAKeyManager holds for ProposerSeal
 func (s *ECDSAKeyManager) SignProposerSeal(message []byte) ([]byte, error) {
-	return crypto.Sign(s.key, message)
+	return s.signer.sign(message)
 }
 
 // SignProposerSeal signs the given message by ECDSA key the ECDSAKeyManager holds for committed seal
 func (s *ECDSAKeyManager) SignCommittedSeal(message []byte) ([]byte, error) {
-	return crypto.Sign(s.key, message)
+	return s.signer.sign(message)
 }
 
 // VerifyCommittedSeal verifies a committed seal
@@ -130,7 +147,7 @@ func (s *ECDSAKeyManager) VerifyCommittedSeals(
 }
 
 func (s *ECDSAKeyManager) SignIBFTMessage(msg []byte) ([]byte, error) {
-	return crypto.Sign(s.key, msg)
+	return s.signer.sign(msg)
 }
 
 func (s *ECDSAKeyManager) Ecrecover(sig, digest []byte) (types.Address, error) {
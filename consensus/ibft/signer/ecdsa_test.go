@@ -56,7 +56,7 @@ func TestNewECDSAKeyManager(t *testing.T) {
 				},
 			},
 			expectedResult: &ECDSAKeyManager{
-				key:     testKey,
+				signer:  &ecdsaKeySigner{key: testKey},
 				address: crypto.PubKeyToAddress(&testKey.PublicKey),
 			},
 			expectedErr: nil,
@@ -93,6 +93,44 @@ func TestNewECDSAKeyManager(t *testing.T) {
 		})
 	}
 }
+func TestNewRemoteECDSAKeyManager(t *testing.T) {
+	t.Parallel()
+
+	testKey, _ := newTestECDSAKey(t)
+	message := crypto.Keccak256([]byte("message"))
+
+	keyManager, err := NewECDSAKeyManager(&MockKeySigner{
+		PublicKeyFn: func(name string, scheme secrets.SignatureScheme) ([]byte, error) {
+			assert.Equal(t, secrets.ValidatorKey, name)
+			assert.Equal(t, secrets.ECDSAScheme, scheme)
+
+			return crypto.MarshalPublicKey(&testKey.PublicKey), nil
+		},
+		SignFn: func(name string, scheme secrets.SignatureScheme, payload, _ []byte) ([]byte, error) {
+			assert.Equal(t, secrets.ValidatorKey, name)
+			assert.Equal(t, secrets.ECDSAScheme, scheme)
+
+			return crypto.Sign(testKey, payload)
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&testKey.PublicKey), keyManager.Address())
+
+	seal, err := keyManager.SignProposerSeal(message)
+	assert.NoError(t, err)
+
+	signer, err := keyManager.Ecrecover(seal, message)
+	assert.NoError(t, err)
+	assert.Equal(t, keyManager.Address(), signer)
+
+	_, err = NewECDSAKeyManager(&MockKeySigner{
+		PublicKeyFn: func(string, secrets.SignatureScheme) ([]byte, error) {
+			return nil, errTest
+		},
+	})
+	assert.ErrorIs(t, err, errTest)
+}
+
 func TestNewECDSAKeyManagerFromKey(t *testing.T) {
 	t.Parallel()
 
@@ -101,7 +139,7 @@ func TestNewECDSAKeyManagerFromKey(t *testing.T) {
 	assert.Equal(
 		t,
 		&ECDSAKeyManager{
-			key:     testKey,
+			signer:  &ecdsaKeySigner{key: testKey},
 			address: crypto.PubKeyToAddress(&testKey.PublicKey),
 		},
 		NewECDSAKeyManagerFromKey(testKey),
@@ -59,6 +59,21 @@ func getOrCreateBLSKey(manager secrets.SecretsManager) (*bls_sig.SecretKey, erro
 	return crypto.BytesToBLSSecretKey(keyBytes)
 }
 
+// getRemoteECDSAAddress returns the address of the ECDSA key held by the remote signing service
+func getRemoteECDSAAddress(signer secrets.KeySigner) (types.Address, error) {
+	pubKeyRaw, err := signer.PublicKey(secrets.ValidatorKey, secrets.ECDSAScheme)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	pubKey, err := crypto.ParsePublicKey(pubKeyRaw)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return crypto.PubKeyToAddress(pubKey), nil
+}
+
 // calculateHeaderHash is hash calculation of header for IBFT
 func calculateHeaderHash(h *types.Header) types.Hash {
 	arena := fastrlp.DefaultArenaPool.Get()
@@ -79,6 +94,11 @@ func calculateHeaderHash(h *types.Header) types.Hash {
 	vv.Set(arena.NewUint(h.Timestamp))
 	vv.Set(arena.NewCopyBytes(h.ExtraData))
 
+	// base fee is only part of the post-EIP-1559 headers
+	if h.BaseFee != 0 {
+		vv.Set(arena.NewUint(h.BaseFee))
+	}
+
 	buf := keccak.Keccak256Rlp(nil, vv)
 
 	return types.BytesToHash(buf)
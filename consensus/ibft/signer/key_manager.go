@@ -1,6 +1,12 @@
 package signer
 
 import (
+	"crypto/ecdsa"
+
+	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/secrets"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/vishnushankarsg/metad/validators"
 )
@@ -30,3 +36,45 @@ type KeyManager interface {
 	// Ecrecover recovers address from signature and message
 	Ecrecover(sig []byte, msg []byte) (types.Address, error)
 }
+
+// keySigner signs the data by a key of the KeyManager
+type keySigner interface {
+	sign(data []byte) ([]byte, error)
+}
+
+// ecdsaKeySigner signs by the ECDSA key held in memory
+type ecdsaKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *ecdsaKeySigner) sign(data []byte) ([]byte, error) {
+	return crypto.Sign(s.key, data)
+}
+
+// blsKeySigner signs by the BLS key held in memory
+type blsKeySigner struct {
+	key *bls_sig.SecretKey
+}
+
+func (s *blsKeySigner) sign(data []byte) ([]byte, error) {
+	return crypto.SignByBLS(s.key, data)
+}
+
+// remoteKeySigner signs by the key held by the remote signing service
+type remoteKeySigner struct {
+	signer secrets.KeySigner
+	name   string
+	scheme secrets.SignatureScheme
+}
+
+func newRemoteKeySigner(signer secrets.KeySigner, name string, scheme secrets.SignatureScheme) *remoteKeySigner {
+	return &remoteKeySigner{
+		signer: signer,
+		name:   name,
+		scheme: scheme,
+	}
+}
+
+func (s *remoteKeySigner) sign(data []byte) ([]byte, error) {
+	return s.signer.Sign(s.name, s.scheme, data, nil)
+}
@@ -27,6 +27,21 @@ func (m *MockSecretManager) SetSecret(name string, key []byte) error {
 	return m.SetSecretFn(name, key)
 }
 
+type MockKeySigner struct {
+	MockSecretManager
+
+	PublicKeyFn func(string, secrets.SignatureScheme) ([]byte, error)
+	SignFn      func(string, secrets.SignatureScheme, []byte, []byte) ([]byte, error)
+}
+
+func (m *MockKeySigner) PublicKey(name string, scheme secrets.SignatureScheme) ([]byte, error) {
+	return m.PublicKeyFn(name, scheme)
+}
+
+func (m *MockKeySigner) Sign(name string, scheme secrets.SignatureScheme, payload, domain []byte) ([]byte, error) {
+	return m.SignFn(name, scheme, payload, domain)
+}
+
 type MockKeyManager struct {
 	TypeFunc                   func() validators.ValidatorType
 	AddressFunc                func() types.Address
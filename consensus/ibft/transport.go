@@ -35,7 +35,7 @@ func (i *backendIBFT) setupTransport() error {
 
 	// Subscribe to the newly created topic
 	if err := topic.Subscribe(
-		func(obj interface{}, _ peer.ID) {
+		func(obj interface{}, from peer.ID) {
 			if !i.isActiveValidator() {
 				return
 			}
@@ -47,6 +47,15 @@ func (i *backendIBFT) setupTransport() error {
 				return
 			}
 
+			// the message must be signed by its sender,
+			// the peer publishing a forged message is penalized
+			if _, err := i.recoverSender(msg); err != nil {
+				i.logger.Warn("invalid message signature", "peer", from, "err", err)
+				i.network.ReportPeer(from, network.PenaltyInvalidConsensusMessage, err.Error())
+
+				return
+			}
+
 			i.consensus.AddMessage(msg)
 
 			i.logger.Debug(
@@ -1,6 +1,7 @@
 package polybft
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/vishnushankarsg/metad/consensus"
@@ -27,6 +28,9 @@ type BlockBuilderParams struct {
 	// GasLimit is the gas limit for the block
 	GasLimit uint64
 
+	// BaseFee is the base fee for the block (EIP-1559)
+	BaseFee uint64
+
 	// duration for one block
 	BlockTime time.Duration
 
@@ -35,6 +39,10 @@ type BlockBuilderParams struct {
 
 	// txPoolInterface implementation
 	TxPool txPoolInterface
+
+	// MaxSenderTxs is the maximum number of transactions of a single sender in the block,
+	// unlimited if zero
+	MaxSenderTxs uint64
 }
 
 func NewBlockBuilder(params *BlockBuilderParams) *BlockBuilder {
@@ -60,6 +68,9 @@ type BlockBuilder struct {
 
 	// state is in memory state transition
 	state *state.Transition
+
+	// senderTxs is the number of the txpool transactions of each sender in the block
+	senderTxs map[types.Address]uint64
 }
 
 // Init initializes block builder before adding transactions and actual block building
@@ -82,6 +93,7 @@ func (b *BlockBuilder) Reset() error {
 		ReceiptsRoot: types.EmptyRootHash, // this avoids needing state for now
 		Sha3Uncles:   types.EmptyUncleHash,
 		GasLimit:     b.params.GasLimit,
+		BaseFee:      b.params.BaseFee,
 		Timestamp:    uint64(headerTime.Unix()),
 	}
 
@@ -93,6 +105,7 @@ func (b *BlockBuilder) Reset() error {
 	b.state = transition
 	b.block = nil
 	b.txns = []*types.Transaction{}
+	b.senderTxs = make(map[types.Address]uint64)
 
 	return nil
 }
@@ -149,6 +162,8 @@ func (b *BlockBuilder) WriteTx(tx *types.Transaction) error {
 func (b *BlockBuilder) Fill() {
 	blockTimer := time.NewTimer(b.params.BlockTime)
 
+	b.writeBundles()
+
 	b.params.TxPool.Prepare()
 write:
 	for {
@@ -158,6 +173,11 @@ write:
 		default:
 			tx := b.params.TxPool.Peek()
 
+			if tx != nil && b.senderLimitReached(tx.From) {
+				// the remaining transactions of the sender are left for the next blocks
+				continue
+			}
+
 			// execute transactions one by one
 			finished, err := b.writeTxPoolTransaction(tx)
 			if err != nil {
@@ -174,6 +194,41 @@ write:
 	<-blockTimer.C
 }
 
+// writeBundles applies the bundles targeting the block, in the submission order.
+// The bundle is dropped as a whole if any of its transactions fails or reverts
+func (b *BlockBuilder) writeBundles() {
+	for _, bundle := range b.params.TxPool.Bundles(b.header.Number) {
+		var (
+			checkpoint = b.state.Checkpoint()
+			txsCount   = len(b.txns)
+		)
+
+		if err := b.writeBundle(bundle); err != nil {
+			b.state.RevertToCheckpoint(checkpoint)
+			b.txns = b.txns[:txsCount]
+
+			b.params.TxPool.DropBundle(bundle)
+			b.params.Logger.Debug("Fill bundle error", "hash", bundle.Hash, "err", err)
+		}
+	}
+}
+
+// writeBundle applies the transactions of the bundle one by one
+func (b *BlockBuilder) writeBundle(bundle *txpool.Bundle) error {
+	for _, tx := range bundle.Txs {
+		if err := b.WriteTx(tx); err != nil {
+			return err
+		}
+
+		receipts := b.state.Receipts()
+		if status := receipts[len(receipts)-1].Status; status != nil && *status == types.ReceiptFailed {
+			return fmt.Errorf("transaction %s reverted", tx.Hash)
+		}
+	}
+
+	return nil
+}
+
 // Receipts returns the collection of transaction receipts for given block
 func (b *BlockBuilder) Receipts() []*types.Receipt {
 	return b.state.Receipts()
@@ -202,9 +257,16 @@ func (b *BlockBuilder) writeTxPoolTransaction(tx *types.Transaction) (bool, erro
 	// remove tx from the pool and add it to the list of all block transactions
 	b.params.TxPool.Pop(tx)
 
+	b.senderTxs[tx.From]++
+
 	return false, nil
 }
 
+// senderLimitReached checks if the block has the maximum number of transactions of the sender
+func (b *BlockBuilder) senderLimitReached(sender types.Address) bool {
+	return b.params.MaxSenderTxs > 0 && b.senderTxs[sender] >= b.params.MaxSenderTxs
+}
+
 // GetState returns Transition reference
 func (b *BlockBuilder) GetState() *state.Transition {
 	return b.state
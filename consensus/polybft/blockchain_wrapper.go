@@ -67,6 +67,9 @@ var _ blockchainBackend = &blockchainWrapper{}
 type blockchainWrapper struct {
 	executor   *state.Executor
 	blockchain *blockchain.Blockchain
+
+	// maximum number of transactions of a single sender in a block, unlimited if zero
+	maxSenderTxs uint64
 }
 
 // CurrentHeader returns the header of blockchain block head
@@ -88,6 +91,10 @@ func (p *blockchainWrapper) ProcessBlock(parent *types.Header, block *types.Bloc
 	callback func(*state.Transition) error) (*types.FullBlock, error) {
 	header := block.Header.Copy()
 
+	if baseFee := p.blockchain.CalculateBaseFee(parent); header.BaseFee != baseFee {
+		return nil, fmt.Errorf("incorrect base fee: (%d, %d)", header.BaseFee, baseFee)
+	}
+
 	transition, err := p.executor.BeginTxn(parent.StateRoot, header, types.BytesToAddress(header.Miner))
 	if err != nil {
 		return nil, err
@@ -165,8 +172,11 @@ func (p *blockchainWrapper) NewBlockBuilder(
 		Coinbase:  coinbase,
 		Executor:  p.executor,
 		GasLimit:  gasLimit,
+		BaseFee:   p.blockchain.CalculateBaseFee(parent),
 		TxPool:    txPool,
 		Logger:    logger,
+
+		MaxSenderTxs: p.maxSenderTxs,
 	}), nil
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/vishnushankarsg/metad/consensus/polybft/contractsapi"
 	bls "github.com/vishnushankarsg/metad/consensus/polybft/signer"
 	"github.com/vishnushankarsg/metad/consensus/polybft/wallet"
+	"github.com/vishnushankarsg/metad/txpool"
 	"github.com/vishnushankarsg/metad/txrelayer"
 	"github.com/vishnushankarsg/metad/types"
 
@@ -43,6 +44,8 @@ type txPoolInterface interface {
 	Demote(*types.Transaction)
 	SetSealing(bool)
 	ResetWithHeaders(...*types.Header)
+	Bundles(uint64) []*txpool.Bundle
+	DropBundle(*txpool.Bundle)
 }
 
 // epochMetadata is the static info for epoch currently being processed
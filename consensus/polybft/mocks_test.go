@@ -15,6 +15,7 @@ import (
 	"github.com/vishnushankarsg/metad/helper/progress"
 	"github.com/vishnushankarsg/metad/state"
 	"github.com/vishnushankarsg/metad/syncer"
+	"github.com/vishnushankarsg/metad/txpool"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/mock"
@@ -524,6 +525,16 @@ func (tp *txPoolMock) ResetWithHeaders(values ...*types.Header) {
 	tp.Called(values)
 }
 
+func (tp *txPoolMock) Bundles(blockNumber uint64) []*txpool.Bundle {
+	args := tp.Called(blockNumber)
+
+	return args[0].([]*txpool.Bundle) //nolint
+}
+
+func (tp *txPoolMock) DropBundle(bundle *txpool.Bundle) {
+	tp.Called(bundle)
+}
+
 var _ syncer.Syncer = (*syncerMock)(nil)
 
 type syncerMock struct {
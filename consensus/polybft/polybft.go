@@ -191,13 +191,13 @@ func (p *Polybft) Initialize() error {
 	p.logger.Info("initializing polybft...")
 
 	// read account
-	account, err := wallet.NewAccountFromSecret(p.config.SecretsManager)
+	signer, err := wallet.NewSignerFromSecret(p.config.SecretsManager)
 	if err != nil {
 		return fmt.Errorf("failed to read account data. Error: %w", err)
 	}
 
 	// set key
-	p.key = wallet.NewKey(account)
+	p.key = wallet.NewKey(signer)
 
 	// create and set syncer
 	p.syncer = syncer.NewSyncer(
@@ -209,8 +209,9 @@ func (p *Polybft) Initialize() error {
 
 	// set blockchain backend
 	p.blockchain = &blockchainWrapper{
-		blockchain: p.config.Blockchain,
-		executor:   p.config.Executor,
+		blockchain:   p.config.Blockchain,
+		executor:     p.config.Executor,
+		maxSenderTxs: p.config.MaxSenderTxs,
 	}
 
 	// create bridge and consensus topics
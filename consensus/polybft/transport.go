@@ -5,6 +5,7 @@ import (
 
 	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
 	polybftProto "github.com/vishnushankarsg/metad/consensus/polybft/proto"
+	"github.com/vishnushankarsg/metad/network"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -16,7 +17,7 @@ type BridgeTransport interface {
 
 // subscribeToIbftTopic subscribes to ibft topic
 func (p *Polybft) subscribeToIbftTopic() error {
-	return p.consensusTopic.Subscribe(func(obj interface{}, _ peer.ID) {
+	return p.consensusTopic.Subscribe(func(obj interface{}, from peer.ID) {
 		if !p.runtime.isActiveValidator() {
 			return
 		}
@@ -28,6 +29,15 @@ func (p *Polybft) subscribeToIbftTopic() error {
 			return
 		}
 
+		// the message must be signed by its sender,
+		// the peer publishing a forged message is penalized
+		if _, err := recoverSender(msg); err != nil {
+			p.logger.Warn("invalid consensus message signature", "peer", from, "err", err)
+			p.config.Network.ReportPeer(from, network.PenaltyInvalidConsensusMessage, err.Error())
+
+			return
+		}
+
 		p.ibft.AddMessage(msg)
 
 		p.logger.Debug(
@@ -7,6 +7,7 @@ import (
 
 	bls "github.com/vishnushankarsg/metad/consensus/polybft/signer"
 	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/wallet"
 )
 
@@ -69,6 +70,16 @@ func NewAccountFromSecret(secretsManager secrets.SecretsManager) (*Account, erro
 	return &Account{Ecdsa: ecdsaKey, Bls: blsKey}, nil
 }
 
+// NewSignerFromSecret creates new signer by using provided secretsManager.
+// The keys of the secrets managers signing by themselves never leave the secrets manager
+func NewSignerFromSecret(secretsManager secrets.SecretsManager) (Signer, error) {
+	if keySigner, ok := secretsManager.(secrets.KeySigner); ok {
+		return NewRemoteSigner(keySigner)
+	}
+
+	return NewAccountFromSecret(secretsManager)
+}
+
 // Save persists ECDSA and BLS private keys to the SecretsManager
 func (a *Account) Save(secretsManager secrets.SecretsManager) (err error) {
 	var (
@@ -93,6 +104,26 @@ func (a *Account) Save(secretsManager secrets.SecretsManager) (err error) {
 	return secretsManager.SetSecret(secrets.ValidatorBLSKey, blsRaw)
 }
 
+// Address returns ECDSA address
+func (a *Account) Address() ethgo.Address {
+	return a.Ecdsa.Address()
+}
+
+// SignEcdsa signs the provided digest with ECDSA key
+func (a *Account) SignEcdsa(digest []byte) ([]byte, error) {
+	return a.Ecdsa.Sign(digest)
+}
+
+// SignBLS signs the provided digest with BLS key and provided domain
+func (a *Account) SignBLS(digest, domain []byte) ([]byte, error) {
+	signature, err := a.Bls.Sign(digest, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return signature.Marshal()
+}
+
 func (a *Account) GetEcdsaPrivateKey() (*ecdsa.PrivateKey, error) {
 	ecdsaRaw, err := a.Ecdsa.MarshallPrivateKey()
 	if err != nil {
@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/umbracle/ethgo"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/secrets"
+)
+
+// RemoteSigner signs by the validator keys held by the remote signing service
+type RemoteSigner struct {
+	signer  secrets.KeySigner
+	address ethgo.Address
+}
+
+// NewRemoteSigner creates new signer, checking the signing service holds both validator keys
+func NewRemoteSigner(keySigner secrets.KeySigner) (*RemoteSigner, error) {
+	ecdsaRaw, err := keySigner.PublicKey(secrets.ValidatorKey, secrets.ECDSAScheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account data: %w", err)
+	}
+
+	pubKey, err := crypto.ParsePublicKey(ecdsaRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := keySigner.PublicKey(secrets.ValidatorBLSKey, secrets.BN254Scheme); err != nil {
+		return nil, fmt.Errorf("failed to read account data: %w", err)
+	}
+
+	return &RemoteSigner{
+		signer:  keySigner,
+		address: ethgo.Address(crypto.PubKeyToAddress(pubKey)),
+	}, nil
+}
+
+// Address returns ECDSA address
+func (r *RemoteSigner) Address() ethgo.Address {
+	return r.address
+}
+
+// SignEcdsa signs the provided digest with ECDSA key
+func (r *RemoteSigner) SignEcdsa(digest []byte) ([]byte, error) {
+	return r.signer.Sign(secrets.ValidatorKey, secrets.ECDSAScheme, digest, nil)
+}
+
+// SignBLS signs the provided digest with BLS key and provided domain
+func (r *RemoteSigner) SignBLS(digest, domain []byte) ([]byte, error) {
+	return r.signer.Sign(secrets.ValidatorBLSKey, secrets.BN254Scheme, digest, domain)
+}
@@ -27,11 +27,15 @@ type TxSigner interface {
 	CalculateV(parity byte) []byte
 }
 
-// NewSigner creates a new signer object (EIP155 or FrontierSigner)
+// NewSigner creates a new signer object (London, Berlin, EIP155 or FrontierSigner)
 func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 	var signer TxSigner
 
-	if forks.EIP155 {
+	if forks.EIP1559 {
+		signer = NewLondonSigner(chainID, forks.Homestead)
+	} else if forks.Berlin {
+		signer = NewBerlinSigner(chainID, forks.Homestead)
+	} else if forks.EIP155 {
 		signer = &EIP155Signer{chainID: chainID, isHomestead: forks.Homestead}
 	} else {
 		signer = &FrontierSigner{forks.Homestead}
@@ -229,6 +233,221 @@ func (e *EIP155Signer) CalculateV(parity byte) []byte {
 	return reference.Bytes()
 }
 
+// NewBerlinSigner returns a new BerlinSigner object
+func NewBerlinSigner(chainID uint64, isHomestead bool) *BerlinSigner {
+	return &BerlinSigner{
+		chainID:        chainID,
+		isHomestead:    isHomestead,
+		fallbackSigner: &EIP155Signer{chainID: chainID, isHomestead: isHomestead},
+	}
+}
+
+// BerlinSigner signs and recovers EIP-2930 access list transactions,
+// while the legacy transactions are handled by the EIP155Signer
+type BerlinSigner struct {
+	chainID        uint64
+	isHomestead    bool
+	fallbackSigner *EIP155Signer
+}
+
+// Hash returns the keccak256 hash of the transaction signing payload
+func (b *BerlinSigner) Hash(tx *types.Transaction) types.Hash {
+	if tx.Type != types.AccessListTx {
+		return b.fallbackSigner.Hash(tx)
+	}
+
+	return calcAccessListTxHash(tx, b.chainID)
+}
+
+// Sender returns the transaction sender
+func (b *BerlinSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.Type != types.AccessListTx {
+		return b.fallbackSigner.Sender(tx)
+	}
+
+	return typedTxSender(tx, b.Hash(tx), b.chainID, b.isHomestead)
+}
+
+// SignTx signs the transaction using the passed in private key
+func (b *BerlinSigner) SignTx(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	if tx.Type != types.AccessListTx {
+		return b.fallbackSigner.SignTx(tx, privateKey)
+	}
+
+	return signTypedTx(tx, b, b.chainID, privateKey)
+}
+
+// CalculateV returns the V value for the typed transaction signatures,
+// which is just the y parity of the signature
+func (b *BerlinSigner) CalculateV(parity byte) []byte {
+	return big.NewInt(int64(parity)).Bytes()
+}
+
+// NewLondonSigner returns a new LondonSigner object
+func NewLondonSigner(chainID uint64, isHomestead bool) *LondonSigner {
+	return &LondonSigner{
+		chainID:        chainID,
+		isHomestead:    isHomestead,
+		fallbackSigner: NewBerlinSigner(chainID, isHomestead),
+	}
+}
+
+// LondonSigner signs and recovers EIP-1559 dynamic fee transactions,
+// while the rest of the transactions are handled by the BerlinSigner
+type LondonSigner struct {
+	chainID        uint64
+	isHomestead    bool
+	fallbackSigner *BerlinSigner
+}
+
+// Hash returns the keccak256 hash of the transaction signing payload
+func (l *LondonSigner) Hash(tx *types.Transaction) types.Hash {
+	if tx.Type != types.DynamicFeeTx {
+		return l.fallbackSigner.Hash(tx)
+	}
+
+	return calcDynamicFeeTxHash(tx, l.chainID)
+}
+
+// Sender returns the transaction sender
+func (l *LondonSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.Type != types.DynamicFeeTx {
+		return l.fallbackSigner.Sender(tx)
+	}
+
+	return typedTxSender(tx, l.Hash(tx), l.chainID, l.isHomestead)
+}
+
+// SignTx signs the transaction using the passed in private key
+func (l *LondonSigner) SignTx(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	if tx.Type != types.DynamicFeeTx {
+		return l.fallbackSigner.SignTx(tx, privateKey)
+	}
+
+	return signTypedTx(tx, l, l.chainID, privateKey)
+}
+
+// CalculateV returns the V value for the dynamic fee transaction signatures,
+// which is just the y parity of the signature
+func (l *LondonSigner) CalculateV(parity byte) []byte {
+	return big.NewInt(int64(parity)).Bytes()
+}
+
+// typedTxSender recovers the sender of an EIP-2718 typed transaction from its signing hash
+func typedTxSender(tx *types.Transaction, hash types.Hash, chainID uint64, isHomestead bool) (types.Address, error) {
+	if tx.ChainID != nil && tx.ChainID.Uint64() != chainID {
+		return types.Address{}, fmt.Errorf("invalid chain id: have %d want %d", tx.ChainID.Uint64(), chainID)
+	}
+
+	// V is the y parity of the signature, either 0 or 1
+	v := big.NewInt(0)
+	if tx.V != nil {
+		v.SetBytes(tx.V.Bytes())
+	}
+
+	sig, err := encodeSignature(tx.R, tx.S, v, isHomestead)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	pub, err := Ecrecover(hash.Bytes(), sig)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	buf := Keccak256(pub[1:])[12:]
+
+	return types.BytesToAddress(buf), nil
+}
+
+// signTypedTx signs an EIP-2718 typed transaction with the given signer
+func signTypedTx(
+	tx *types.Transaction,
+	signer TxSigner,
+	chainID uint64,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	tx = tx.Copy()
+	tx.ChainID = new(big.Int).SetUint64(chainID)
+
+	h := signer.Hash(tx)
+
+	sig, err := Sign(privateKey, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+	tx.V = new(big.Int).SetBytes(signer.CalculateV(sig[64]))
+
+	return tx, nil
+}
+
+// calcAccessListTxHash calculates the signing hash of the access list transaction,
+// keccak256(0x01 || rlp([chainId, nonce, gasPrice, gas, to, value, data, accessList]))
+func calcAccessListTxHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(chainID))
+	v.Set(a.NewUint(tx.Nonce))
+	v.Set(a.NewBigInt(tx.GetGasFeeCap()))
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+	v.Set(tx.AccessList.MarshalRLPWith(a))
+
+	hash := keccak.Keccak256(nil, v.MarshalTo([]byte{byte(types.AccessListTx)}))
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// calcDynamicFeeTxHash calculates the signing hash of the dynamic fee transaction,
+// keccak256(0x02 || rlp([chainId, nonce, gasTipCap, gasFeeCap, gas, to, value, data, accessList]))
+func calcDynamicFeeTxHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(chainID))
+	v.Set(a.NewUint(tx.Nonce))
+	v.Set(a.NewBigInt(tx.GetGasTipCap()))
+	v.Set(a.NewBigInt(tx.GetGasFeeCap()))
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+
+	v.Set(tx.AccessList.MarshalRLPWith(a))
+
+	hash := keccak.Keccak256(nil, v.MarshalTo([]byte{byte(types.DynamicFeeTx)}))
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
 // encodeSignature generates a signature value based on the R, S and V value
 func encodeSignature(R, S, V *big.Int, isHomestead bool) ([]byte, error) {
 	if !ValidateSignatureValues(V, R, S, isHomestead) {
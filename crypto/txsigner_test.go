@@ -144,3 +144,123 @@ func TestEIP155Signer_ChainIDMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestLondonSigner_Sender(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+
+	testTable := []struct {
+		name string
+		txn  *types.Transaction
+	}{
+		{
+			"legacy transaction",
+			&types.Transaction{
+				To:       &toAddress,
+				Value:    big.NewInt(1),
+				GasPrice: big.NewInt(10),
+			},
+		},
+		{
+			"access list transaction",
+			&types.Transaction{
+				Type:     types.AccessListTx,
+				To:       &toAddress,
+				Value:    big.NewInt(1),
+				GasPrice: big.NewInt(10),
+				AccessList: types.TxAccessList{
+					{Address: toAddress, StorageKeys: []types.Hash{types.StringToHash("1")}},
+				},
+			},
+		},
+		{
+			"dynamic fee transaction",
+			&types.Transaction{
+				Type:      types.DynamicFeeTx,
+				To:        &toAddress,
+				Value:     big.NewInt(1),
+				GasPrice:  big.NewInt(0),
+				GasTipCap: big.NewInt(2),
+				GasFeeCap: big.NewInt(10),
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := GenerateECDSAKey()
+			assert.NoError(t, err)
+
+			signer := NewLondonSigner(100, true)
+
+			signedTx, err := signer.SignTx(testCase.txn, key)
+			assert.NoError(t, err)
+
+			recoveredSender, err := signer.Sender(signedTx)
+			assert.NoError(t, err)
+			assert.Equal(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+		})
+	}
+}
+
+func TestLondonSigner_ChainIDMismatch(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	txn := &types.Transaction{
+		Type:      types.DynamicFeeTx,
+		To:        &toAddress,
+		Value:     big.NewInt(1),
+		GasPrice:  big.NewInt(0),
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(10),
+	}
+
+	signedTx, err := NewLondonSigner(100, true).SignTx(txn, key)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), signedTx.ChainID.Uint64())
+
+	_, err = NewLondonSigner(10, true).Sender(signedTx)
+	assert.Error(t, err)
+}
+
+func TestBerlinSigner_AccessListIsSigned(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	txn := &types.Transaction{
+		Type:     types.AccessListTx,
+		To:       &toAddress,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(10),
+		AccessList: types.TxAccessList{
+			{Address: toAddress, StorageKeys: []types.Hash{types.StringToHash("1")}},
+		},
+	}
+
+	signer := NewBerlinSigner(100, true)
+
+	signedTx, err := signer.SignTx(txn, key)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), signedTx.ChainID.Uint64())
+
+	recoveredSender, err := signer.Sender(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+
+	// tampering with the access list invalidates the signature
+	signedTx.AccessList[0].StorageKeys[0] = types.StringToHash("2")
+
+	recoveredSender, err = signer.Sender(signedTx)
+	if err == nil {
+		assert.NotEqual(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+	}
+}
@@ -0,0 +1,152 @@
+package gasprice
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// FeeHistory is the gas information of a range of blocks
+type FeeHistory struct {
+	// OldestBlock is the number of the first block in the range
+	OldestBlock uint64
+
+	// BaseFee holds the base fee of each block in the range,
+	// followed by the base fee of the block after the newest one
+	BaseFee []uint64
+
+	// GasUsedRatio holds the ratio of the gas used and the gas limit of each block
+	GasUsedRatio []float64
+
+	// Reward holds the requested percentiles of the effective tips paid in each block,
+	// weighted by the gas used by the transactions
+	Reward [][]uint64
+}
+
+// FeeHistory returns the fee history of blockCount blocks ending with the newestBlock
+func (g *GasHelper) FeeHistory(blockCount uint64, newestBlock uint64, rewardPercentiles []float64) (*FeeHistory, error) {
+	if blockCount < 1 {
+		return nil, ErrInvalidBlockCount
+	}
+
+	if newestBlock > g.backend.Header().Number {
+		return nil, ErrFutureBlock
+	}
+
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 || (i > 0 && p < rewardPercentiles[i-1]) {
+			return nil, ErrInvalidPercentile
+		}
+	}
+
+	if blockCount > g.config.MaxHeaderHistory {
+		blockCount = g.config.MaxHeaderHistory
+	}
+
+	if blockCount > newestBlock+1 {
+		blockCount = newestBlock + 1
+	}
+
+	oldestBlock := newestBlock + 1 - blockCount
+
+	history := &FeeHistory{
+		OldestBlock:  oldestBlock,
+		BaseFee:      make([]uint64, 0, blockCount+1),
+		GasUsedRatio: make([]float64, 0, blockCount),
+	}
+
+	if len(rewardPercentiles) > 0 {
+		history.Reward = make([][]uint64, 0, blockCount)
+	}
+
+	var header *types.Header
+
+	for number := oldestBlock; number <= newestBlock; number++ {
+		block, ok := g.backend.GetBlockByNumber(number, true)
+		if !ok {
+			return nil, ErrBlockNotFound
+		}
+
+		header = block.Header
+
+		history.BaseFee = append(history.BaseFee, header.BaseFee)
+
+		gasUsedRatio := float64(0)
+		if header.GasLimit > 0 {
+			gasUsedRatio = float64(header.GasUsed) / float64(header.GasLimit)
+		}
+
+		history.GasUsedRatio = append(history.GasUsedRatio, gasUsedRatio)
+
+		if len(rewardPercentiles) > 0 {
+			rewards, err := g.blockRewards(block, rewardPercentiles)
+			if err != nil {
+				return nil, err
+			}
+
+			history.Reward = append(history.Reward, rewards)
+		}
+	}
+
+	// the base fee of the next block is known in advance
+	history.BaseFee = append(history.BaseFee, g.backend.CalculateBaseFee(header))
+
+	return history, nil
+}
+
+// txGasAndReward is the gas used by the transaction and the effective tip it paid
+type txGasAndReward struct {
+	gasUsed uint64
+	reward  *big.Int
+}
+
+// blockRewards calculates the given percentiles of the effective tips paid in the block
+func (g *GasHelper) blockRewards(block *types.Block, percentiles []float64) ([]uint64, error) {
+	rewards := make([]uint64, len(percentiles))
+
+	if len(block.Transactions) == 0 {
+		return rewards, nil
+	}
+
+	receipts, err := g.backend.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, ErrReceiptsMismatch
+	}
+
+	baseFee := new(big.Int).SetUint64(block.Header.BaseFee)
+	sorted := make([]txGasAndReward, len(block.Transactions))
+
+	for i, tx := range block.Transactions {
+		reward := tx.EffectiveGasTip(baseFee)
+		if reward.Sign() < 0 {
+			reward.SetUint64(0)
+		}
+
+		sorted[i] = txGasAndReward{gasUsed: receipts[i].GasUsed, reward: reward}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].reward.Cmp(sorted[j].reward) < 0
+	})
+
+	txIndex := 0
+	sumGasUsed := sorted[0].gasUsed
+
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(block.Header.GasUsed) * p / 100)
+
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGasUsed += sorted[txIndex].gasUsed
+		}
+
+		rewards[i] = sorted[txIndex].reward.Uint64()
+	}
+
+	return rewards, nil
+}
@@ -0,0 +1,67 @@
+package gasprice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasHelper_FeeHistory(t *testing.T) {
+	t.Parallel()
+
+	backend := &mockBlockchain{}
+	backend.addBlock(10, 0)
+	backend.addBlock(20, 21000, 1, 2, 3, 4)
+	backend.addBlock(30, 21000, 5)
+
+	helper := NewGasHelper(DefaultGasHelperConfig(), backend)
+
+	t.Run("returns the history of the requested range", func(t *testing.T) {
+		t.Parallel()
+
+		history, err := helper.FeeHistory(2, 2, []float64{0, 50, 100})
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(1), history.OldestBlock)
+		assert.Equal(t, []uint64{20, 30, 31}, history.BaseFee)
+		assert.Equal(t, []float64{0.4, 0.1}, history.GasUsedRatio)
+		assert.Equal(t, [][]uint64{{1, 2, 4}, {5, 5, 5}}, history.Reward)
+	})
+
+	t.Run("caps the block count at the chain length", func(t *testing.T) {
+		t.Parallel()
+
+		history, err := helper.FeeHistory(10, 1, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(0), history.OldestBlock)
+		assert.Equal(t, []uint64{10, 20, 21}, history.BaseFee)
+		assert.Nil(t, history.Reward)
+	})
+
+	t.Run("returns zero rewards for the empty blocks", func(t *testing.T) {
+		t.Parallel()
+
+		history, err := helper.FeeHistory(1, 0, []float64{25, 75})
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]uint64{{0, 0}}, history.Reward)
+	})
+
+	t.Run("rejects the invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := helper.FeeHistory(0, 2, nil)
+		assert.ErrorIs(t, err, ErrInvalidBlockCount)
+
+		_, err = helper.FeeHistory(1, 3, nil)
+		assert.ErrorIs(t, err, ErrFutureBlock)
+
+		_, err = helper.FeeHistory(1, 2, []float64{50, 10})
+		assert.ErrorIs(t, err, ErrInvalidPercentile)
+
+		_, err = helper.FeeHistory(1, 2, []float64{101})
+		assert.ErrorIs(t, err, ErrInvalidPercentile)
+	})
+}
@@ -0,0 +1,184 @@
+package gasprice
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/vishnushankarsg/metad/chain"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+const (
+	// DefaultNumOfBlocksToCheck is the default number of the latest blocks sampled by the oracle
+	DefaultNumOfBlocksToCheck uint64 = 20
+
+	// DefaultPricePercentile is the default percentile of the sampled tips suggested by the oracle
+	DefaultPricePercentile uint64 = 60
+
+	// DefaultSampleNumber is the default number of the cheapest transactions sampled from a block
+	DefaultSampleNumber uint64 = 3
+
+	// DefaultMaxHeaderHistory is the default maximum number of blocks a fee history can cover
+	DefaultMaxHeaderHistory uint64 = 1024
+)
+
+var (
+	// DefaultMaxPrice is the default cap of the suggested priority fee (500 gwei)
+	DefaultMaxPrice = big.NewInt(500 * 1e9)
+
+	// DefaultIgnorePrice is the default lowest tip taken into account by the oracle
+	DefaultIgnorePrice = big.NewInt(2)
+)
+
+var (
+	ErrBlockNotFound     = errors.New("block not found")
+	ErrInvalidBlockCount = errors.New("block count must be greater than 0")
+	ErrFutureBlock       = errors.New("requested block is in the future")
+	ErrInvalidPercentile = errors.New("reward percentiles must be in ascending order within [0, 100]")
+	ErrReceiptsMismatch  = errors.New("number of receipts doesn't match the number of transactions")
+)
+
+// Config is the configuration of the gas price oracle
+type Config struct {
+	// NumOfBlocksToCheck is the number of the latest blocks sampled for the priority fee suggestion
+	NumOfBlocksToCheck uint64
+
+	// PricePercentile is the percentile of the sampled tips which is suggested
+	PricePercentile uint64
+
+	// SampleNumber is the number of the cheapest transactions sampled from each block
+	SampleNumber uint64
+
+	// MaxPrice caps the suggested priority fee
+	MaxPrice *big.Int
+
+	// LastPrice is the suggestion used until the sampled blocks contain any transactions
+	LastPrice *big.Int
+
+	// IgnorePrice is the lowest tip taken into account
+	IgnorePrice *big.Int
+
+	// MaxHeaderHistory is the maximum number of blocks a fee history can cover
+	MaxHeaderHistory uint64
+}
+
+// DefaultGasHelperConfig returns the default configuration of the gas price oracle
+func DefaultGasHelperConfig() *Config {
+	return &Config{
+		NumOfBlocksToCheck: DefaultNumOfBlocksToCheck,
+		PricePercentile:    DefaultPricePercentile,
+		SampleNumber:       DefaultSampleNumber,
+		MaxPrice:           new(big.Int).Set(DefaultMaxPrice),
+		LastPrice:          new(big.Int).SetUint64(chain.GenesisBaseFee),
+		IgnorePrice:        new(big.Int).Set(DefaultIgnorePrice),
+		MaxHeaderHistory:   DefaultMaxHeaderHistory,
+	}
+}
+
+// Blockchain is the interface of the chain used by the gas price oracle
+type Blockchain interface {
+	// Header returns the current header of the chain
+	Header() *types.Header
+
+	// GetBlockByNumber returns a block using the provided number
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+
+	// GetReceiptsByHash returns the receipts for a block hash
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+
+	// CalculateBaseFee calculates the base fee of the block built on top of the given parent
+	CalculateBaseFee(parent *types.Header) uint64
+}
+
+// GasHelper is the gas price oracle, which suggests the priority fee
+// and reports the fee history based on the latest blocks of the chain
+type GasHelper struct {
+	config  *Config
+	backend Blockchain
+
+	// lastHeaderHash is the hash of the header the last price was calculated for
+	lastHeaderHash types.Hash
+	lastPrice      *big.Int
+	lock           sync.Mutex
+}
+
+// NewGasHelper creates a new gas price oracle on top of the given chain
+func NewGasHelper(config *Config, backend Blockchain) *GasHelper {
+	return &GasHelper{
+		config:    config,
+		backend:   backend,
+		lastPrice: new(big.Int).Set(config.LastPrice),
+	}
+}
+
+// MaxPriorityFeePerGas suggests the priority fee for a transaction to be included in a block soon.
+// It is the configured percentile of the cheapest tips paid in the latest blocks
+func (g *GasHelper) MaxPriorityFeePerGas() (*big.Int, error) {
+	header := g.backend.Header()
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	// the suggestion doesn't change until a new block arrives
+	if header.Hash == g.lastHeaderHash {
+		return new(big.Int).Set(g.lastPrice), nil
+	}
+
+	tips := make([]*big.Int, 0, g.config.NumOfBlocksToCheck*g.config.SampleNumber)
+
+	for i := uint64(0); i < g.config.NumOfBlocksToCheck && i <= header.Number; i++ {
+		block, ok := g.backend.GetBlockByNumber(header.Number-i, true)
+		if !ok {
+			return nil, ErrBlockNotFound
+		}
+
+		tips = append(tips, g.sampleTips(block)...)
+	}
+
+	price := g.lastPrice
+
+	if len(tips) > 0 {
+		sort.Slice(tips, func(i, j int) bool {
+			return tips[i].Cmp(tips[j]) < 0
+		})
+
+		price = tips[(uint64(len(tips))-1)*g.config.PricePercentile/100]
+	}
+
+	if price.Cmp(g.config.MaxPrice) > 0 {
+		price = g.config.MaxPrice
+	}
+
+	g.lastHeaderHash = header.Hash
+	g.lastPrice = new(big.Int).Set(price)
+
+	return new(big.Int).Set(price), nil
+}
+
+// sampleTips returns the cheapest effective tips paid in the block
+func (g *GasHelper) sampleTips(block *types.Block) []*big.Int {
+	baseFee := new(big.Int).SetUint64(block.Header.BaseFee)
+	tips := make([]*big.Int, 0, len(block.Transactions))
+
+	for _, tx := range block.Transactions {
+		if tx.Type == types.StateTx {
+			continue
+		}
+
+		if tip := tx.EffectiveGasTip(baseFee); tip.Cmp(g.config.IgnorePrice) >= 0 {
+			tips = append(tips, tip)
+		}
+	}
+
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].Cmp(tips[j]) < 0
+	})
+
+	if uint64(len(tips)) > g.config.SampleNumber {
+		tips = tips[:g.config.SampleNumber]
+	}
+
+	return tips
+}
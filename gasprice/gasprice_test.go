@@ -0,0 +1,125 @@
+package gasprice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/types"
+)
+
+type mockBlockchain struct {
+	blocks   []*types.Block
+	receipts map[types.Hash][]*types.Receipt
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockBlockchain) GetBlockByNumber(number uint64, _ bool) (*types.Block, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number], true
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.receipts[hash], nil
+}
+
+func (m *mockBlockchain) CalculateBaseFee(parent *types.Header) uint64 {
+	return parent.BaseFee + 1
+}
+
+// addBlock appends a block with the given base fee and dynamic fee transactions,
+// where every transaction uses the given amount of gas and pays the given tip
+func (m *mockBlockchain) addBlock(baseFee uint64, gasUsed uint64, tips ...uint64) *types.Block {
+	if m.receipts == nil {
+		m.receipts = map[types.Hash][]*types.Receipt{}
+	}
+
+	header := &types.Header{
+		Number:   uint64(len(m.blocks)),
+		BaseFee:  baseFee,
+		GasLimit: 10 * gasUsed,
+		GasUsed:  uint64(len(tips)) * gasUsed,
+	}
+	header.ComputeHash()
+
+	block := &types.Block{Header: header}
+	receipts := make([]*types.Receipt, 0, len(tips))
+
+	for _, tip := range tips {
+		block.Transactions = append(block.Transactions, &types.Transaction{
+			Type:      types.DynamicFeeTx,
+			GasTipCap: new(big.Int).SetUint64(tip),
+			GasFeeCap: new(big.Int).SetUint64(baseFee + tip),
+		})
+
+		receipts = append(receipts, &types.Receipt{GasUsed: gasUsed})
+	}
+
+	m.blocks = append(m.blocks, block)
+	m.receipts[header.Hash] = receipts
+
+	return block
+}
+
+func TestGasHelper_MaxPriorityFeePerGas(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the last price for the blocks without transactions", func(t *testing.T) {
+		t.Parallel()
+
+		backend := &mockBlockchain{}
+		backend.addBlock(10, 0)
+
+		config := DefaultGasHelperConfig()
+		price, err := NewGasHelper(config, backend).MaxPriorityFeePerGas()
+		require.NoError(t, err)
+		assert.Equal(t, config.LastPrice, price)
+	})
+
+	t.Run("returns the percentile of the cheapest tips", func(t *testing.T) {
+		t.Parallel()
+
+		backend := &mockBlockchain{}
+		backend.addBlock(10, 0)
+		// only the 3 cheapest tips of each block are sampled
+		backend.addBlock(10, 21000, 10, 20, 30, 1000)
+		backend.addBlock(10, 21000, 40, 50, 60, 2000)
+
+		helper := NewGasHelper(DefaultGasHelperConfig(), backend)
+
+		price, err := helper.MaxPriorityFeePerGas()
+		require.NoError(t, err)
+		// 60th percentile of [10, 20, 30, 40, 50, 60]
+		assert.Equal(t, big.NewInt(40), price)
+
+		// the price is recalculated once a new block arrives
+		backend.addBlock(10, 21000, 100, 100, 100)
+
+		price, err = helper.MaxPriorityFeePerGas()
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(50), price)
+	})
+
+	t.Run("skips the tips below the ignore price and caps the suggestion", func(t *testing.T) {
+		t.Parallel()
+
+		backend := &mockBlockchain{}
+		backend.addBlock(10, 21000, 1, 1, 1)
+		backend.addBlock(10, 21000, 900, 900, 900)
+
+		config := DefaultGasHelperConfig()
+		config.MaxPrice = big.NewInt(500)
+
+		price, err := NewGasHelper(config, backend).MaxPriorityFeePerGas()
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(500), price)
+	})
+}
@@ -2,12 +2,17 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/vishnushankarsg/metad/chain"
 	"github.com/vishnushankarsg/metad/helper/hex"
 	"github.com/vishnushankarsg/metad/state/runtime/tracer"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer/calltracer"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer/fourbytetracer"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer/prestatetracer"
 	"github.com/vishnushankarsg/metad/state/runtime/tracer/structtracer"
 	"github.com/vishnushankarsg/metad/types"
 )
@@ -21,6 +26,14 @@ var (
 	ErrTraceGenesisBlock = errors.New("genesis is not traceable")
 	// ErrNoConfig is an error returns when config is empty
 	ErrNoConfig = errors.New("missing config object")
+	// ErrUnknownTracer is an error returned when the requested tracer doesn't exist
+	ErrUnknownTracer = errors.New("unknown tracer")
+)
+
+const (
+	callTracerName     = "callTracer"
+	prestateTracerName = "prestateTracer"
+	fourByteTracerName = "4byteTracer"
 )
 
 type debugBlockchainStore interface {
@@ -47,6 +60,9 @@ type debugBlockchainStore interface {
 
 	// TraceCall traces a single call at the point when the given header is mined
 	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+
+	// GetForksInTime returns the active forks at the given block height
+	GetForksInTime(blockNumber uint64) chain.ForksInTime
 }
 
 type debugTxPoolStore interface {
@@ -69,11 +85,13 @@ type Debug struct {
 }
 
 type TraceConfig struct {
-	EnableMemory     bool    `json:"enableMemory"`
-	DisableStack     bool    `json:"disableStack"`
-	DisableStorage   bool    `json:"disableStorage"`
-	EnableReturnData bool    `json:"enableReturnData"`
-	Timeout          *string `json:"timeout"`
+	EnableMemory     bool            `json:"enableMemory"`
+	DisableStack     bool            `json:"disableStack"`
+	DisableStorage   bool            `json:"disableStorage"`
+	EnableReturnData bool            `json:"enableReturnData"`
+	Timeout          *string         `json:"timeout"`
+	Tracer           *string         `json:"tracer"`
+	TracerConfig     json.RawMessage `json:"tracerConfig"`
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -135,7 +153,7 @@ func (d *Debug) TraceTransaction(
 		return nil, ErrTraceGenesisBlock
 	}
 
-	tracer, cancel, err := newTracer(config)
+	tracer, cancel, err := newTracer(config, d.store.GetForksInTime(block.Number()))
 	if err != nil {
 		return nil, err
 	}
@@ -165,13 +183,13 @@ func (d *Debug) TraceCall(
 		tx.Gas = header.GasLimit
 	}
 
-	tracer, cancel, err := newTracer(config)
-	defer cancel()
-
+	tracer, cancel, err := newTracer(config, d.store.GetForksInTime(header.Number))
 	if err != nil {
 		return nil, err
 	}
 
+	defer cancel()
+
 	return d.store.TraceCall(tx, header, tracer)
 }
 
@@ -183,18 +201,18 @@ func (d *Debug) traceBlock(
 		return nil, ErrTraceGenesisBlock
 	}
 
-	tracer, cancel, err := newTracer(config)
-	defer cancel()
-
+	tracer, cancel, err := newTracer(config, d.store.GetForksInTime(block.Number()))
 	if err != nil {
 		return nil, err
 	}
 
+	defer cancel()
+
 	return d.store.TraceBlock(block, tracer)
 }
 
-// newTracer creates new tracer by config
-func newTracer(config *TraceConfig) (
+// newTracer creates new tracer by config, for the block with the given active forks
+func newTracer(config *TraceConfig, forks chain.ForksInTime) (
 	tracer.Tracer,
 	context.CancelFunc,
 	error,
@@ -214,12 +232,10 @@ func newTracer(config *TraceConfig) (
 		}
 	}
 
-	tracer := structtracer.NewStructTracer(structtracer.Config{
-		EnableMemory:     config.EnableMemory,
-		EnableStack:      !config.DisableStack,
-		EnableStorage:    !config.DisableStorage,
-		EnableReturnData: config.EnableReturnData,
-	})
+	tracer, err := newTracerByName(config, forks)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
@@ -234,3 +250,53 @@ func newTracer(config *TraceConfig) (
 	// cancellation of context is done by caller
 	return tracer, cancel, nil
 }
+
+// newTracerByName creates the tracer selected by the tracer field of the config,
+// the struct logger is used if no tracer is selected
+func newTracerByName(config *TraceConfig, forks chain.ForksInTime) (tracer.Tracer, error) {
+	if config.Tracer == nil || *config.Tracer == "" {
+		return structtracer.NewStructTracer(structtracer.Config{
+			EnableMemory:     config.EnableMemory,
+			EnableStack:      !config.DisableStack,
+			EnableStorage:    !config.DisableStorage,
+			EnableReturnData: config.EnableReturnData,
+		}), nil
+	}
+
+	switch *config.Tracer {
+	case callTracerName:
+		var tracerConfig calltracer.Config
+		if err := unmarshalTracerConfig(config.TracerConfig, &tracerConfig); err != nil {
+			return nil, err
+		}
+
+		return calltracer.NewCallTracer(tracerConfig), nil
+
+	case prestateTracerName:
+		var tracerConfig prestatetracer.Config
+		if err := unmarshalTracerConfig(config.TracerConfig, &tracerConfig); err != nil {
+			return nil, err
+		}
+
+		return prestatetracer.NewPrestateTracer(tracerConfig), nil
+
+	case fourByteTracerName:
+		return fourbytetracer.NewFourByteTracer(forks), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTracer, *config.Tracer)
+	}
+}
+
+// unmarshalTracerConfig decodes the tracer specific config, if it is given
+func unmarshalTracerConfig(raw json.RawMessage, tracerConfig interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, tracerConfig); err != nil {
+		return fmt.Errorf("invalid tracer config: %w", err)
+	}
+
+	return nil
+}
@@ -6,10 +6,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/vishnushankarsg/metad/chain"
 	"github.com/vishnushankarsg/metad/helper/hex"
 	"github.com/vishnushankarsg/metad/state/runtime/tracer"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer/calltracer"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer/fourbytetracer"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer/prestatetracer"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type debugEndpointMockStore struct {
@@ -65,6 +70,10 @@ func (s *debugEndpointMockStore) GetAccount(root types.Hash, addr types.Address)
 	return s.getAccountFn(root, addr)
 }
 
+func (s *debugEndpointMockStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
+	return chain.AllForksEnabled.At(blockNumber)
+}
+
 func TestDebugTraceConfigDecode(t *testing.T) {
 	timeout15s := "15s"
 
@@ -699,7 +708,7 @@ func Test_newTracer(t *testing.T) {
 			EnableReturnData: true,
 			DisableStack:     false,
 			DisableStorage:   false,
-		})
+		}, chain.AllForksEnabled.At(0))
 
 		t.Cleanup(func() {
 			cancel()
@@ -709,10 +718,74 @@ func Test_newTracer(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should create the selected tracer", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name         string
+			tracerConfig string
+			expected     tracer.Tracer
+		}{
+			{
+				name:         callTracerName,
+				tracerConfig: `{"onlyTopCall": true, "withLog": true}`,
+				expected:     calltracer.NewCallTracer(calltracer.Config{OnlyTopCall: true, WithLog: true}),
+			},
+			{
+				name:         prestateTracerName,
+				tracerConfig: `{"diffMode": true}`,
+				expected:     prestatetracer.NewPrestateTracer(prestatetracer.Config{DiffMode: true}),
+			},
+			{
+				name:     fourByteTracerName,
+				expected: fourbytetracer.NewFourByteTracer(chain.AllForksEnabled.At(0)),
+			},
+		}
+
+		for _, test := range tests {
+			name := test.name
+
+			tracer, cancel, err := newTracer(&TraceConfig{
+				Tracer:       &name,
+				TracerConfig: json.RawMessage(test.tracerConfig),
+			}, chain.AllForksEnabled.At(0))
+			require.NoError(t, err)
+
+			cancel()
+
+			assert.Equal(t, test.expected, tracer)
+		}
+	})
+
+	t.Run("should return error for unknown tracer", func(t *testing.T) {
+		t.Parallel()
+
+		name := "unknownTracer"
+
+		tracer, cancel, err := newTracer(&TraceConfig{Tracer: &name}, chain.AllForksEnabled.At(0))
+
+		assert.Nil(t, tracer)
+		assert.Nil(t, cancel)
+		assert.ErrorIs(t, err, ErrUnknownTracer)
+	})
+
+	t.Run("should return error for invalid tracer config", func(t *testing.T) {
+		t.Parallel()
+
+		name := callTracerName
+
+		_, _, err := newTracer(&TraceConfig{
+			Tracer:       &name,
+			TracerConfig: json.RawMessage(`{"onlyTopCall": "yes"}`),
+		}, chain.AllForksEnabled.At(0))
+
+		assert.ErrorContains(t, err, "invalid tracer config")
+	})
+
 	t.Run("should return error if arg is nil", func(t *testing.T) {
 		t.Parallel()
 
-		tracer, cancel, err := newTracer(nil)
+		tracer, cancel, err := newTracer(nil, chain.AllForksEnabled.At(0))
 
 		assert.Nil(t, tracer)
 		assert.Nil(t, cancel)
@@ -729,7 +802,7 @@ func Test_newTracer(t *testing.T) {
 			DisableStack:     false,
 			DisableStorage:   false,
 			Timeout:          &timeout,
-		})
+		}, chain.AllForksEnabled.At(0))
 
 		t.Cleanup(func() {
 			cancel()
@@ -755,7 +828,7 @@ func Test_newTracer(t *testing.T) {
 			DisableStack:     false,
 			DisableStorage:   false,
 			Timeout:          &timeout,
-		})
+		}, chain.AllForksEnabled.At(0))
 
 		assert.NoError(t, err)
 
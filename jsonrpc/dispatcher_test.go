@@ -101,6 +101,58 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 	})
 }
 
+func TestDispatcher_HandleWebsocketConnection_EthSubscribePendingTransactions(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			chainID:                 0,
+			priceLimit:              0,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+	mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+	req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions"]
+	}`)
+	if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+		t.Fatal(err)
+	}
+
+	store.emitPendingTx(&types.Transaction{Hash: types.StringToHash("1")})
+
+	select {
+	case <-msgCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
+	}
+
+	// the full transaction flag must be a boolean
+	req = []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions", "full"]
+	}`)
+	resp, err := dispatcher.HandleWs(req, mockConnection)
+	require.NoError(t, err)
+
+	var res interface{}
+	assert.Error(t, expectJSONResult(resp, &res))
+
+	req = []byte(`{
+		"method": "eth_subscribe",
+		"params": ["syncing"]
+	}`)
+	resp, err = dispatcher.HandleWs(req, mockConnection)
+	require.NoError(t, err)
+	assert.NoError(t, expectJSONResult(resp, &res))
+}
+
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 	store := newMockStore()
 	dispatcher := newTestDispatcher(t,
@@ -1,15 +1,20 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/vishnushankarsg/metad/blockchain"
+	"github.com/vishnushankarsg/metad/chain"
+	"github.com/vishnushankarsg/metad/gasprice"
 	"github.com/vishnushankarsg/metad/helper/progress"
 	"github.com/vishnushankarsg/metad/state/runtime"
+	"github.com/vishnushankarsg/metad/txpool/proto"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -166,6 +171,37 @@ func TestEth_GetTransactionByHash(t *testing.T) {
 	})
 }
 
+func TestEth_GetTransactionBySenderAndNonce(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+	block := newTestBlock(1, hash4)
+	store.add(block)
+
+	for i := 0; i < 3; i++ {
+		block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+	}
+
+	res, err := eth.GetTransactionBySenderAndNonce(addr0, argUint64(1))
+	require.NoError(t, err)
+
+	//nolint:forcetypeassert
+	foundTxn := res.(*transaction)
+	assert.Equal(t, block.Transactions[1].Hash, foundTxn.Hash)
+	assert.Equal(t, argUint64(1), foundTxn.Nonce)
+	assert.Equal(t, argUint64(1), *foundTxn.TxIndex)
+	assert.Equal(t, hash4, *foundTxn.BlockHash)
+
+	res, err = eth.GetTransactionBySenderAndNonce(addr0, argUint64(3))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetTransactionBySenderAndNonce(addr1, argUint64(0))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
 func TestEth_GetTransactionReceipt(t *testing.T) {
 	t.Parallel()
 
@@ -213,6 +249,133 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 		assert.Equal(t, block.Hash(), response.BlockHash)
 		assert.NotNil(t, response.Logs)
 	})
+
+	t.Run("numbers the logs within the block", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		eth := newTestEthEndpoint(store)
+		block := newTestBlock(1, hash4)
+		store.add(block)
+
+		receipts := make([]*types.Receipt, 3)
+
+		for i := range receipts {
+			block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+
+			// the transactions emit 2, 0 and 3 logs
+			receipts[i] = &types.Receipt{Logs: make([]*types.Log, []int{2, 0, 3}[i])}
+			for j := range receipts[i].Logs {
+				receipts[i].Logs[j] = &types.Log{Topics: []types.Hash{hash4}}
+			}
+
+			receipts[i].SetStatus(types.ReceiptSuccess)
+		}
+
+		store.receipts[hash4] = receipts
+
+		res, err := eth.GetTransactionReceipt(block.Transactions[2].Hash)
+		require.NoError(t, err)
+
+		//nolint:forcetypeassert
+		response := res.(*receipt)
+		require.Len(t, response.Logs, 3)
+
+		for j, log := range response.Logs {
+			assert.Equal(t, argUint64(2), log.TxIndex)
+			assert.Equal(t, argUint64(2+j), log.LogIndex)
+		}
+	})
+}
+
+func TestEth_GetBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns error if block not found", func(t *testing.T) {
+		t.Parallel()
+
+		eth := newTestEthEndpoint(newMockBlockStore())
+
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash1})
+
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("returns receipts of all block transactions", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		eth := newTestEthEndpoint(store)
+		block := newTestBlock(1, hash4)
+		store.add(block)
+
+		contractAddr := types.StringToAddress("0x100")
+		receipts := make([]*types.Receipt, 2)
+
+		for i := range receipts {
+			block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+
+			receipts[i] = &types.Receipt{
+				CumulativeGasUsed: uint64(i+1) * 21000,
+				GasUsed:           21000,
+				Logs: []*types.Log{
+					{Topics: []types.Hash{hash4}},
+					{Topics: []types.Hash{hash3}},
+				},
+			}
+			receipts[i].SetStatus(types.ReceiptSuccess)
+		}
+
+		receipts[1].ContractAddress = &contractAddr
+		store.receipts[hash4] = receipts
+
+		blockNumber := BlockNumber(1)
+
+		for _, filter := range []BlockNumberOrHash{{BlockHash: &hash4}, {BlockNumber: &blockNumber}} {
+			res, err := eth.GetBlockReceipts(filter)
+			require.NoError(t, err)
+
+			//nolint:forcetypeassert
+			response := res.([]*receipt)
+			require.Len(t, response, 2)
+
+			for i, r := range response {
+				txn := block.Transactions[i]
+
+				assert.Equal(t, txn.Hash, r.TxHash)
+				assert.Equal(t, argUint64(i), r.TxIndex)
+				assert.Equal(t, hash4, r.BlockHash)
+				assert.Equal(t, argUint64(1), r.BlockNumber)
+				assert.Equal(t, argUint64(21000), r.GasUsed)
+				assert.Equal(t, argBig(*txn.GasPrice), r.EffectiveGasPrice)
+				require.Len(t, r.Logs, 2)
+
+				for j, log := range r.Logs {
+					assert.Equal(t, txn.Hash, log.TxHash)
+					assert.Equal(t, argUint64(i), log.TxIndex)
+					// the logs are numbered within the block
+					assert.Equal(t, argUint64(2*i+j), log.LogIndex)
+				}
+			}
+
+			assert.Nil(t, response[0].ContractAddress)
+			assert.Equal(t, &contractAddr, response[1].ContractAddress)
+		}
+	})
+
+	t.Run("returns empty list for block without transactions", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		eth := newTestEthEndpoint(store)
+		store.add(newTestBlock(1, hash4))
+
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash4})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*receipt{}, res)
+	})
 }
 
 func TestEth_Syncing(t *testing.T) {
@@ -284,6 +447,55 @@ func TestEth_GasPrice(t *testing.T) {
 	assert.Equal(t, argUint64(store.averageGasPrice), res)
 }
 
+func TestEth_GasPrice_London(t *testing.T) {
+	store := newMockBlockStore()
+	store.add(&types.Block{Header: &types.Header{Number: 1, BaseFee: 1000}})
+	store.forks = chain.AllForksEnabled.At(0)
+	store.priorityFee = big.NewInt(200)
+
+	t.Run("returns the base fee increased by the priority fee", func(t *testing.T) {
+		res, err := newTestEthEndpoint(store).GasPrice()
+		assert.NoError(t, err)
+		assert.Equal(t, argUint64(1200), res)
+	})
+
+	t.Run("returns price limit flag value when it is larger", func(t *testing.T) {
+		res, err := newTestEthEndpointWithPriceLimit(store, 5000).GasPrice()
+		assert.NoError(t, err)
+		assert.Equal(t, argUint64(5000), res)
+	})
+}
+
+func TestEth_MaxPriorityFeePerGas(t *testing.T) {
+	store := newMockBlockStore()
+	store.priorityFee = big.NewInt(1500)
+
+	res, err := newTestEthEndpoint(store).MaxPriorityFeePerGas()
+	assert.NoError(t, err)
+	assert.Equal(t, argBigPtr(big.NewInt(1500)), res)
+}
+
+func TestEth_FeeHistory(t *testing.T) {
+	store := newMockBlockStore()
+	store.add(newTestBlock(1, hash1))
+	store.feeHistory = &gasprice.FeeHistory{
+		OldestBlock:  1,
+		BaseFee:      []uint64{10, 11},
+		GasUsedRatio: []float64{0.5},
+		Reward:       [][]uint64{{1, 2}},
+	}
+
+	res, err := newTestEthEndpoint(store).FeeHistory(1, LatestBlockNumber, []float64{10, 90})
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	assert.NoError(t, err)
+	assert.JSONEq(t,
+		`{"oldestBlock":"0x1","baseFeePerGas":["0xa","0xb"],"gasUsedRatio":[0.5],"reward":[["0x1","0x2"]]}`,
+		string(data),
+	)
+}
+
 func TestEth_Call(t *testing.T) {
 	t.Parallel()
 
@@ -348,6 +560,9 @@ type mockBlockStore struct {
 	isSyncing       bool
 	averageGasPrice int64
 	ethCallError    error
+	forks           chain.ForksInTime
+	priorityFee     *big.Int
+	feeHistory      *gasprice.FeeHistory
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -464,6 +679,15 @@ func (m *mockBlockStore) GetBlockByNumber(blockNumber uint64, full bool) (*types
 	return nil, false
 }
 
+func (m *mockBlockStore) GetHeaderByNumber(blockNumber uint64) (*types.Header, bool) {
+	b, ok := m.GetBlockByNumber(blockNumber, false)
+	if !ok {
+		return nil, false
+	}
+
+	return b.Header, true
+}
+
 func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Hash() == hash {
@@ -475,6 +699,10 @@ func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Bloc
 }
 
 func (m *mockBlockStore) Header() *types.Header {
+	if len(m.blocks) == 0 {
+		return &types.Header{}
+	}
+
 	return m.blocks[len(m.blocks)-1].Header
 }
 
@@ -490,6 +718,18 @@ func (m *mockBlockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
 	return types.ZeroHash, false
 }
 
+func (m *mockBlockStore) ReadTxSenderLookup(sender types.Address, nonce uint64) (types.Hash, bool) {
+	for _, block := range m.blocks {
+		for _, txn := range block.Transactions {
+			if txn.From == sender && txn.Nonce == nonce {
+				return txn.Hash, true
+			}
+		}
+	}
+
+	return types.ZeroHash, false
+}
+
 func (m *mockBlockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
 	for _, txn := range m.pendingTxns {
 		if txn.Hash == txHash {
@@ -517,6 +757,18 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
+func (m *mockBlockStore) GetForksInTime(uint64) chain.ForksInTime {
+	return m.forks
+}
+
+func (m *mockBlockStore) MaxPriorityFeePerGas() (*big.Int, error) {
+	return m.priorityFee, nil
+}
+
+func (m *mockBlockStore) FeeHistory(uint64, uint64, []float64) (*gasprice.FeeHistory, error) {
+	return m.feeHistory, nil
+}
+
 func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction, overrides types.StateOverride) (*runtime.ExecutionResult, error) {
 	return &runtime.ExecutionResult{Err: m.ethCallError}, nil
 }
@@ -525,6 +777,10 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) SubscribeTxEvents([]proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	return nil, func() {}
+}
+
 func (m *mockBlockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/umbracle/fastrlp"
 
 	"github.com/vishnushankarsg/metad/chain"
+	"github.com/vishnushankarsg/metad/gasprice"
 	"github.com/vishnushankarsg/metad/helper/common"
 	"github.com/vishnushankarsg/metad/helper/progress"
 	"github.com/vishnushankarsg/metad/state"
@@ -20,6 +21,12 @@ type ethTxPoolStore interface {
 	// AddTx adds a new transaction to the tx pool
 	AddTx(tx *types.Transaction) error
 
+	// AddPrivateTx adds a new transaction to the tx pool without broadcasting it to the network
+	AddPrivateTx(tx *types.Transaction) error
+
+	// AddBundle adds the transactions executed back-to-back in the given block, or not at all
+	AddBundle(txs []*types.Transaction, blockNumber uint64) (types.Hash, error)
+
 	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
 	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
 
@@ -32,11 +39,35 @@ type Account struct {
 	Nonce   uint64
 }
 
+// AccountProof is the Merkle proof of an account and of its storage slots
+type AccountProof struct {
+	// Account is the proven account, empty if the account doesn't exist
+	Account *state.Account
+
+	// Proof holds the RLP encoded state trie nodes on the path to the account
+	Proof [][]byte
+
+	// StorageProofs holds the proofs of the requested storage slots
+	StorageProofs []*StorageProof
+}
+
+// StorageProof is the Merkle proof of a storage slot
+type StorageProof struct {
+	Key   types.Hash
+	Value types.Hash
+
+	// Proof holds the RLP encoded storage trie nodes on the path to the slot
+	Proof [][]byte
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+
+	// GetProof returns the Merkle proof of the account and of the given storage slots
+	GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error)
 }
 
 type ethBlockchainStore interface {
@@ -55,6 +86,9 @@ type ethBlockchainStore interface {
 	// ReadTxLookup returns a block hash in which a given txn was mined
 	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
 
+	// ReadTxSenderLookup returns the hash of the mined txn of the given sender and nonce
+	ReadTxSenderLookup(sender types.Address, nonce uint64) (types.Hash, bool)
+
 	// GetReceiptsByHash returns the receipts for a block hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 
@@ -66,6 +100,12 @@ type ethBlockchainStore interface {
 
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
+
+	// MaxPriorityFeePerGas suggests the priority fee for a transaction to be included in a block
+	MaxPriorityFeePerGas() (*big.Int, error)
+
+	// FeeHistory returns the fee history of blockCount blocks ending with the newestBlock
+	FeeHistory(blockCount uint64, newestBlock uint64, rewardPercentiles []float64) (*gasprice.FeeHistory, error)
 }
 
 type ethFilter interface {
@@ -207,6 +247,48 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 	return tx.Hash.String(), nil
 }
 
+// SendPrivateRawTransaction sends a raw transaction, which is kept in the local tx pool
+// only and included in the blocks proposed by this node, without being gossiped to the peers
+func (e *Eth) SendPrivateRawTransaction(buf argBytes) (interface{}, error) {
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalRLP(buf); err != nil {
+		return nil, err
+	}
+
+	tx.ComputeHash()
+
+	if err := e.store.AddPrivateTx(tx); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// SendBundle sends the ordered raw transactions, which are executed back-to-back
+// in the target block, or not at all. The bundle is kept by this node only,
+// and included in the target block if proposed by this node
+func (e *Eth) SendBundle(args *bundleArgs) (interface{}, error) {
+	txs := make([]*types.Transaction, len(args.Txs))
+
+	for i, raw := range args.Txs {
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return nil, fmt.Errorf("invalid bundle transaction %d, %w", i, err)
+		}
+
+		tx.ComputeHash()
+
+		txs[i] = tx
+	}
+
+	hash, err := e.store.AddBundle(txs, uint64(args.BlockNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	return &bundleResult{BundleHash: hash}, nil
+}
+
 // SendTransaction rejects eth_sendTransaction json-rpc call as we don't support wallet management
 func (e *Eth) SendTransaction(_ *txnArgs) (interface{}, error) {
 	return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
@@ -242,6 +324,7 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 					argUintPtr(block.Number()),
 					argHashPtr(block.Hash()),
 					&idx,
+					new(big.Int).SetUint64(block.Header.BaseFee),
 				)
 			}
 		}
@@ -279,6 +362,18 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 	return nil, nil
 }
 
+// GetTransactionBySenderAndNonce returns the mined transaction of the given sender and nonce.
+// The node must index the transactions by their sender (--tx-sender-index)
+func (e *Eth) GetTransactionBySenderAndNonce(sender types.Address, nonce argUint64) (interface{}, error) {
+	hash, ok := e.store.ReadTxSenderLookup(sender, uint64(nonce))
+	if !ok {
+		// txn not found
+		return nil, nil
+	}
+
+	return e.GetTransactionByHash(hash)
+}
+
 // GetTransactionReceipt returns a transaction receipt by his hash
 func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 	blockHash, ok := e.store.ReadTxLookup(hash)
@@ -331,38 +426,59 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		return nil, nil
 	}
 
-	txn := block.Transactions[indx]
-	raw := receipts[indx]
-
-	logs := make([]*Log, len(raw.Logs))
-	for indx, elem := range raw.Logs {
-		logs[indx] = &Log{
-			Address:     elem.Address,
-			Topics:      elem.Topics,
-			Data:        argBytes(elem.Data),
-			BlockHash:   block.Hash(),
-			BlockNumber: argUint64(block.Number()),
-			TxHash:      txn.Hash,
-			TxIndex:     argUint64(indx),
-			LogIndex:    argUint64(indx),
-			Removed:     false,
-		}
+	// the logs of the previous transactions are numbered first
+	logIndex := uint64(0)
+	for _, raw := range receipts[:indx] {
+		logIndex += uint64(len(raw.Logs))
+	}
+
+	return toReceipt(receipts[indx], block.Transactions[indx], uint64(indx), logIndex, block.Header), nil
+}
+
+// GetBlockReceipts returns the receipts of all the transactions of the given block
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		// block not found
+		return nil, nil
 	}
 
-	res := &receipt{
-		Root:              raw.Root,
-		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
-		LogsBloom:         raw.LogsBloom,
-		Status:            argUint64(*raw.Status),
-		TxHash:            txn.Hash,
-		TxIndex:           argUint64(indx),
-		BlockHash:         block.Hash(),
-		BlockNumber:       argUint64(block.Number()),
-		GasUsed:           argUint64(raw.GasUsed),
-		ContractAddress:   raw.ContractAddress,
-		FromAddr:          txn.From,
-		ToAddr:            txn.To,
-		Logs:              logs,
+	if len(block.Transactions) == 0 {
+		return []*receipt{}, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		// block receipts not found
+		e.logger.Warn(
+			fmt.Sprintf("Receipts for block with hash [%s] not found", block.Hash().String()),
+		)
+
+		return nil, nil
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		// Receipts not written yet on the db
+		e.logger.Warn(
+			fmt.Sprintf("No receipts found for block with hash [%s]", block.Hash().String()),
+		)
+
+		return nil, nil
+	}
+
+	var (
+		res      = make([]*receipt, len(receipts))
+		logIndex = uint64(0)
+	)
+
+	for indx, raw := range receipts {
+		res[indx] = toReceipt(raw, block.Transactions[indx], uint64(indx), logIndex, block.Header)
+		logIndex += uint64(len(raw.Logs))
 	}
 
 	return res, nil
@@ -410,8 +526,20 @@ func (e *Eth) GetStorageAt(
 }
 
 // GasPrice returns the average gas price based on the last x blocks
-// taking into consideration operator defined price limit
+// taking into consideration operator defined price limit.
+// After the EIP-1559 fork it is the latest base fee increased by the suggested priority fee
 func (e *Eth) GasPrice() (interface{}, error) {
+	header := e.store.Header()
+
+	if e.store.GetForksInTime(header.Number).EIP1559 {
+		priorityFee, err := e.store.MaxPriorityFeePerGas()
+		if err != nil {
+			return nil, err
+		}
+
+		return argUint64(common.Max(e.priceLimit, header.BaseFee+priorityFee.Uint64())), nil
+	}
+
 	// Fetch average gas price in uint64
 	avgGasPrice := e.store.GetAvgGasPrice().Uint64()
 
@@ -419,6 +547,33 @@ func (e *Eth) GasPrice() (interface{}, error) {
 	return argUint64(common.Max(e.priceLimit, avgGasPrice)), nil
 }
 
+// MaxPriorityFeePerGas returns the suggested priority fee for a dynamic fee transaction,
+// based on the tips paid in the latest blocks
+func (e *Eth) MaxPriorityFeePerGas() (interface{}, error) {
+	priorityFee, err := e.store.MaxPriorityFeePerGas()
+	if err != nil {
+		return nil, err
+	}
+
+	return argBigPtr(priorityFee), nil
+}
+
+// FeeHistory returns the base fees, the gas used ratios and the requested percentiles
+// of the effective tips for the range of blocks ending with the newest block
+func (e *Eth) FeeHistory(blockCount argUint64, newestBlock BlockNumber, rewardPercentiles []float64) (interface{}, error) {
+	num, err := GetNumericBlockNumber(newestBlock, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := e.store.FeeHistory(uint64(blockCount), num, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return toFeeHistory(history), nil
+}
+
 type overrideAccount struct {
 	Nonce     *argUint64                 `json:"nonce"`
 	Code      *argBytes                  `json:"code"`
@@ -538,7 +693,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		highEnd = header.GasLimit
 	}
 
-	gasPriceInt := new(big.Int).Set(transaction.GasPrice)
+	gasPriceInt := new(big.Int).Set(transaction.GetGasFeeCap())
 	valueInt := new(big.Int).Set(transaction.Value)
 
 	var availableBalance *big.Int
@@ -778,6 +933,26 @@ func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interfac
 	return argBytesPtr(code), nil
 }
 
+// GetProof returns the Merkle proof of the account and of its storage slots
+// at the given block, as specified by EIP-1186
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProof(address, proof), nil
+}
+
 // NewFilter creates a filter object, based on filter options, to notify when the state changes (logs).
 func (e *Eth) NewFilter(filter *LogQuery) (interface{}, error) {
 	return e.filterManager.NewLogFilter(filter, nil), nil
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
 	"testing"
@@ -394,6 +395,58 @@ func TestEth_State_GetCode(t *testing.T) {
 	}
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	store := &mockSpecialStore{
+		account: &mockAccount{
+			address: addr0,
+			account: &Account{
+				Balance: big.NewInt(100),
+				Nonce:   10,
+			},
+			storage: map[types.Hash][]byte{
+				hash1: {0x12, 0x34},
+			},
+		},
+		block: &types.Block{
+			Header: &types.Header{
+				Hash:      types.ZeroHash,
+				Number:    0,
+				StateRoot: types.EmptyRootHash,
+			},
+		},
+	}
+
+	eth := newTestEthEndpoint(store)
+	blockNumberLatest := LatestBlockNumber
+	blockNumberInvalid := BlockNumber(0x1)
+
+	t.Run("should return the account and storage proofs", func(t *testing.T) {
+		res, err := eth.GetProof(addr0, []types.Hash{hash1, hash2}, BlockNumberOrHash{BlockNumber: &blockNumberLatest})
+		assert.NoError(t, err)
+
+		data, err := json.Marshal(res)
+		assert.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"address": "0x0100000000000000000000000000000000000000",
+			"accountProof": ["0x01", "0x02"],
+			"balance": "0x64",
+			"codeHash": "`+types.ZeroHash.String()+`",
+			"nonce": "0xa",
+			"storageHash": "`+types.EmptyRootHash.String()+`",
+			"storageProof": [
+				{"key": "`+hash1.String()+`", "value": "0x1234", "proof": ["0x03"]},
+				{"key": "`+hash2.String()+`", "value": "0x0", "proof": ["0x03"]}
+			]
+		}`, string(data))
+	})
+
+	t.Run("should return an error for non-existing block", func(t *testing.T) {
+		_, err := eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &blockNumberInvalid})
+		assert.Error(t, err)
+	})
+}
+
 func TestEth_State_GetStorageAt(t *testing.T) {
 	store := &mockSpecialStore{
 		account: &mockAccount{
@@ -836,6 +889,35 @@ func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte,
 	return m.account.code, nil
 }
 
+func (m *mockSpecialStore) GetProof(
+	root types.Hash,
+	addr types.Address,
+	slots []types.Hash,
+) (*AccountProof, error) {
+	if m.account.address != addr {
+		return nil, ErrStateNotFound
+	}
+
+	proof := &AccountProof{
+		Account: &state.Account{
+			Balance: m.account.account.Balance,
+			Nonce:   m.account.account.Nonce,
+			Root:    types.EmptyRootHash,
+		},
+		Proof: [][]byte{{0x1}, {0x2}},
+	}
+
+	for _, slot := range slots {
+		proof.StorageProofs = append(proof.StorageProofs, &StorageProof{
+			Key:   slot,
+			Value: types.BytesToHash(m.account.storage[slot]),
+			Proof: [][]byte{{0x3}},
+		})
+	}
+
+	return proof, nil
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.ForksInTime{}
 }
@@ -46,10 +46,61 @@ func TestEth_TxnPool_SendTransaction(t *testing.T) {
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 }
 
+func TestEth_TxnPool_SendPrivateRawTransaction(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	txn := &types.Transaction{
+		From: addr0,
+		V:    big.NewInt(1),
+	}
+	txn.ComputeHash()
+
+	hash, err := eth.SendPrivateRawTransaction(txn.MarshalRLP())
+	assert.NoError(t, err)
+	assert.Equal(t, txn.Hash.String(), hash)
+	assert.True(t, store.private)
+	assert.Equal(t, txn.Hash, store.txn.Hash)
+}
+
+func TestEth_TxnPool_SendBundle(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	txs := []*types.Transaction{
+		{From: addr0, Nonce: 0, V: big.NewInt(1)},
+		{From: addr0, Nonce: 1, V: big.NewInt(1)},
+	}
+
+	args := &bundleArgs{BlockNumber: 10}
+	for _, tx := range txs {
+		tx.ComputeHash()
+		args.Txs = append(args.Txs, tx.MarshalRLP())
+	}
+
+	res, err := eth.SendBundle(args)
+	assert.NoError(t, err)
+	assert.Equal(t, &bundleResult{BundleHash: types.Hash{0x1}}, res)
+
+	// the transactions are passed in the order of the bundle
+	assert.Equal(t, uint64(10), store.bundleBlock)
+	assert.Len(t, store.bundle, 2)
+
+	for i, tx := range txs {
+		assert.Equal(t, tx.Hash, store.bundle[i].Hash)
+	}
+
+	_, err = eth.SendBundle(&bundleArgs{Txs: []argBytes{{0x1}}, BlockNumber: 10})
+	assert.Error(t, err)
+}
+
 type mockStoreTxn struct {
 	ethStore
-	accounts map[types.Address]*mockAccount
-	txn      *types.Transaction
+	accounts    map[types.Address]*mockAccount
+	txn         *types.Transaction
+	private     bool
+	bundle      []*types.Transaction
+	bundleBlock uint64
 }
 
 func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
@@ -58,6 +109,20 @@ func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
 	return nil
 }
 
+func (m *mockStoreTxn) AddPrivateTx(tx *types.Transaction) error {
+	m.txn = tx
+	m.private = true
+
+	return nil
+}
+
+func (m *mockStoreTxn) AddBundle(txs []*types.Transaction, blockNumber uint64) (types.Hash, error) {
+	m.bundle = txs
+	m.bundleBlock = blockNumber
+
+	return types.Hash{0x1}, nil
+}
+
 func (m *mockStoreTxn) GetNonce(addr types.Address) uint64 {
 	return 1
 }
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/vishnushankarsg/metad/blockchain"
+	"github.com/vishnushankarsg/metad/helper/progress"
+	"github.com/vishnushankarsg/metad/txpool/proto"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -30,6 +32,9 @@ var (
 // defaultTimeout is the timeout to remove the filters that don't have a web socket stream
 var defaultTimeout = 1 * time.Minute
 
+// syncingCheckInterval is the interval of checking the sync progression for the syncing filters
+var syncingCheckInterval = 1 * time.Second
+
 const (
 	// The index in heap which is indicating the element is not in the heap
 	NoIndexInHeap = -1
@@ -212,6 +217,104 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// pendingTxFilter is a filter to store the transactions added to the pending queue of the txpool
+type pendingTxFilter struct {
+	filterBase
+	sync.Mutex
+
+	// fullTx indicates the whole transactions are stored instead of their hashes
+	fullTx bool
+	txs    []interface{}
+}
+
+// appendTx appends new transaction or transaction hash to txs
+func (f *pendingTxFilter) appendTx(tx interface{}) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.txs = append(f.txs, tx)
+}
+
+// takeTxUpdates returns all saved transactions in filter and set new transaction slice
+func (f *pendingTxFilter) takeTxUpdates() []interface{} {
+	f.Lock()
+	defer f.Unlock()
+
+	txs := f.txs
+	f.txs = []interface{}{}
+
+	return txs
+}
+
+// getUpdates returns stored transactions
+func (f *pendingTxFilter) getUpdates() (interface{}, error) {
+	return f.takeTxUpdates(), nil
+}
+
+// sendUpdates writes stored transactions to web socket stream
+func (f *pendingTxFilter) sendUpdates() error {
+	for _, tx := range f.takeTxUpdates() {
+		res, err := json.Marshal(tx)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(res)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncingFilter is a filter to store the changes of the sync status
+type syncingFilter struct {
+	filterBase
+	sync.Mutex
+
+	statuses []interface{}
+}
+
+// appendStatus appends new sync status to statuses
+func (f *syncingFilter) appendStatus(status interface{}) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.statuses = append(f.statuses, status)
+}
+
+// takeStatusUpdates returns all saved sync statuses in filter and set new status slice
+func (f *syncingFilter) takeStatusUpdates() []interface{} {
+	f.Lock()
+	defer f.Unlock()
+
+	statuses := f.statuses
+	f.statuses = []interface{}{}
+
+	return statuses
+}
+
+// getUpdates returns stored sync statuses
+func (f *syncingFilter) getUpdates() (interface{}, error) {
+	return f.takeStatusUpdates(), nil
+}
+
+// sendUpdates writes stored sync statuses to web socket stream
+func (f *syncingFilter) sendUpdates() error {
+	for _, status := range f.takeStatusUpdates() {
+		res, err := json.Marshal(status)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(res)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
@@ -228,6 +331,15 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// SubscribeTxEvents subscribes for the txpool events of the given types
+	SubscribeTxEvents(eventTypes []proto.EventType) (<-chan *proto.TxPoolEvent, func())
+
+	// GetPendingTx gets the pending transaction from the txpool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// GetSyncProgression retrieves the current sync progression, if any
+	GetSyncProgression() *progress.Progression
 }
 
 // FilterManager manages all running filters
@@ -243,6 +355,12 @@ type FilterManager struct {
 	blockStream     *blockStream
 	blockRangeLimit uint64
 
+	txEventCh      <-chan *proto.TxPoolEvent
+	cancelTxEvents func()
+
+	// syncProgression is the sync progression of the last check, nil if the node wasn't syncing
+	syncProgression *progress.Progression
+
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
@@ -273,6 +391,9 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 	// start the head watcher
 	m.subscription = store.SubscribeEvents()
 
+	// start the pending transactions watcher
+	m.txEventCh, m.cancelTxEvents = store.SubscribeTxEvents([]proto.EventType{proto.EventType_PROMOTED})
+
 	return m
 }
 
@@ -291,7 +412,13 @@ func (f *FilterManager) Run() {
 		}
 	}()
 
-	var timeoutCh <-chan time.Time
+	var (
+		timeoutCh <-chan time.Time
+		txEventCh = f.txEventCh
+	)
+
+	syncingTicker := time.NewTicker(syncingCheckInterval)
+	defer syncingTicker.Stop()
 
 	for {
 		// check for the next filter to be removed
@@ -309,6 +436,25 @@ func (f *FilterManager) Run() {
 				f.logger.Error("failed to dispatch event", "err", err)
 			}
 
+		case evnt, ok := <-txEventCh:
+			if !ok {
+				// the txpool subscription is closed, stop watching it
+				txEventCh = nil
+
+				continue
+			}
+
+			// new pending transaction
+			if err := f.dispatchPendingTx(types.StringToHash(evnt.TxHash)); err != nil {
+				f.logger.Error("failed to dispatch pending transaction", "err", err)
+			}
+
+		case <-syncingTicker.C:
+			// check the sync progression
+			if err := f.dispatchSyncProgression(); err != nil {
+				f.logger.Error("failed to dispatch sync progression", "err", err)
+			}
+
 		case <-timeoutCh:
 			// timeout for filter
 			// if filter still exists
@@ -328,6 +474,7 @@ func (f *FilterManager) Run() {
 
 // Close closed closeCh so that terminate worker
 func (f *FilterManager) Close() {
+	f.cancelTxEvents()
 	close(f.closeCh)
 }
 
@@ -359,6 +506,33 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewPendingTxFilter adds new PendingTxFilter
+func (f *FilterManager) NewPendingTxFilter(fullTx bool, ws wsConn) string {
+	filter := &pendingTxFilter{
+		filterBase: newFilterBase(ws),
+		fullTx:     fullTx,
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
+// NewSyncingFilter adds new SyncingFilter
+func (f *FilterManager) NewSyncingFilter(ws wsConn) string {
+	filter := &syncingFilter{
+		filterBase: newFilterBase(ws),
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -375,10 +549,14 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 		return nil, err
 	}
 
-	logs := make([]*Log, 0)
+	var (
+		logs = make([]*Log, 0)
+		// the logs are numbered within the block
+		logIdx = uint64(0)
+	)
 
 	for idx, receipt := range receipts {
-		for logIdx, log := range receipt.Logs {
+		for _, log := range receipt.Logs {
 			if query.Match(log) {
 				logs = append(logs, &Log{
 					Address:     log.Address,
@@ -391,6 +569,8 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 					LogIndex:    argUint64(logIdx),
 				})
 			}
+
+			logIdx++
 		}
 	}
 
@@ -675,6 +855,9 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 		return nil
 	}
 
+	// the logs are numbered within the block
+	logIndex := uint64(0)
+
 	for indx, receipt := range receipts {
 		if receipt.TxHash == types.ZeroHash {
 			// Extract tx Hash
@@ -692,16 +875,119 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 						BlockHash:   header.Hash,
 						TxHash:      receipt.TxHash,
 						TxIndex:     argUint64(indx),
+						LogIndex:    argUint64(logIndex),
 						Removed:     false,
 					})
 				}
 			}
+
+			logIndex++
 		}
 	}
 
 	return nil
 }
 
+// dispatchPendingTx is an event handler for new pending transaction event
+func (f *FilterManager) dispatchPendingTx(txHash types.Hash) error {
+	// store new transaction in each filters
+	f.processPendingTx(txHash)
+
+	// send data to web socket stream
+	return f.flushWsFilters()
+}
+
+// processPendingTx makes each PendingTxFilter append the new transaction
+func (f *FilterManager) processPendingTx(txHash types.Hash) {
+	f.RLock()
+	defer f.RUnlock()
+
+	var (
+		tx       *transaction
+		txLoaded bool
+	)
+
+	for _, filter := range f.filters {
+		pendingTxFilter, ok := filter.(*pendingTxFilter)
+		if !ok {
+			continue
+		}
+
+		if !pendingTxFilter.fullTx {
+			pendingTxFilter.appendTx(txHash)
+
+			continue
+		}
+
+		// the transaction is loaded only once, if any filter needs it
+		if !txLoaded {
+			txLoaded = true
+
+			if pendingTx, found := f.store.GetPendingTx(txHash); found {
+				tx = toPendingTransaction(pendingTx)
+			}
+		}
+
+		// the transaction may have already left the txpool
+		if tx != nil {
+			pendingTxFilter.appendTx(tx)
+		}
+	}
+}
+
+// dispatchSyncProgression is a handler for the sync progression check
+func (f *FilterManager) dispatchSyncProgression() error {
+	// store the sync status in each filters, if it changed
+	if !f.processSyncProgression() {
+		return nil
+	}
+
+	// send data to web socket stream
+	return f.flushWsFilters()
+}
+
+// processSyncProgression makes each SyncingFilter append the sync status,
+// if it changed since the last check
+func (f *FilterManager) processSyncProgression() bool {
+	current := f.store.GetSyncProgression()
+
+	if current == nil && f.syncProgression == nil ||
+		current != nil && f.syncProgression != nil && *current == *f.syncProgression {
+		return false
+	}
+
+	f.syncProgression = nil
+
+	// the node isn't syncing anymore
+	var status interface{} = false
+
+	if current != nil {
+		syncProgression := *current
+		f.syncProgression = &syncProgression
+
+		status = &syncingStatus{
+			Syncing: true,
+			Status: &progression{
+				Type:          string(current.SyncType),
+				StartingBlock: argUint64(current.StartingBlock),
+				CurrentBlock:  argUint64(current.CurrentBlock),
+				HighestBlock:  argUint64(current.HighestBlock),
+			},
+		}
+	}
+
+	f.RLock()
+	defer f.RUnlock()
+
+	for _, filter := range f.filters {
+		if syncingFilter, ok := filter.(*syncingFilter); ok {
+			syncingFilter.appendStatus(status)
+		}
+	}
+
+	return true
+}
+
 // flushWsFilters make each filters with web socket connection write the updates to web socket stream
 // flushWsFilters also removes the filters if flushWsFilters notices the connection is closed
 func (f *FilterManager) flushWsFilters() error {
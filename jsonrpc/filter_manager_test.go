@@ -12,10 +12,12 @@ import (
 	"time"
 
 	"github.com/vishnushankarsg/metad/blockchain"
+	"github.com/vishnushankarsg/metad/helper/progress"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_GetLogsForQuery(t *testing.T) {
@@ -154,6 +156,98 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+func Test_LogIndexWithinBlock(t *testing.T) {
+	t.Parallel()
+
+	topicA := types.StringToHash("a")
+	topicB := types.StringToHash("b")
+
+	// the logs matching the query are spread over the transactions of the block
+	b := &types.Block{
+		Header: &types.Header{
+			Number: 1,
+			Hash:   hash1,
+		},
+		Transactions: []*types.Transaction{
+			{Value: big.NewInt(10), Hash: hash2},
+			{Value: big.NewInt(11), Hash: hash3},
+		},
+	}
+
+	store := &mockBlockStore{
+		receipts: map[types.Hash][]*types.Receipt{
+			hash1: {
+				{
+					Logs: []*types.Log{
+						{Topics: []types.Hash{topicA}},
+						{Topics: []types.Hash{topicB}},
+					},
+				},
+				{
+					Logs: []*types.Log{
+						{Topics: []types.Hash{topicB}},
+						{Topics: []types.Hash{topicA}},
+					},
+				},
+			},
+		},
+	}
+	store.appendBlocksToStore([]*types.Block{b})
+
+	query := &LogQuery{
+		BlockHash: &b.Header.Hash,
+		Topics:    [][]types.Hash{{topicB}},
+	}
+
+	assertLogIndexes := func(t *testing.T, logs []*Log) {
+		t.Helper()
+
+		require.Len(t, logs, 2)
+
+		assert.Equal(t, hash2, logs[0].TxHash)
+		assert.Equal(t, argUint64(0), logs[0].TxIndex)
+		assert.Equal(t, argUint64(1), logs[0].LogIndex)
+
+		assert.Equal(t, hash3, logs[1].TxHash)
+		assert.Equal(t, argUint64(1), logs[1].TxIndex)
+		assert.Equal(t, argUint64(2), logs[1].LogIndex)
+	}
+
+	t.Run("logs of the query", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+		defer m.Close()
+
+		logs, err := m.GetLogsForQuery(query)
+		require.NoError(t, err)
+
+		assertLogIndexes(t, logs)
+	})
+
+	t.Run("logs appended to the filters", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+		defer m.Close()
+
+		id := m.NewLogFilter(query, nil)
+
+		require.NoError(t, m.appendLogsToFilters(&block{
+			Number: argUint64(b.Header.Number),
+			Hash:   b.Header.Hash,
+		}))
+
+		changes, err := m.GetFilterChanges(id)
+		require.NoError(t, err)
+
+		logs, ok := changes.([]*Log)
+		require.True(t, ok)
+
+		assertLogIndexes(t, logs)
+	})
+}
+
 func Test_GetLogFilterFromID(t *testing.T) {
 	t.Parallel()
 
@@ -469,6 +563,104 @@ func TestFilterWebsocket(t *testing.T) {
 	}
 }
 
+func TestFilterPendingTx(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	hashMock, hashMsgCh := newMockWsConnWithMsgCh()
+	fullMock, fullMsgCh := newMockWsConnWithMsgCh()
+
+	m.NewPendingTxFilter(false, hashMock)
+	m.NewPendingTxFilter(true, fullMock)
+
+	httpID := m.NewPendingTxFilter(false, nil)
+
+	tx := &types.Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(10),
+		Value:    big.NewInt(0),
+		V:        big.NewInt(0),
+		R:        big.NewInt(0),
+		S:        big.NewInt(0),
+		Hash:     types.StringToHash("1"),
+	}
+
+	store.emitPendingTx(tx)
+
+	select {
+	case msg := <-hashMsgCh:
+		assert.Contains(t, string(msg), fmt.Sprintf(`"result": "%s"`, tx.Hash))
+	case <-time.After(2 * time.Second):
+		t.Fatal("pending transaction hash not received in 2 seconds")
+	}
+
+	select {
+	case msg := <-fullMsgCh:
+		assert.Contains(t, string(msg), fmt.Sprintf(`"hash":"%s"`, tx.Hash))
+	case <-time.After(2 * time.Second):
+		t.Fatal("pending transaction not received in 2 seconds")
+	}
+
+	changes, err := m.GetFilterChanges(httpID)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{tx.Hash}, changes)
+}
+
+func TestFilterSyncing(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	id := m.NewSyncingFilter(nil)
+
+	// nothing changed, the node isn't syncing
+	require.NoError(t, m.dispatchSyncProgression())
+
+	changes, err := m.GetFilterChanges(id)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// the node started syncing
+	store.setSyncProgression(&progress.Progression{
+		SyncType:      progress.ChainSyncBulk,
+		StartingBlock: 1,
+		CurrentBlock:  5,
+		HighestBlock:  10,
+	})
+	require.NoError(t, m.dispatchSyncProgression())
+
+	// the same progression is reported once
+	require.NoError(t, m.dispatchSyncProgression())
+
+	// the node stopped syncing
+	store.setSyncProgression(nil)
+	require.NoError(t, m.dispatchSyncProgression())
+
+	changes, err = m.GetFilterChanges(id)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		&syncingStatus{
+			Syncing: true,
+			Status: &progression{
+				Type:          string(progress.ChainSyncBulk),
+				StartingBlock: 1,
+				CurrentBlock:  5,
+				HighestBlock:  10,
+			},
+		},
+		false,
+	}, changes)
+}
+
 type mockWsConn struct {
 	SetFilterIDFn  func(string)
 	GetFilterIDFn  func() string
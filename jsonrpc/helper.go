@@ -14,6 +14,7 @@ var (
 	ErrNegativeBlockNumber      = errors.New("invalid argument 0: block number must not be negative")
 	ErrFailedFetchGenesis       = errors.New("error fetching genesis block header")
 	ErrNoDataInContractCreation = errors.New("contract creation without data provided")
+	ErrGasPriceAndDynamicFee    = errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 )
 
 type latestHeaderGetter interface {
@@ -220,6 +221,42 @@ func DecodeTxn(arg *txnArgs, store nonceGetter) (*types.Transaction, error) {
 		txn.To = arg.To
 	}
 
+	// the dynamic fee fields turn the call into a dynamic fee transaction (EIP-1559)
+	isDynamicFeeTx := arg.Type != nil && types.TxType(*arg.Type) == types.DynamicFeeTx
+	if isDynamicFeeTx || arg.MaxFeePerGas != nil || arg.MaxPriorityFeePerGas != nil {
+		if arg.GasPrice != nil && len(*arg.GasPrice) != 0 {
+			return nil, ErrGasPriceAndDynamicFee
+		}
+
+		txn.Type = types.DynamicFeeTx
+		txn.GasTipCap = new(big.Int)
+		txn.GasFeeCap = new(big.Int)
+
+		if arg.MaxPriorityFeePerGas != nil {
+			txn.GasTipCap.SetBytes(*arg.MaxPriorityFeePerGas)
+		}
+
+		if arg.MaxFeePerGas != nil {
+			txn.GasFeeCap.SetBytes(*arg.MaxFeePerGas)
+		}
+	}
+
+	// the access list turns the legacy call into an access list transaction (EIP-2930)
+	isAccessListTx := arg.Type != nil && types.TxType(*arg.Type) == types.AccessListTx
+	if txn.Type == types.LegacyTx && (isAccessListTx || arg.AccessList != nil) {
+		txn.Type = types.AccessListTx
+	}
+
+	if txn.Type != types.LegacyTx {
+		if arg.AccessList != nil {
+			txn.AccessList = *arg.AccessList
+		}
+
+		if arg.ChainID != nil {
+			txn.ChainID = new(big.Int).SetUint64(uint64(*arg.ChainID))
+		}
+	}
+
 	txn.ComputeHash()
 
 	return txn, nil
@@ -790,6 +790,79 @@ func TestDecodeTxn(t *testing.T) {
 			expected: nil,
 			err:      true,
 		},
+		{
+			name: "should return dynamic fee transaction if the fee caps are given",
+			arg: &txnArgs{
+				From:                 &from,
+				To:                   &to,
+				Gas:                  &gas,
+				MaxFeePerGas:         &gasPrice,
+				MaxPriorityFeePerGas: &value,
+				Value:                &value,
+				Input:                &input,
+				Nonce:                &nonce,
+			},
+			store: &debugEndpointMockStore{},
+			expected: &types.Transaction{
+				Type:      types.DynamicFeeTx,
+				From:      from,
+				To:        &to,
+				Gas:       uint64(gas),
+				GasPrice:  new(big.Int),
+				GasFeeCap: new(big.Int).SetBytes([]byte(gasPrice)),
+				GasTipCap: new(big.Int).SetBytes([]byte(value)),
+				Value:     new(big.Int).SetBytes([]byte(value)),
+				Input:     input,
+				Nonce:     uint64(nonce),
+			},
+			err: false,
+		},
+		{
+			name: "should return access list transaction if the access list is given",
+			arg: &txnArgs{
+				From:     &from,
+				To:       &to,
+				Gas:      &gas,
+				GasPrice: &gasPrice,
+				Value:    &value,
+				Input:    &input,
+				Nonce:    &nonce,
+				AccessList: &types.TxAccessList{
+					{Address: to, StorageKeys: []types.Hash{types.StringToHash("1")}},
+				},
+			},
+			store: &debugEndpointMockStore{},
+			expected: &types.Transaction{
+				Type:     types.AccessListTx,
+				From:     from,
+				To:       &to,
+				Gas:      uint64(gas),
+				GasPrice: new(big.Int).SetBytes([]byte(gasPrice)),
+				Value:    new(big.Int).SetBytes([]byte(value)),
+				Input:    input,
+				Nonce:    uint64(nonce),
+				AccessList: types.TxAccessList{
+					{Address: to, StorageKeys: []types.Hash{types.StringToHash("1")}},
+				},
+			},
+			err: false,
+		},
+		{
+			name: "should return error if both gas price and fee caps are given",
+			arg: &txnArgs{
+				From:         &from,
+				To:           &to,
+				Gas:          &gas,
+				GasPrice:     &gasPrice,
+				MaxFeePerGas: &gasPrice,
+				Value:        &value,
+				Input:        &input,
+				Nonce:        &nonce,
+			},
+			store:    &debugEndpointMockStore{},
+			expected: nil,
+			err:      true,
+		},
 		{
 			name: "should return error both to and input are not given",
 			arg: &txnArgs{
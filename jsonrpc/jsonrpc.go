@@ -57,6 +57,7 @@ type JSONRPCStore interface {
 	filterManagerStore
 	bridgeStore
 	debugStore
+	traceStore
 }
 
 type Config struct {
@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/vishnushankarsg/metad/blockchain"
+	"github.com/vishnushankarsg/metad/helper/progress"
+	"github.com/vishnushankarsg/metad/txpool/proto"
 	"github.com/vishnushankarsg/metad/types"
 )
 
@@ -52,6 +54,12 @@ type mockStore struct {
 
 	// headers is the list of historical headers
 	historicalHeaders []*types.Header
+
+	txEventCh       chan *proto.TxPoolEvent
+	pendingTxsLock  sync.Mutex
+	pendingTxs      map[types.Hash]*types.Transaction
+	syncLock        sync.Mutex
+	syncProgression *progress.Progression
 }
 
 func newMockStore() *mockStore {
@@ -59,6 +67,8 @@ func newMockStore() *mockStore {
 		header:       &types.Header{Number: 0},
 		subscription: blockchain.NewMockSubscription(),
 		accounts:     map[types.Address]*Account{},
+		txEventCh:    make(chan *proto.TxPoolEvent),
+		pendingTxs:   map[types.Hash]*types.Transaction{},
 	}
 	m.addHeader(m.header)
 
@@ -108,6 +118,45 @@ func (m *mockStore) emitEvent(evnt *mockEvent) {
 	m.subscription.Push(bEvnt)
 }
 
+// emitPendingTx adds the transaction to the pending transactions and emits its txpool event
+func (m *mockStore) emitPendingTx(tx *types.Transaction) {
+	m.pendingTxsLock.Lock()
+	m.pendingTxs[tx.Hash] = tx
+	m.pendingTxsLock.Unlock()
+
+	m.txEventCh <- &proto.TxPoolEvent{
+		Type:   proto.EventType_PROMOTED,
+		TxHash: tx.Hash.String(),
+	}
+}
+
+func (m *mockStore) setSyncProgression(syncProgression *progress.Progression) {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	m.syncProgression = syncProgression
+}
+
+func (m *mockStore) SubscribeTxEvents([]proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	return m.txEventCh, func() {}
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	m.pendingTxsLock.Lock()
+	defer m.pendingTxsLock.Unlock()
+
+	tx, ok := m.pendingTxs[txHash]
+
+	return tx, ok
+}
+
+func (m *mockStore) GetSyncProgression() *progress.Progression {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	return m.syncProgression
+}
+
 func (m *mockStore) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
 	if acc, ok := m.accounts[addr]; ok {
 		return acc, nil
@@ -0,0 +1,446 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vishnushankarsg/metad/chain"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer/calltracer"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+var (
+	// ErrUnsupportedTraceType is an error returned when the requested trace type isn't supported
+	ErrUnsupportedTraceType = errors.New("unsupported trace type")
+)
+
+const (
+	traceTypeTrace = "trace"
+
+	parityCallType    = "call"
+	parityCreateType  = "create"
+	paritySuicideType = "suicide"
+)
+
+type traceStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetHeaderByNumber gets a header using the provided number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetBlockByNumber gets a block using the provided height
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// TraceBlock traces all transactions in the given block
+	TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error)
+
+	// TraceTxn traces a transaction in the block, associated with the given hash
+	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
+}
+
+// Trace is the trace jsonrpc endpoint, returning the calls in the parity trace format
+type Trace struct {
+	store           traceStore
+	blockRangeLimit uint64
+}
+
+// TraceFilter is the filter of the calls returned by trace_filter
+type TraceFilter struct {
+	FromBlock   *BlockNumber    `json:"fromBlock"`
+	ToBlock     *BlockNumber    `json:"toBlock"`
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+	After       *uint64         `json:"after"`
+	Count       *uint64         `json:"count"`
+}
+
+// matches checks whether the sender and the recipient of the call match the filter
+func (f *TraceFilter) matches(trace *parityTrace) bool {
+	from, to := trace.Action.From, trace.Action.To
+
+	switch trace.Type {
+	case parityCreateType:
+		to = nil
+		if trace.Result != nil {
+			to = trace.Result.Address
+		}
+
+	case paritySuicideType:
+		from, to = trace.Action.Address, trace.Action.RefundAddress
+	}
+
+	return containsAddress(f.FromAddress, from) && containsAddress(f.ToAddress, to)
+}
+
+// containsAddress checks whether the address is in the list, an empty list matches any address
+func containsAddress(addrs []types.Address, addr *types.Address) bool {
+	if len(addrs) == 0 {
+		return true
+	}
+
+	if addr == nil {
+		return false
+	}
+
+	for _, a := range addrs {
+		if a == *addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Block returns the calls made by all the transactions in the block
+func (t *Trace) Block(blockNumber BlockNumber) (interface{}, error) {
+	block, err := t.getBlockByNumber(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	return t.traceBlock(block)
+}
+
+// Transaction returns the calls made by the transaction
+func (t *Trace) Transaction(txHash types.Hash) (interface{}, error) {
+	tx, block := GetTxAndBlockByTxHash(txHash, t.store)
+	if tx == nil {
+		return nil, fmt.Errorf("tx %s not found", txHash.String())
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	tracer, cancel, err := newCallTracer()
+	if err != nil {
+		return nil, err
+	}
+
+	defer cancel()
+
+	res, err := t.store.TraceTxn(block, tx.Hash, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	frame, err := toCallFrame(res)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, blockTx := range block.Transactions {
+		if blockTx.Hash == tx.Hash {
+			return localizeTraces(flattenCallFrame(frame, []int{}, nil), block, idx), nil
+		}
+	}
+
+	return nil, fmt.Errorf("tx %s not found", txHash.String())
+}
+
+// ReplayBlockTransactions replays all the transactions in the block
+// and returns the requested traces of each of them
+func (t *Trace) ReplayBlockTransactions(
+	blockNumber BlockNumber,
+	traceTypes []string,
+) (interface{}, error) {
+	withTrace := false
+
+	for _, traceType := range traceTypes {
+		if traceType != traceTypeTrace {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedTraceType, traceType)
+		}
+
+		withTrace = true
+	}
+
+	block, err := t.getBlockByNumber(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	frames, err := t.traceBlockFrames(block)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*replayedTransaction, len(frames))
+
+	for idx, frame := range frames {
+		res[idx] = &replayedTransaction{
+			Output:          frame.Output,
+			TransactionHash: block.Transactions[idx].Hash,
+		}
+
+		if withTrace {
+			res[idx].Trace = flattenCallFrame(frame, []int{}, nil)
+		}
+	}
+
+	return res, nil
+}
+
+// Filter returns the calls made in the block range, matching the filter
+func (t *Trace) Filter(filter TraceFilter) (interface{}, error) {
+	fromBlock, toBlock := LatestBlockNumber, LatestBlockNumber
+	if filter.FromBlock != nil {
+		fromBlock = *filter.FromBlock
+	}
+
+	if filter.ToBlock != nil {
+		toBlock = *filter.ToBlock
+	}
+
+	from, err := GetNumericBlockNumber(fromBlock, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := GetNumericBlockNumber(toBlock, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if to < from {
+		return nil, ErrIncorrectBlockRange
+	}
+
+	// genesis block can't be traced, skip it
+	if from == 0 {
+		from = 1
+	}
+
+	// if not disabled, avoid handling large block ranges
+	if t.blockRangeLimit != 0 && to-from > t.blockRangeLimit {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	var (
+		skip  uint64
+		res   = make([]*parityTrace, 0)
+		limit = filter.Count
+	)
+
+	if filter.After != nil {
+		skip = *filter.After
+	}
+
+	for i := from; i <= to; i++ {
+		block, ok := t.store.GetBlockByNumber(i, true)
+		if !ok {
+			break
+		}
+
+		if len(block.Transactions) == 0 {
+			continue
+		}
+
+		traces, err := t.traceBlock(block)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, trace := range traces {
+			if !filter.matches(trace) {
+				continue
+			}
+
+			if skip > 0 {
+				skip--
+
+				continue
+			}
+
+			if limit != nil && uint64(len(res)) >= *limit {
+				return res, nil
+			}
+
+			res = append(res, trace)
+		}
+	}
+
+	return res, nil
+}
+
+func (t *Trace) getBlockByNumber(blockNumber BlockNumber) (*types.Block, error) {
+	num, err := GetNumericBlockNumber(blockNumber, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	return block, nil
+}
+
+// traceBlock returns the calls made by all the transactions in the block
+func (t *Trace) traceBlock(block *types.Block) ([]*parityTrace, error) {
+	frames, err := t.traceBlockFrames(block)
+	if err != nil {
+		return nil, err
+	}
+
+	traces := make([]*parityTrace, 0, len(frames))
+
+	for idx, frame := range frames {
+		traces = append(traces, localizeTraces(flattenCallFrame(frame, []int{}, nil), block, idx)...)
+	}
+
+	return traces, nil
+}
+
+// traceBlockFrames returns the call tree of each transaction in the block
+func (t *Trace) traceBlockFrames(block *types.Block) ([]*calltracer.CallFrame, error) {
+	tracer, cancel, err := newCallTracer()
+	if err != nil {
+		return nil, err
+	}
+
+	defer cancel()
+
+	results, err := t.store.TraceBlock(block, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make([]*calltracer.CallFrame, len(results))
+
+	for idx, res := range results {
+		if frames[idx], err = toCallFrame(res); err != nil {
+			return nil, err
+		}
+	}
+
+	return frames, nil
+}
+
+// newCallTracer creates the call tracer the parity traces are built from
+func newCallTracer() (tracer.Tracer, context.CancelFunc, error) {
+	tracerName := callTracerName
+
+	// the call tracer doesn't depend on the forks of the traced block
+	return newTracer(&TraceConfig{Tracer: &tracerName}, chain.ForksInTime{})
+}
+
+func toCallFrame(res interface{}) (*calltracer.CallFrame, error) {
+	frame, ok := res.(*calltracer.CallFrame)
+	if !ok {
+		return nil, fmt.Errorf("unexpected trace result %T", res)
+	}
+
+	return frame, nil
+}
+
+// flattenCallFrame converts the call tree built by the call tracer
+// to the flat list of calls of the parity trace format
+func flattenCallFrame(frame *calltracer.CallFrame, traceAddress []int, traces []*parityTrace) []*parityTrace {
+	from := frame.From
+
+	value := frame.Value
+	if value == "" {
+		value = "0x0"
+	}
+
+	trace := &parityTrace{
+		Error:        frame.Error,
+		Subtraces:    len(frame.Calls),
+		TraceAddress: traceAddress,
+	}
+
+	switch frame.Type {
+	case "CREATE", "CREATE2":
+		trace.Type = parityCreateType
+		trace.Action = &traceAction{
+			From:  &from,
+			Gas:   frame.Gas,
+			Init:  frame.Input,
+			Value: value,
+		}
+
+		if frame.Error == "" {
+			trace.Result = &traceResult{
+				GasUsed: frame.GasUsed,
+				Address: frame.To,
+				Code:    frame.Output,
+			}
+		}
+
+	case "SELFDESTRUCT":
+		trace.Type = paritySuicideType
+		trace.Action = &traceAction{
+			Address:       &from,
+			RefundAddress: frame.To,
+			Balance:       value,
+		}
+
+	default:
+		trace.Type = parityCallType
+		trace.Action = &traceAction{
+			CallType: strings.ToLower(frame.Type),
+			From:     &from,
+			To:       frame.To,
+			Gas:      frame.Gas,
+			Input:    frame.Input,
+			Value:    value,
+		}
+
+		if frame.Error == "" {
+			trace.Result = &traceResult{
+				GasUsed: frame.GasUsed,
+				Output:  frame.Output,
+			}
+		}
+	}
+
+	traces = append(traces, trace)
+
+	for idx, call := range frame.Calls {
+		callAddress := make([]int, len(traceAddress)+1)
+		copy(callAddress, traceAddress)
+		callAddress[len(traceAddress)] = idx
+
+		traces = flattenCallFrame(call, callAddress, traces)
+	}
+
+	return traces
+}
+
+// localizeTraces sets the block and the transaction the calls were made in
+func localizeTraces(traces []*parityTrace, block *types.Block, txIndex int) []*parityTrace {
+	var (
+		blockHash   = block.Hash()
+		blockNumber = block.Number()
+		txHash      = block.Transactions[txIndex].Hash
+		txPosition  = uint64(txIndex)
+	)
+
+	for _, trace := range traces {
+		trace.BlockHash = &blockHash
+		trace.BlockNumber = &blockNumber
+		trace.TransactionHash = &txHash
+		trace.TransactionPosition = &txPosition
+	}
+
+	return traces
+}
@@ -0,0 +1,319 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/state/runtime/tracer"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer/calltracer"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+var (
+	testTraceFrom     = types.StringToAddress("1")
+	testTraceTo       = types.StringToAddress("2")
+	testTraceCreated  = types.StringToAddress("3")
+	testTraceCallee   = types.StringToAddress("4")
+	testTraceTxHash   = types.StringToHash("1")
+	testTraceTxHash2  = types.StringToHash("2")
+	testTraceTx       = createTestTransaction(testTraceTxHash)
+	testTraceTx2      = createTestTransaction(testTraceTxHash2)
+	testTraceBlockNum = uint64(10)
+)
+
+// newTestCallFrame returns a call to testTraceTo, which creates a contract
+// and makes a reverted call to testTraceCallee
+func newTestCallFrame() *calltracer.CallFrame {
+	to, created, callee := testTraceTo, testTraceCreated, testTraceCallee
+
+	return &calltracer.CallFrame{
+		Type:    "CALL",
+		From:    testTraceFrom,
+		To:      &to,
+		Value:   "0x1",
+		Gas:     "0x186a0",
+		GasUsed: "0x9c40",
+		Input:   "0x01",
+		Output:  "0x02",
+		Calls: []*calltracer.CallFrame{
+			{
+				Type:    "CREATE",
+				From:    testTraceTo,
+				To:      &created,
+				Value:   "0x0",
+				Gas:     "0x1388",
+				GasUsed: "0x3e8",
+				Input:   "0x6000",
+				Output:  "0x00",
+			},
+			{
+				Type:    "DELEGATECALL",
+				From:    testTraceTo,
+				To:      &callee,
+				Gas:     "0x1388",
+				GasUsed: "0x7d0",
+				Input:   "0x",
+				Output:  "0x",
+				Error:   "execution reverted",
+			},
+		},
+	}
+}
+
+func newTestTraceBlock() *types.Block {
+	header := createTestHeader(testTraceBlockNum)
+
+	return &types.Block{
+		Header:       header,
+		Transactions: []*types.Transaction{testTraceTx, testTraceTx2},
+	}
+}
+
+func newTestTraceStore(block *types.Block) *debugEndpointMockStore {
+	return &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return block.Header
+		},
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			switch {
+			case num == block.Number():
+				return block, true
+			case num < block.Number():
+				// the previous blocks are empty
+				return wrapHeaderWithTestBlock(createTestHeader(num)), true
+			default:
+				return nil, false
+			}
+		},
+		readTxLookupFn: func(hash types.Hash) (types.Hash, bool) {
+			return block.Hash(), true
+		},
+		getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+			return block, true
+		},
+		traceBlockFn: func(b *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+			if _, ok := tracer.(*calltracer.CallTracer); !ok {
+				return nil, ErrUnknownTracer
+			}
+
+			return []interface{}{newTestCallFrame(), newTestCallFrame()}, nil
+		},
+		traceTxnFn: func(b *types.Block, hash types.Hash, tracer tracer.Tracer) (interface{}, error) {
+			if _, ok := tracer.(*calltracer.CallTracer); !ok {
+				return nil, ErrUnknownTracer
+			}
+
+			return newTestCallFrame(), nil
+		},
+	}
+}
+
+func TestTrace_flattenCallFrame(t *testing.T) {
+	t.Parallel()
+
+	from, to, created, callee := testTraceFrom, testTraceTo, testTraceCreated, testTraceCallee
+
+	traces := flattenCallFrame(newTestCallFrame(), []int{}, nil)
+
+	assert.Equal(t, []*parityTrace{
+		{
+			Action: &traceAction{
+				CallType: "call",
+				From:     &from,
+				To:       &to,
+				Gas:      "0x186a0",
+				Input:    "0x01",
+				Value:    "0x1",
+			},
+			Result: &traceResult{
+				GasUsed: "0x9c40",
+				Output:  "0x02",
+			},
+			Subtraces:    2,
+			TraceAddress: []int{},
+			Type:         "call",
+		},
+		{
+			Action: &traceAction{
+				From:  &to,
+				Gas:   "0x1388",
+				Init:  "0x6000",
+				Value: "0x0",
+			},
+			Result: &traceResult{
+				GasUsed: "0x3e8",
+				Address: &created,
+				Code:    "0x00",
+			},
+			TraceAddress: []int{0},
+			Type:         "create",
+		},
+		{
+			Action: &traceAction{
+				CallType: "delegatecall",
+				From:     &to,
+				To:       &callee,
+				Gas:      "0x1388",
+				Input:    "0x",
+				Value:    "0x0",
+			},
+			Error:        "execution reverted",
+			TraceAddress: []int{1},
+			Type:         "call",
+		},
+	}, traces)
+}
+
+func TestTrace_Block(t *testing.T) {
+	t.Parallel()
+
+	block := newTestTraceBlock()
+	endpoint := &Trace{newTestTraceStore(block), 1000}
+
+	res, err := endpoint.Block(BlockNumber(testTraceBlockNum))
+	require.NoError(t, err)
+
+	traces, ok := res.([]*parityTrace)
+	require.True(t, ok)
+	require.Len(t, traces, 6)
+
+	for idx, trace := range traces {
+		assert.Equal(t, block.Hash(), *trace.BlockHash)
+		assert.Equal(t, testTraceBlockNum, *trace.BlockNumber)
+		assert.Equal(t, uint64(idx/3), *trace.TransactionPosition)
+		assert.Equal(t, block.Transactions[idx/3].Hash, *trace.TransactionHash)
+	}
+
+	_, err = endpoint.Block(BlockNumber(11))
+	assert.Error(t, err)
+}
+
+func TestTrace_Transaction(t *testing.T) {
+	t.Parallel()
+
+	block := newTestTraceBlock()
+	endpoint := &Trace{newTestTraceStore(block), 1000}
+
+	res, err := endpoint.Transaction(testTraceTxHash2)
+	require.NoError(t, err)
+
+	traces, ok := res.([]*parityTrace)
+	require.True(t, ok)
+	require.Len(t, traces, 3)
+
+	for _, trace := range traces {
+		assert.Equal(t, testTraceTxHash2, *trace.TransactionHash)
+		assert.Equal(t, uint64(1), *trace.TransactionPosition)
+	}
+}
+
+func TestTrace_ReplayBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	block := newTestTraceBlock()
+	endpoint := &Trace{newTestTraceStore(block), 1000}
+
+	res, err := endpoint.ReplayBlockTransactions(BlockNumber(testTraceBlockNum), []string{"trace"})
+	require.NoError(t, err)
+
+	replayed, ok := res.([]*replayedTransaction)
+	require.True(t, ok)
+	require.Len(t, replayed, 2)
+
+	for idx, tx := range replayed {
+		assert.Equal(t, block.Transactions[idx].Hash, tx.TransactionHash)
+		assert.Equal(t, "0x02", tx.Output)
+		require.Len(t, tx.Trace, 3)
+		// the replayed traces are not localized
+		assert.Nil(t, tx.Trace[0].BlockHash)
+	}
+
+	_, err = endpoint.ReplayBlockTransactions(BlockNumber(testTraceBlockNum), []string{"vmTrace"})
+	assert.ErrorIs(t, err, ErrUnsupportedTraceType)
+}
+
+func TestTrace_Filter(t *testing.T) {
+	t.Parallel()
+
+	block := newTestTraceBlock()
+
+	blockNumber := func(n uint64) *BlockNumber {
+		num := BlockNumber(n)
+
+		return &num
+	}
+
+	count := func(n uint64) *uint64 {
+		return &n
+	}
+
+	tests := []struct {
+		name     string
+		filter   TraceFilter
+		limit    uint64
+		expected int
+		err      error
+	}{
+		{
+			name:     "should return all the traces of the range",
+			filter:   TraceFilter{FromBlock: blockNumber(0)},
+			limit:    1000,
+			expected: 6,
+		},
+		{
+			name:     "should filter by the sender",
+			filter:   TraceFilter{FromAddress: []types.Address{testTraceFrom}},
+			limit:    1000,
+			expected: 2,
+		},
+		{
+			name:     "should filter by the created contract",
+			filter:   TraceFilter{ToAddress: []types.Address{testTraceCreated}},
+			limit:    1000,
+			expected: 2,
+		},
+		{
+			name:     "should paginate the traces",
+			filter:   TraceFilter{After: count(2), Count: count(3)},
+			limit:    1000,
+			expected: 3,
+		},
+		{
+			name:   "should return error for too large range",
+			filter: TraceFilter{FromBlock: blockNumber(1)},
+			limit:  5,
+			err:    ErrBlockRangeTooHigh,
+		},
+		{
+			name:   "should return error for incorrect range",
+			filter: TraceFilter{FromBlock: blockNumber(10), ToBlock: blockNumber(9)},
+			limit:  1000,
+			err:    ErrIncorrectBlockRange,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := &Trace{newTestTraceStore(block), test.limit}
+
+			res, err := endpoint.Filter(test.filter)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			traces, ok := res.([]*parityTrace)
+			require.True(t, ok)
+			assert.Len(t, traces, test.expected)
+		})
+	}
+}
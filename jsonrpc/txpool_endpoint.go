@@ -14,6 +14,10 @@ type txPoolStore interface {
 
 	// GetCapacity returns the current and max capacity of the pool in slots
 	GetCapacity() (uint64, uint64)
+
+	// GetTxsFrom gets the page of the pending and queued transactions of the sender, ordered by nonce,
+	// along with the total number of the sender's transactions
+	GetTxsFrom(addr types.Address, offset, limit uint64) ([]*types.Transaction, []*types.Transaction, uint64)
 }
 
 // TxPool is the txpool jsonrpc endpoint
@@ -26,6 +30,18 @@ type ContentResponse struct {
 	Queued  map[types.Address]map[uint64]*txpoolTransaction `json:"queued"`
 }
 
+type ContentFromResponse struct {
+	Pending map[uint64]*txpoolTransaction `json:"pending"`
+	Queued  map[uint64]*txpoolTransaction `json:"queued"`
+	Total   uint64                        `json:"total"`
+}
+
+// pageArgs selects the page of the transactions, ordered by nonce
+type pageArgs struct {
+	Offset argUint64 `json:"offset"`
+	Limit  argUint64 `json:"limit"`
+}
+
 type InspectResponse struct {
 	Pending         map[string]map[string]string `json:"pending"`
 	Queued          map[string]map[string]string `json:"queued"`
@@ -55,7 +71,7 @@ type txpoolTransaction struct {
 func toTxPoolTransaction(t *types.Transaction) *txpoolTransaction {
 	return &txpoolTransaction{
 		Nonce:       argUint64(t.Nonce),
-		GasPrice:    argBig(*t.GasPrice),
+		GasPrice:    argBig(*t.GetGasFeeCap()),
 		Gas:         argUint64(t.Gas),
 		To:          t.To,
 		Value:       argBig(*t.Value),
@@ -107,6 +123,34 @@ func (t *TxPool) Content() (interface{}, error) {
 	return resp, nil
 }
 
+// Create response for txpool_contentFrom request, limited to the transactions of the given sender.
+// The optional page selects the range of the transactions ordered by nonce,
+// the page size is capped by txpool.MaxTxsPageSize.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_contentfrom.
+func (t *TxPool) ContentFrom(addr types.Address, page *pageArgs) (interface{}, error) {
+	if page == nil {
+		page = &pageArgs{}
+	}
+
+	pendingTxs, queuedTxs, total := t.store.GetTxsFrom(addr, uint64(page.Offset), uint64(page.Limit))
+
+	resp := ContentFromResponse{
+		Pending: make(map[uint64]*txpoolTransaction, len(pendingTxs)),
+		Queued:  make(map[uint64]*txpoolTransaction, len(queuedTxs)),
+		Total:   total,
+	}
+
+	for _, tx := range pendingTxs {
+		resp.Pending[tx.Nonce] = toTxPoolTransaction(tx)
+	}
+
+	for _, tx := range queuedTxs {
+		resp.Queued[tx.Nonce] = toTxPoolTransaction(tx)
+	}
+
+	return resp, nil
+}
+
 // Create response for txpool_inspect request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
 func (t *TxPool) Inspect() (interface{}, error) {
@@ -120,7 +164,7 @@ func (t *TxPool) Inspect() (interface{}, error) {
 		for _, tx := range txs {
 			nonceStr := strconv.FormatUint(tx.Nonce, 10)
 			pendingRPCTxs[addr.String()][nonceStr] = fmt.Sprintf(
-				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GasPrice,
+				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GetGasFeeCap(),
 			)
 		}
 	}
@@ -133,7 +177,7 @@ func (t *TxPool) Inspect() (interface{}, error) {
 		for _, tx := range txs {
 			nonceStr := strconv.FormatUint(tx.Nonce, 10)
 			queuedRPCTxs[addr.String()][nonceStr] = fmt.Sprintf(
-				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GasPrice,
+				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GetGasFeeCap(),
 			)
 		}
 	}
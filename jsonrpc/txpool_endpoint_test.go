@@ -228,12 +228,49 @@ func TestStatusEndpoint(t *testing.T) {
 	})
 }
 
+func TestContentFromEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	address1, address2 := types.Address{0x1}, types.Address{0x2}
+
+	mockStore.pending[address1] = []*types.Transaction{
+		newTestTransaction(0, address1),
+		newTestTransaction(1, address1),
+	}
+	mockStore.queued[address1] = []*types.Transaction{newTestTransaction(3, address1)}
+	mockStore.pending[address2] = []*types.Transaction{newTestTransaction(0, address2)}
+
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, err := txPoolEndpoint.ContentFrom(address1, nil)
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	response := result.(ContentFromResponse)
+
+	assert.Equal(t, uint64(3), response.Total)
+	assert.Len(t, response.Pending, 2)
+	assert.Len(t, response.Queued, 1)
+	assert.Equal(t, address1, response.Pending[1].From)
+	assert.Equal(t, argUint64(3), response.Queued[3].Nonce)
+
+	// the page is passed to the store
+	_, err = txPoolEndpoint.ContentFrom(address1, &pageArgs{Offset: 1, Limit: 2})
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(1), mockStore.offset)
+	assert.Equal(t, uint64(2), mockStore.limit)
+}
+
 type mockTxPoolStore struct {
 	pending       map[types.Address][]*types.Transaction
 	queued        map[types.Address][]*types.Transaction
 	capacity      uint64
 	maxSlots      uint64
 	includeQueued bool
+	offset        uint64
+	limit         uint64
 }
 
 func newMockTxPoolStore() *mockTxPoolStore {
@@ -253,6 +290,15 @@ func (s *mockTxPoolStore) GetCapacity() (uint64, uint64) {
 	return s.capacity, s.maxSlots
 }
 
+func (s *mockTxPoolStore) GetTxsFrom(
+	addr types.Address,
+	offset, limit uint64,
+) ([]*types.Transaction, []*types.Transaction, uint64) {
+	s.offset, s.limit = offset, limit
+
+	return s.pending[addr], s.queued[addr], uint64(len(s.pending[addr]) + len(s.queued[addr]))
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
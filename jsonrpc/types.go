@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/vishnushankarsg/metad/gasprice"
 	"github.com/vishnushankarsg/metad/helper/hex"
 	"github.com/vishnushankarsg/metad/types"
 )
@@ -15,20 +16,25 @@ type transactionOrHash interface {
 }
 
 type transaction struct {
-	Nonce       argUint64      `json:"nonce"`
-	GasPrice    argBig         `json:"gasPrice"`
-	Gas         argUint64      `json:"gas"`
-	To          *types.Address `json:"to"`
-	Value       argBig         `json:"value"`
-	Input       argBytes       `json:"input"`
-	V           argBig         `json:"v"`
-	R           argBig         `json:"r"`
-	S           argBig         `json:"s"`
-	Hash        types.Hash     `json:"hash"`
-	From        types.Address  `json:"from"`
-	BlockHash   *types.Hash    `json:"blockHash"`
-	BlockNumber *argUint64     `json:"blockNumber"`
-	TxIndex     *argUint64     `json:"transactionIndex"`
+	Nonce       argUint64           `json:"nonce"`
+	GasPrice    argBig              `json:"gasPrice"`
+	GasTipCap   *argBig             `json:"maxPriorityFeePerGas,omitempty"`
+	GasFeeCap   *argBig             `json:"maxFeePerGas,omitempty"`
+	Gas         argUint64           `json:"gas"`
+	To          *types.Address      `json:"to"`
+	Value       argBig              `json:"value"`
+	Input       argBytes            `json:"input"`
+	V           argBig              `json:"v"`
+	R           argBig              `json:"r"`
+	S           argBig              `json:"s"`
+	Hash        types.Hash          `json:"hash"`
+	From        types.Address       `json:"from"`
+	BlockHash   *types.Hash         `json:"blockHash"`
+	BlockNumber *argUint64          `json:"blockNumber"`
+	TxIndex     *argUint64          `json:"transactionIndex"`
+	Type        argUint64           `json:"type"`
+	ChainID     *argBig             `json:"chainId,omitempty"`
+	AccessList  *types.TxAccessList `json:"accessList,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -43,18 +49,27 @@ func (h transactionHash) MarshalText() ([]byte, error) {
 }
 
 func toPendingTransaction(t *types.Transaction) *transaction {
-	return toTransaction(t, nil, nil, nil)
+	return toTransaction(t, nil, nil, nil, nil)
 }
 
+// toTransaction converts the transaction to its json-rpc representation.
+// The gas price of the sealed dynamic fee transactions is the effective gas price for the block base fee,
+// the pending ones report the gas fee cap instead
 func toTransaction(
 	t *types.Transaction,
 	blockNumber *argUint64,
 	blockHash *types.Hash,
 	txIndex *int,
+	baseFee *big.Int,
 ) *transaction {
+	gasPrice := t.GetGasFeeCap()
+	if baseFee != nil {
+		gasPrice = t.EffectiveGasPrice(baseFee)
+	}
+
 	res := &transaction{
 		Nonce:    argUint64(t.Nonce),
-		GasPrice: argBig(*t.GasPrice),
+		GasPrice: argBig(*gasPrice),
 		Gas:      argUint64(t.Gas),
 		To:       t.To,
 		Value:    argBig(*t.Value),
@@ -64,6 +79,25 @@ func toTransaction(
 		S:        argBig(*t.S),
 		Hash:     t.Hash,
 		From:     t.From,
+		Type:     argUint64(t.Type),
+	}
+
+	if t.Type == types.DynamicFeeTx {
+		res.GasTipCap = argBigPtr(t.GetGasTipCap())
+		res.GasFeeCap = argBigPtr(t.GetGasFeeCap())
+	}
+
+	if t.Type == types.AccessListTx || t.Type == types.DynamicFeeTx {
+		accessList := types.TxAccessList{}
+		if t.AccessList != nil {
+			accessList = t.AccessList
+		}
+
+		res.AccessList = &accessList
+
+		if t.ChainID != nil {
+			res.ChainID = argBigPtr(t.ChainID)
+		}
 	}
 
 	if blockNumber != nil {
@@ -100,6 +134,7 @@ type block struct {
 	MixHash         types.Hash          `json:"mixHash"`
 	Nonce           types.Nonce         `json:"nonce"`
 	Hash            types.Hash          `json:"hash"`
+	BaseFee         argUint64           `json:"baseFeePerGas,omitempty"`
 	Transactions    []transactionOrHash `json:"transactions"`
 	Uncles          []types.Hash        `json:"uncles"`
 }
@@ -138,6 +173,7 @@ func toBlock(b *types.Block, fullTx bool) *block {
 		MixHash:         h.MixHash,
 		Nonce:           h.Nonce,
 		Hash:            h.Hash,
+		BaseFee:         argUint64(h.BaseFee),
 		Transactions:    []transactionOrHash{},
 		Uncles:          []types.Hash{},
 	}
@@ -151,6 +187,7 @@ func toBlock(b *types.Block, fullTx bool) *block {
 					argUintPtr(b.Number()),
 					argHashPtr(b.Hash()),
 					&idx,
+					new(big.Int).SetUint64(h.BaseFee),
 				),
 			)
 		} else {
@@ -182,6 +219,8 @@ type receipt struct {
 	ContractAddress   *types.Address `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
+	EffectiveGasPrice argBig         `json:"effectiveGasPrice"`
+	Type              argUint64      `json:"type"`
 }
 
 type Log struct {
@@ -196,6 +235,49 @@ type Log struct {
 	Removed     bool          `json:"removed"`
 }
 
+// toReceipt returns the receipt of the transaction at the given index of the block
+// with the given header. The logs are numbered within the block, starting with logIndex
+func toReceipt(
+	raw *types.Receipt,
+	txn *types.Transaction,
+	txIndex uint64,
+	logIndex uint64,
+	header *types.Header,
+) *receipt {
+	logs := make([]*Log, len(raw.Logs))
+	for indx, elem := range raw.Logs {
+		logs[indx] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   header.Hash,
+			BlockNumber: argUint64(header.Number),
+			TxHash:      txn.Hash,
+			TxIndex:     argUint64(txIndex),
+			LogIndex:    argUint64(logIndex + uint64(indx)),
+			Removed:     false,
+		}
+	}
+
+	return &receipt{
+		Root:              raw.Root,
+		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
+		LogsBloom:         raw.LogsBloom,
+		Status:            argUint64(*raw.Status),
+		TxHash:            txn.Hash,
+		TxIndex:           argUint64(txIndex),
+		BlockHash:         header.Hash,
+		BlockNumber:       argUint64(header.Number),
+		GasUsed:           argUint64(raw.GasUsed),
+		ContractAddress:   raw.ContractAddress,
+		FromAddr:          txn.From,
+		ToAddr:            txn.To,
+		Logs:              logs,
+		EffectiveGasPrice: argBig(*txn.EffectiveGasPrice(new(big.Int).SetUint64(header.BaseFee))),
+		Type:              argUint64(txn.Type),
+	}
+}
+
 type argBig big.Int
 
 func argBigPtr(b *big.Int) *argBig {
@@ -307,14 +389,62 @@ func encodeToHex(b []byte) []byte {
 
 // txnArgs is the transaction argument for the rpc endpoints
 type txnArgs struct {
-	From     *types.Address
-	To       *types.Address
-	Gas      *argUint64
-	GasPrice *argBytes
-	Value    *argBytes
-	Data     *argBytes
-	Input    *argBytes
-	Nonce    *argUint64
+	From                 *types.Address
+	To                   *types.Address
+	Gas                  *argUint64
+	GasPrice             *argBytes
+	MaxFeePerGas         *argBytes
+	MaxPriorityFeePerGas *argBytes
+	Value                *argBytes
+	Data                 *argBytes
+	Input                *argBytes
+	Nonce                *argUint64
+	Type                 *argUint64
+	ChainID              *argUint64
+	AccessList           *types.TxAccessList
+}
+
+// bundleArgs is the bundle argument for the eth_sendBundle endpoint
+type bundleArgs struct {
+	Txs         []argBytes `json:"txs"`
+	BlockNumber argUint64  `json:"blockNumber"`
+}
+
+type bundleResult struct {
+	BundleHash types.Hash `json:"bundleHash"`
+}
+
+type feeHistory struct {
+	OldestBlock   argUint64     `json:"oldestBlock"`
+	BaseFeePerGas []argUint64   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64     `json:"gasUsedRatio"`
+	Reward        [][]argUint64 `json:"reward,omitempty"`
+}
+
+func toFeeHistory(h *gasprice.FeeHistory) *feeHistory {
+	res := &feeHistory{
+		OldestBlock:   argUint64(h.OldestBlock),
+		BaseFeePerGas: make([]argUint64, len(h.BaseFee)),
+		GasUsedRatio:  h.GasUsedRatio,
+	}
+
+	for i, baseFee := range h.BaseFee {
+		res.BaseFeePerGas[i] = argUint64(baseFee)
+	}
+
+	if h.Reward != nil {
+		res.Reward = make([][]argUint64, len(h.Reward))
+
+		for i, rewards := range h.Reward {
+			res.Reward[i] = make([]argUint64, len(rewards))
+
+			for j, reward := range rewards {
+				res.Reward[i][j] = argUint64(reward)
+			}
+		}
+	}
+
+	return res
 }
 
 type progression struct {
@@ -323,3 +453,97 @@ type progression struct {
 	CurrentBlock  argUint64 `json:"currentBlock"`
 	HighestBlock  argUint64 `json:"highestBlock"`
 }
+
+type syncingStatus struct {
+	Syncing bool         `json:"syncing"`
+	Status  *progression `json:"status"`
+}
+
+type storageProof struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+type accountProof struct {
+	Address      types.Address   `json:"address"`
+	AccountProof []argBytes      `json:"accountProof"`
+	Balance      argBig          `json:"balance"`
+	CodeHash     types.Hash      `json:"codeHash"`
+	Nonce        argUint64       `json:"nonce"`
+	StorageHash  types.Hash      `json:"storageHash"`
+	StorageProof []*storageProof `json:"storageProof"`
+}
+
+func toProofNodes(proof [][]byte) []argBytes {
+	nodes := make([]argBytes, len(proof))
+	for i, node := range proof {
+		nodes[i] = argBytes(node)
+	}
+
+	return nodes
+}
+
+func toAccountProof(address types.Address, p *AccountProof) *accountProof {
+	res := &accountProof{
+		Address:      address,
+		AccountProof: toProofNodes(p.Proof),
+		Balance:      argBig(*p.Account.Balance),
+		CodeHash:     types.BytesToHash(p.Account.CodeHash),
+		Nonce:        argUint64(p.Account.Nonce),
+		StorageHash:  p.Account.Root,
+		StorageProof: make([]*storageProof, len(p.StorageProofs)),
+	}
+
+	for i, sp := range p.StorageProofs {
+		res.StorageProof[i] = &storageProof{
+			Key:   sp.Key,
+			Value: argBig(*new(big.Int).SetBytes(sp.Value.Bytes())),
+			Proof: toProofNodes(sp.Proof),
+		}
+	}
+
+	return res
+}
+
+type traceAction struct {
+	CallType      string         `json:"callType,omitempty"`
+	From          *types.Address `json:"from,omitempty"`
+	To            *types.Address `json:"to,omitempty"`
+	Gas           string         `json:"gas,omitempty"`
+	Input         string         `json:"input,omitempty"`
+	Init          string         `json:"init,omitempty"`
+	Value         string         `json:"value,omitempty"`
+	Address       *types.Address `json:"address,omitempty"`
+	RefundAddress *types.Address `json:"refundAddress,omitempty"`
+	Balance       string         `json:"balance,omitempty"`
+}
+
+type traceResult struct {
+	GasUsed string         `json:"gasUsed"`
+	Output  string         `json:"output,omitempty"`
+	Address *types.Address `json:"address,omitempty"`
+	Code    string         `json:"code,omitempty"`
+}
+
+// parityTrace is a single call of the flat list of calls in the parity trace format
+type parityTrace struct {
+	Action              *traceAction `json:"action"`
+	BlockHash           *types.Hash  `json:"blockHash,omitempty"`
+	BlockNumber         *uint64      `json:"blockNumber,omitempty"`
+	Error               string       `json:"error,omitempty"`
+	Result              *traceResult `json:"result"`
+	Subtraces           int          `json:"subtraces"`
+	TraceAddress        []int        `json:"traceAddress"`
+	TransactionHash     *types.Hash  `json:"transactionHash,omitempty"`
+	TransactionPosition *uint64      `json:"transactionPosition,omitempty"`
+	Type                string       `json:"type"`
+}
+
+type replayedTransaction struct {
+	Output          string         `json:"output"`
+	StateDiff       interface{}    `json:"stateDiff"`
+	Trace           []*parityTrace `json:"trace"`
+	VMTrace         interface{}    `json:"vmTrace"`
+	TransactionHash types.Hash     `json:"transactionHash"`
+}
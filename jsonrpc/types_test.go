@@ -74,6 +74,23 @@ func TestDecode_TxnArgs(t *testing.T) {
 				Value: &hex,
 			},
 		},
+		{
+			data: `{
+				"to": "{{.Libp2pAddr}}",
+				"accessList": [
+					{
+						"address": "{{.Libp2pAddr}}",
+						"storageKeys": ["{{.Hash}}"]
+					}
+				]
+			}`,
+			res: &txnArgs{
+				To: &addr,
+				AccessList: &types.TxAccessList{
+					{Address: addr, StorageKeys: []types.Hash{{}}},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -117,7 +134,7 @@ func TestToTransaction_Returns_V_R_S_ValuesWithoutLeading0(t *testing.T) {
 		From:     types.Address{},
 	}
 
-	jsonTx := toTransaction(&txn, nil, nil, nil)
+	jsonTx := toTransaction(&txn, nil, nil, nil, nil)
 
 	jsonV, _ := jsonTx.V.MarshalText()
 	jsonR, _ := jsonTx.R.MarshalText()
@@ -287,20 +287,22 @@ func CreateServer(params *CreateServerParams) (*Server, error) {
 		params.Logger = hclog.NewNullLogger()
 	}
 
-	secretsManager, factoryErr := local.SecretsManagerFactory(
-		nil,
-		&secrets.SecretsManagerParams{
-			Logger: params.Logger,
-			Extra: map[string]interface{}{
-				secrets.Path: cfg.DataDir,
+	if cfg.SecretsManager == nil {
+		secretsManager, factoryErr := local.SecretsManagerFactory(
+			nil,
+			&secrets.SecretsManagerParams{
+				Logger: params.Logger,
+				Extra: map[string]interface{}{
+					secrets.Path: cfg.DataDir,
+				},
 			},
-		},
-	)
-	if factoryErr != nil {
-		return nil, factoryErr
-	}
+		)
+		if factoryErr != nil {
+			return nil, factoryErr
+		}
 
-	cfg.SecretsManager = secretsManager
+		cfg.SecretsManager = secretsManager
+	}
 
 	server, err := NewServer(params.Logger, cfg)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/vishnushankarsg/metad/secrets"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/crypto/pb"
 )
 
 // ReadLibp2pKey reads the private networking key from the secrets manager
@@ -46,3 +47,55 @@ func ParseLibp2pKey(key []byte) (crypto.PrivKey, error) {
 
 	return libp2pKey, nil
 }
+
+// remoteLibp2pKey is the networking private key held by the remote signing service,
+// which signs on behalf of the node and never exposes the key
+type remoteLibp2pKey struct {
+	signer secrets.KeySigner
+	pubKey crypto.PubKey
+}
+
+// NewRemoteLibp2pKey returns the networking private key signing by the remote signing service
+func NewRemoteLibp2pKey(signer secrets.KeySigner) (crypto.PrivKey, error) {
+	pubKeyRaw, err := signer.PublicKey(secrets.NetworkKey, secrets.Libp2pScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := crypto.UnmarshalPublicKey(pubKeyRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteLibp2pKey{
+		signer: signer,
+		pubKey: pubKey,
+	}, nil
+}
+
+// Equals checks whether the keys have the same public key
+func (k *remoteLibp2pKey) Equals(other crypto.Key) bool {
+	otherPrivKey, ok := other.(crypto.PrivKey)
+
+	return ok && k.pubKey.Equals(otherPrivKey.GetPublic())
+}
+
+// Raw never returns the key, which stays in the signing service
+func (k *remoteLibp2pKey) Raw() ([]byte, error) {
+	return nil, secrets.ErrKeyNotExportable
+}
+
+// Type returns the type of the key
+func (k *remoteLibp2pKey) Type() pb.KeyType {
+	return k.pubKey.Type()
+}
+
+// Sign signs the data by the signing service
+func (k *remoteLibp2pKey) Sign(data []byte) ([]byte, error) {
+	return k.signer.Sign(secrets.NetworkKey, secrets.Libp2pScheme, data, nil)
+}
+
+// GetPublic returns the public key
+func (k *remoteLibp2pKey) GetPublic() crypto.PubKey {
+	return k.pubKey
+}
@@ -0,0 +1,339 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/vishnushankarsg/metad/helper/common"
+)
+
+// PeerPenalty is the amount the penalty score of a misbehaving peer is increased by
+type PeerPenalty float64
+
+// Define the penalties of the peer misbehaviors
+const (
+	// PenaltyInvalidTx is the penalty of gossiping an invalid transaction
+	PenaltyInvalidTx PeerPenalty = 5
+
+	// PenaltyInvalidConsensusMessage is the penalty of gossiping an invalid consensus message
+	PenaltyInvalidConsensusMessage PeerPenalty = 20
+
+	// PenaltyInvalidBlock is the penalty of serving a block that fails verification
+	PenaltyInvalidBlock PeerPenalty = 50
+)
+
+const (
+	// BanThreshold is the penalty score at which the peer is banned
+	BanThreshold = 100
+
+	// DefaultBanDuration is the duration of the bans by the penalty score
+	DefaultBanDuration = time.Hour
+
+	// penaltyDecayPerMinute is the amount the penalty score decays by every minute,
+	// so only the peers misbehaving repeatedly in a short time are banned
+	penaltyDecayPerMinute = 10
+
+	// BannedPeersFile is the name of the file in the networking data directory
+	// the bans are persisted to
+	BannedPeersFile = "banned_peers.json"
+)
+
+var (
+	ErrPeerNotBanned = errors.New("peer is not banned")
+	ErrBanSelf       = errors.New("unable to ban the local node")
+	ErrBanDuration   = errors.New("ban duration must be positive")
+)
+
+// BannedPeer is the ban of a peer, refused until the ban expires
+type BannedPeer struct {
+	ID     peer.ID   `json:"id"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason"`
+}
+
+// peerScore is the penalty score of a peer, as of the last update
+type peerScore struct {
+	penalty float64
+	updated time.Time
+}
+
+// current returns the penalty score decayed since the last update
+func (s *peerScore) current(now time.Time) float64 {
+	decay := now.Sub(s.updated).Minutes() * penaltyDecayPerMinute
+
+	return maxFloat(s.penalty-decay, 0)
+}
+
+// reputation tracks the penalty scores of the peers and the bans.
+// The bans are persisted to the ban list file, if set
+type reputation struct {
+	logger hclog.Logger
+
+	path string // the ban list file, empty if the bans are not persisted
+
+	scores map[peer.ID]*peerScore  // the penalty scores of the misbehaving peers
+	bans   map[peer.ID]*BannedPeer // the active bans
+
+	lastPrune time.Time // the time the decayed scores were pruned last
+
+	lock sync.Mutex
+
+	now func() time.Time // the time source, replaced in tests
+}
+
+// newReputation creates the reputation tracker, loading the bans persisted in the data directory
+func newReputation(logger hclog.Logger, dataDir string) (*reputation, error) {
+	r := &reputation{
+		logger: logger,
+		scores: make(map[peer.ID]*peerScore),
+		bans:   make(map[peer.ID]*BannedPeer),
+		now:    time.Now,
+	}
+
+	if dataDir == "" {
+		return r, nil
+	}
+
+	r.path = filepath.Join(dataDir, BannedPeersFile)
+
+	if err := r.load(); err != nil {
+		return nil, fmt.Errorf("unable to load banned peers, %w", err)
+	}
+
+	return r, nil
+}
+
+// load reads the unexpired bans from the ban list file
+func (r *reputation) load() error {
+	raw, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var bans []*BannedPeer
+	if err := json.Unmarshal(raw, &bans); err != nil {
+		return err
+	}
+
+	now := r.now()
+
+	for _, ban := range bans {
+		if ban.Until.After(now) {
+			r.bans[ban.ID] = ban
+		}
+	}
+
+	return nil
+}
+
+// save writes the active bans to the ban list file, if set. Must be called under the lock
+func (r *reputation) save() error {
+	if r.path == "" {
+		return nil
+	}
+
+	raw, err := json.MarshalIndent(r.activeBans(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := common.SaveFileSafe(r.path, raw, 0660); err != nil {
+		return fmt.Errorf("unable to save banned peers, %w", err)
+	}
+
+	return nil
+}
+
+// activeBans prunes the expired bans and returns the active ones. Must be called under the lock
+func (r *reputation) activeBans() []*BannedPeer {
+	now := r.now()
+	bans := make([]*BannedPeer, 0, len(r.bans))
+
+	for id, ban := range r.bans {
+		if !ban.Until.After(now) {
+			delete(r.bans, id)
+
+			continue
+		}
+
+		bans = append(bans, ban)
+	}
+
+	return bans
+}
+
+// report adds the penalty to the peer score, and returns true if the score reached the ban threshold.
+// The score of the banned peer is reset
+func (r *reputation) report(id peer.ID, penalty PeerPenalty) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+
+	r.pruneScores(now)
+
+	score, ok := r.scores[id]
+	if !ok {
+		score = &peerScore{}
+		r.scores[id] = score
+	} else {
+		score.penalty = score.current(now)
+	}
+
+	score.penalty += float64(penalty)
+	score.updated = now
+
+	if score.penalty < BanThreshold {
+		return false
+	}
+
+	delete(r.scores, id)
+
+	return true
+}
+
+// score returns the current penalty score of the peer
+func (r *reputation) score(id peer.ID) float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	score, ok := r.scores[id]
+	if !ok {
+		return 0
+	}
+
+	return score.current(r.now())
+}
+
+// pruneScores removes the scores decayed to zero, at most once a minute,
+// so the scores of the peers which stopped misbehaving are not kept forever. Must be called under the lock
+func (r *reputation) pruneScores(now time.Time) {
+	if now.Sub(r.lastPrune) < time.Minute {
+		return
+	}
+
+	r.lastPrune = now
+
+	for id, score := range r.scores {
+		if score.current(now) == 0 {
+			delete(r.scores, id)
+		}
+	}
+}
+
+// ban bans the peer for the duration, extending its current ban if any
+func (r *reputation) ban(id peer.ID, duration time.Duration, reason string) (*BannedPeer, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ban := &BannedPeer{
+		ID:     id,
+		Until:  r.now().Add(duration).UTC(),
+		Reason: reason,
+	}
+
+	if current, ok := r.bans[id]; ok && current.Until.After(ban.Until) {
+		ban.Until = current.Until
+	}
+
+	r.bans[id] = ban
+	banCopy := *ban
+
+	return &banCopy, r.save()
+}
+
+// unban lifts the ban of the peer and resets its score
+func (r *reputation) unban(id peer.ID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ban, ok := r.bans[id]
+	if !ok || !ban.Until.After(r.now()) {
+		return ErrPeerNotBanned
+	}
+
+	delete(r.bans, id)
+	delete(r.scores, id)
+
+	return r.save()
+}
+
+// isBanned checks if the peer is banned
+func (r *reputation) isBanned(id peer.ID) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ban, ok := r.bans[id]
+
+	return ok && ban.Until.After(r.now())
+}
+
+// bannedPeers returns the active bans
+func (r *reputation) bannedPeers() []*BannedPeer {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	bans := r.activeBans()
+	result := make([]*BannedPeer, len(bans))
+
+	for i, ban := range bans {
+		banCopy := *ban
+		result[i] = &banCopy
+	}
+
+	return result
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// connectionGater refuses the connections to and from the banned peers
+type connectionGater struct {
+	reputation *reputation
+}
+
+var _ connmgr.ConnectionGater = (*connectionGater)(nil)
+
+// InterceptPeerDial refuses to dial the banned peers
+func (g *connectionGater) InterceptPeerDial(id peer.ID) bool {
+	return !g.reputation.isBanned(id)
+}
+
+// InterceptAddrDial refuses to dial the banned peers
+func (g *connectionGater) InterceptAddrDial(id peer.ID, _ multiaddr.Multiaddr) bool {
+	return !g.reputation.isBanned(id)
+}
+
+// InterceptAccept accepts all the inbound connections, the peer is not known until the handshake
+func (g *connectionGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured refuses the secured connections with the banned peers
+func (g *connectionGater) InterceptSecured(_ network.Direction, id peer.ID, _ network.ConnMultiaddrs) bool {
+	return !g.reputation.isBanned(id)
+}
+
+// InterceptUpgraded accepts all the upgraded connections, the banned peers are refused before
+func (g *connectionGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
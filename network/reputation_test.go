@@ -0,0 +1,215 @@
+package network
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestReputation creates the reputation tracker driven by the returned clock
+func newTestReputation(t *testing.T, dataDir string) (*reputation, *time.Time) {
+	t.Helper()
+
+	r, err := newReputation(hclog.NewNullLogger(), dataDir)
+	require.NoError(t, err)
+
+	now := time.Now()
+	r.now = func() time.Time {
+		return now
+	}
+
+	return r, &now
+}
+
+// newTestPeerID generates a random peer ID
+func newTestPeerID(t *testing.T) peer.ID {
+	t.Helper()
+
+	key, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestReputation_Report(t *testing.T) {
+	t.Parallel()
+
+	r, now := newTestReputation(t, "")
+	id := newTestPeerID(t)
+
+	assert.False(t, r.report(id, PenaltyInvalidBlock))
+	assert.Equal(t, float64(PenaltyInvalidBlock), r.score(id))
+
+	// the score decays over time
+	*now = now.Add(2 * time.Minute)
+
+	assert.Equal(t, float64(PenaltyInvalidBlock)-2*penaltyDecayPerMinute, r.score(id))
+	assert.False(t, r.report(id, PenaltyInvalidBlock))
+
+	// the score reaching the threshold bans the peer, and is reset
+	assert.True(t, r.report(id, PenaltyInvalidConsensusMessage))
+	assert.Equal(t, float64(0), r.score(id))
+
+	// the scores of the other peers are not affected
+	assert.Equal(t, float64(0), r.score(newTestPeerID(t)))
+}
+
+func TestReputation_PruneScores(t *testing.T) {
+	t.Parallel()
+
+	r, now := newTestReputation(t, "")
+	idle, active := newTestPeerID(t), newTestPeerID(t)
+
+	assert.False(t, r.report(idle, PenaltyInvalidTx))
+	assert.False(t, r.report(active, PenaltyInvalidBlock))
+
+	// the score of the idle peer decays to zero, the one of the active peer doesn't
+	*now = now.Add(time.Minute)
+
+	assert.False(t, r.report(active, PenaltyInvalidTx))
+	assert.Len(t, r.scores, 1)
+	assert.Contains(t, r.scores, active)
+
+	assert.Equal(t, float64(PenaltyInvalidBlock+PenaltyInvalidTx)-penaltyDecayPerMinute, r.score(active))
+}
+
+func TestReputation_Ban(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+
+	r, now := newTestReputation(t, dataDir)
+
+	idA, idB, idC := newTestPeerID(t), newTestPeerID(t), newTestPeerID(t)
+
+	_, err := r.ban(idA, time.Hour, "reason A")
+	require.NoError(t, err)
+
+	_, err = r.ban(idB, 2*time.Hour, "reason B")
+	require.NoError(t, err)
+
+	assert.True(t, r.isBanned(idA))
+	assert.False(t, r.isBanned(idC))
+
+	// the shorter ban doesn't shorten the current one
+	ban, err := r.ban(idB, time.Minute, "reason B")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Hour).UTC(), ban.Until)
+
+	assert.ErrorIs(t, r.unban(idC), ErrPeerNotBanned)
+	require.NoError(t, r.unban(idA))
+	assert.False(t, r.isBanned(idA))
+
+	// the bans are persisted
+	assert.FileExists(t, filepath.Join(dataDir, BannedPeersFile))
+
+	loaded, err := newReputation(hclog.NewNullLogger(), dataDir)
+	require.NoError(t, err)
+
+	bans := loaded.bannedPeers()
+	require.Len(t, bans, 1)
+	assert.Equal(t, idB, bans[0].ID)
+	assert.Equal(t, "reason B", bans[0].Reason)
+	assert.True(t, now.Add(2*time.Hour).Equal(bans[0].Until))
+
+	// the bans expire
+	*now = now.Add(2 * time.Hour)
+
+	assert.False(t, r.isBanned(idB))
+	assert.Empty(t, r.bannedPeers())
+	assert.ErrorIs(t, r.unban(idB), ErrPeerNotBanned)
+}
+
+func TestReputation_InvalidBanList(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, BannedPeersFile), []byte("invalid"), 0600))
+
+	_, err := newReputation(hclog.NewNullLogger(), dataDir)
+	assert.Error(t, err)
+}
+
+func TestBanPeer(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	require.NoError(t, JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout))
+
+	bannedID := servers[1].AddrInfo().ID
+
+	_, err := servers[0].BanPeer(servers[0].AddrInfo().ID, time.Hour, "self")
+	assert.ErrorIs(t, err, ErrBanSelf)
+
+	_, err = servers[0].BanPeer(bannedID, 0, "no duration")
+	assert.ErrorIs(t, err, ErrBanDuration)
+
+	// the banned peer is disconnected
+	ban, err := servers[0].BanPeer(bannedID, time.Hour, "misbehavior")
+	require.NoError(t, err)
+	assert.Equal(t, bannedID, ban.ID)
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer disconnectFn()
+
+	_, err = WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[0], bannedID)
+	require.NoError(t, err)
+
+	bans := servers[0].BannedPeers()
+	require.Len(t, bans, 1)
+	assert.Equal(t, bannedID, bans[0].ID)
+	assert.Equal(t, "misbehavior", bans[0].Reason)
+
+	// the connections with the banned peer are refused both ways
+	assert.Error(t, servers[0].host.Connect(context.Background(), *servers[1].AddrInfo()))
+
+	_ = servers[1].host.Connect(context.Background(), *servers[0].AddrInfo())
+
+	assert.Never(t, func() bool {
+		return servers[0].IsConnected(bannedID)
+	}, time.Second, 100*time.Millisecond)
+
+	// the unbanned peer is able to connect again
+	require.NoError(t, servers[0].UnbanPeer(bannedID))
+	assert.ErrorIs(t, servers[0].UnbanPeer(bannedID), ErrPeerNotBanned)
+
+	require.NoError(t, JoinAndWait(servers[1], servers[0], DefaultBufferTimeout, DefaultJoinTimeout))
+}
+
+func TestReportPeer(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	require.NoError(t, createErr)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	reportedID := servers[1].AddrInfo().ID
+
+	servers[0].ReportPeer(reportedID, PenaltyInvalidBlock, "invalid block")
+	servers[0].ReportPeer(reportedID, PenaltyInvalidConsensusMessage, "invalid consensus message")
+	assert.False(t, servers[0].IsBanned(reportedID))
+
+	// the reports of the local node are ignored
+	servers[0].ReportPeer(servers[0].AddrInfo().ID, PenaltyInvalidBlock, "invalid block")
+	servers[0].ReportPeer(servers[0].AddrInfo().ID, PenaltyInvalidBlock, "invalid block")
+	assert.Empty(t, servers[0].BannedPeers())
+
+	servers[0].ReportPeer(reportedID, PenaltyInvalidBlock, "invalid block")
+	assert.True(t, servers[0].IsBanned(reportedID))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/armon/go-metrics"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	rawGrpc "google.golang.org/grpc"
 
 	peerEvent "github.com/vishnushankarsg/metad/network/event"
@@ -88,6 +89,8 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	reputation *reputation // the penalty scores and the bans of the peers
 }
 
 // NewServer returns a new instance of the networking server
@@ -118,13 +121,28 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return addrs
 	}
 
-	host, err := libp2p.New(
+	reputation, err := newReputation(logger, config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	libp2pOptions := []libp2p.Option{
 		// Use noise as the encryption protocol
 		libp2p.Security(noise.ID, noise.New),
 		libp2p.ListenAddrs(listenAddr),
 		libp2p.AddrsFactory(addrsFactory),
 		libp2p.Identity(key),
-	)
+		// Refuse the connections with the banned peers
+		libp2p.ConnectionGater(&connectionGater{reputation: reputation}),
+	}
+
+	if _, ok := key.(*remoteLibp2pKey); ok {
+		// The QUIC transport derives its keys from the raw private key,
+		// which the remote signing service never exposes
+		libp2pOptions = append(libp2pOptions, libp2p.Transport(tcp.NewTCPTransport))
+	}
+
+	host, err := libp2p.New(libp2pOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p stack: %w", err)
 	}
@@ -145,6 +163,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		emitterPeerEvent: emitter,
 		protocols:        map[string]Protocol{},
 		secretsManager:   config.SecretsManager,
+		reputation:       reputation,
 		bootnodes: &bootnodesWrapper{
 			bootnodeArr:       make([]*peer.AddrInfo, 0),
 			bootnodesMap:      make(map[peer.ID]*peer.AddrInfo),
@@ -214,6 +233,16 @@ func (pci *PeerConnInfo) getProtocolStream(protocol string) *rawGrpc.ClientConn
 func setupLibp2pKey(secretsManager secrets.SecretsManager) (crypto.PrivKey, error) {
 	var key crypto.PrivKey
 
+	if remoteSigner, ok := secretsManager.(secrets.KeySigner); ok {
+		// The key is held by the remote signing service, sign by it
+		remoteKey, err := NewRemoteLibp2pKey(remoteSigner)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read networking public key from remote signer, %w", err)
+		}
+
+		return remoteKey, nil
+	}
+
 	if secretsManager.HasSecret(secrets.NetworkKey) {
 		// The key is present in the secrets manager, read it
 		networkingKey, readErr := ReadLibp2pKey(secretsManager)
@@ -413,6 +442,12 @@ func (s *Server) runDial() {
 
 			s.logger.Debug(fmt.Sprintf("Dialing peer [%s] as local [%s]", peerInfo.String(), s.host.ID()))
 
+			if s.reputation.isBanned(peerInfo.ID) {
+				s.logger.Debug("skipping dial to banned peer", "id", peerInfo.ID)
+
+				continue
+			}
+
 			if !s.IsConnected(peerInfo.ID) {
 				// the connection process is async because it involves connection (here) +
 				// the handshake done in the identity service.
@@ -0,0 +1,72 @@
+package network
+
+import (
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ReportPeer adds the penalty of the misbehavior to the peer score.
+// The peer reaching the ban threshold is banned for the default ban duration
+func (s *Server) ReportPeer(peerID peer.ID, penalty PeerPenalty, reason string) {
+	if peerID == "" || peerID == s.host.ID() {
+		return
+	}
+
+	s.logger.Debug("Peer reported", "id", peerID, "penalty", penalty, "reason", reason)
+
+	metrics.IncrCounter([]string{networkMetrics, "peer_reports"}, 1)
+
+	if !s.reputation.report(peerID, penalty) {
+		return
+	}
+
+	if _, err := s.BanPeer(peerID, DefaultBanDuration, reason); err != nil {
+		s.logger.Error("Unable to ban peer", "id", peerID, "err", err)
+	}
+}
+
+// BanPeer bans the peer for the duration and disconnects from it.
+// The connections with the banned peer are refused until the ban expires.
+// The ban is active even if it fails to be persisted
+func (s *Server) BanPeer(peerID peer.ID, duration time.Duration, reason string) (*BannedPeer, error) {
+	if peerID == s.host.ID() {
+		return nil, ErrBanSelf
+	}
+
+	if duration <= 0 {
+		return nil, ErrBanDuration
+	}
+
+	ban, err := s.reputation.ban(peerID, duration, reason)
+
+	s.logger.Warn("Peer banned", "id", peerID, "until", ban.Until, "reason", reason)
+
+	metrics.IncrCounter([]string{networkMetrics, "peer_bans"}, 1)
+
+	s.DisconnectFromPeer(peerID, reason)
+
+	return ban, err
+}
+
+// UnbanPeer lifts the ban of the peer
+func (s *Server) UnbanPeer(peerID peer.ID) error {
+	if err := s.reputation.unban(peerID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Peer unbanned", "id", peerID)
+
+	return nil
+}
+
+// IsBanned checks if the peer is banned
+func (s *Server) IsBanned(peerID peer.ID) bool {
+	return s.reputation.isBanned(peerID)
+}
+
+// BannedPeers returns the active bans
+func (s *Server) BannedPeers() []*BannedPeer {
+	return s.reputation.bannedPeers()
+}
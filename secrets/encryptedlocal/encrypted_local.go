@@ -0,0 +1,353 @@
+package encryptedlocal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo/keystore"
+	"golang.org/x/term"
+
+	"github.com/vishnushankarsg/metad/helper/common"
+	"github.com/vishnushankarsg/metad/secrets"
+)
+
+// KeystoreExt is the extension of the keystore files, appended to the names of the local secret files
+const KeystoreExt = ".json"
+
+var (
+	// scryptN is the scrypt CPU/memory cost of the keystore encryption
+	scryptN = 1 << 18
+
+	// scryptP is the scrypt parallelization of the keystore encryption
+	scryptP = 1
+)
+
+var (
+	ErrNoPassphrase = fmt.Errorf(
+		"no passphrase provided, use a passphrase file, the %s environment variable or an interactive terminal",
+		secrets.PassphraseEnv,
+	)
+)
+
+// localSecrets are the names of the secrets handled by the local SecretsManager
+var localSecrets = []string{
+	secrets.ValidatorKey,
+	secrets.ValidatorBLSKey,
+	secrets.ValidatorBLSSignature,
+	secrets.NetworkKey,
+}
+
+// EncryptedLocalSecretsManager is a SecretsManager that stores secrets locally on disk,
+// encrypted in the Web3 Secret Storage format (scrypt + AES-128-CTR)
+type EncryptedLocalSecretsManager struct {
+	// Logger object
+	logger hclog.Logger
+
+	// Path to the base working directory
+	path string
+
+	// Passphrase unlocking the keystores
+	passphrase string
+
+	// Map of known secrets and their keystore paths
+	secretPathMap map[string]string
+
+	// Map of the already decrypted secrets
+	decrypted map[string][]byte
+
+	// Mux for the secretPathMap and the decrypted secrets
+	lock sync.RWMutex
+}
+
+// SecretsManagerFactory implements the factory method.
+// The passphrase is taken from the params, the passphrase file of the config,
+// the environment variable or the interactive prompt, in this order
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	// Set up the base object
+	encryptedManager := &EncryptedLocalSecretsManager{
+		logger:        params.Logger.Named(string(secrets.EncryptedLocal)),
+		secretPathMap: make(map[string]string),
+		decrypted:     make(map[string][]byte),
+	}
+
+	// Grab the path to the working directory
+	path, ok := params.Extra[secrets.Path]
+	if !ok {
+		return nil, errors.New("no path specified for encrypted local secrets manager")
+	}
+
+	encryptedManager.path, ok = path.(string)
+	if !ok {
+		return nil, errors.New("invalid type assertion")
+	}
+
+	passphrase, err := ResolvePassphrase(config, params)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedManager.passphrase = passphrase
+
+	if err := encryptedManager.Setup(); err != nil {
+		return nil, err
+	}
+
+	return encryptedManager, nil
+}
+
+// ResolvePassphrase returns the passphrase from the params or reads it as configured
+func ResolvePassphrase(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (string, error) {
+	if raw, ok := params.Extra[secrets.Passphrase]; ok {
+		passphrase, ok := raw.(string)
+		if !ok {
+			return "", errors.New("invalid type assertion")
+		}
+
+		if passphrase == "" {
+			return "", ErrNoPassphrase
+		}
+
+		return passphrase, nil
+	}
+
+	var passphraseFile string
+
+	if config != nil {
+		if raw, ok := config.Extra[secrets.PassphraseFile]; ok {
+			if passphraseFile, ok = raw.(string); !ok {
+				return "", errors.New("invalid type assertion")
+			}
+		}
+	}
+
+	return ReadPassphrase(passphraseFile)
+}
+
+// ReadPassphrase reads the passphrase from the given file (if set), the environment variable
+// or the interactive prompt, in this order. The trailing new line of the file is ignored
+func ReadPassphrase(passphraseFile string) (string, error) {
+	var passphrase string
+
+	switch {
+	case passphraseFile != "":
+		raw, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("unable to read passphrase file, %w", err)
+		}
+
+		passphrase = strings.TrimRight(string(raw), "\r\n")
+	case os.Getenv(secrets.PassphraseEnv) != "":
+		passphrase = os.Getenv(secrets.PassphraseEnv)
+	case term.IsTerminal(int(os.Stdin.Fd())):
+		fmt.Fprint(os.Stderr, "Secrets passphrase: ")
+
+		raw, err := term.ReadPassword(int(os.Stdin.Fd()))
+
+		fmt.Fprintln(os.Stderr)
+
+		if err != nil {
+			return "", fmt.Errorf("unable to read passphrase, %w", err)
+		}
+
+		passphrase = string(raw)
+	}
+
+	if passphrase == "" {
+		return "", ErrNoPassphrase
+	}
+
+	return passphrase, nil
+}
+
+// Setup sets up the encrypted local SecretsManager
+func (e *EncryptedLocalSecretsManager) Setup() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	subDirectories := []string{secrets.ConsensusFolderLocal, secrets.NetworkFolderLocal}
+
+	// Set up the local directories
+	if err := common.SetupDataDir(e.path, subDirectories, 0770); err != nil {
+		return err
+	}
+
+	for name, path := range LocalSecretPaths(e.path) {
+		e.secretPathMap[name] = path + KeystoreExt
+	}
+
+	return nil
+}
+
+// LocalSecretPaths returns the paths of the plaintext secrets of the local SecretsManager
+func LocalSecretPaths(path string) map[string]string {
+	return map[string]string{
+		// baseDir/consensus/validator.key
+		secrets.ValidatorKey: filepath.Join(path, secrets.ConsensusFolderLocal, secrets.ValidatorKeyLocal),
+		// baseDir/consensus/validator-bls.key
+		secrets.ValidatorBLSKey: filepath.Join(path, secrets.ConsensusFolderLocal, secrets.ValidatorBLSKeyLocal),
+		// baseDir/consensus/validator.sig
+		secrets.ValidatorBLSSignature: filepath.Join(
+			path,
+			secrets.ConsensusFolderLocal,
+			secrets.ValidatorBLSSignatureLocal,
+		),
+		// baseDir/libp2p/libp2p.key
+		secrets.NetworkKey: filepath.Join(path, secrets.NetworkFolderLocal, secrets.NetworkKeyLocal),
+	}
+}
+
+// GetSecret decrypts the secret from its keystore on disk
+func (e *EncryptedLocalSecretsManager) GetSecret(name string) ([]byte, error) {
+	e.lock.RLock()
+	secretPath, ok := e.secretPathMap[name]
+	secret, decrypted := e.decrypted[name]
+	e.lock.RUnlock()
+
+	if !ok {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	if decrypted {
+		return secret, nil
+	}
+
+	// Read the keystore from disk
+	encrypted, err := os.ReadFile(secretPath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to read secret from disk (%s), %w",
+			secretPath,
+			err,
+		)
+	}
+
+	secret, err = keystore.DecryptV3(encrypted, e.passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt secret (%s), %w", secretPath, err)
+	}
+
+	e.lock.Lock()
+	e.decrypted[name] = secret
+	e.lock.Unlock()
+
+	return secret, nil
+}
+
+// SetSecret encrypts the secret and saves its keystore to disk
+func (e *EncryptedLocalSecretsManager) SetSecret(name string, value []byte) error {
+	e.lock.RLock()
+	secretPath, ok := e.secretPathMap[name]
+	e.lock.RUnlock()
+
+	if !ok {
+		return secrets.ErrSecretNotFound
+	}
+
+	// Checks for existing secret
+	if _, err := os.Stat(secretPath); err == nil {
+		return fmt.Errorf(
+			"%s already initialized",
+			secretPath,
+		)
+	}
+
+	encrypted, err := keystore.EncryptV3(value, e.passphrase, scryptN, scryptP)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt secret, %w", err)
+	}
+
+	// Write the keystore to disk
+	if err := common.SaveFileSafe(secretPath, encrypted, 0440); err != nil {
+		return fmt.Errorf(
+			"unable to write secret to disk (%s), %w",
+			secretPath,
+			err,
+		)
+	}
+
+	e.lock.Lock()
+	e.decrypted[name] = value
+	e.lock.Unlock()
+
+	return nil
+}
+
+// HasSecret checks if the keystore of the secret is present on disk
+func (e *EncryptedLocalSecretsManager) HasSecret(name string) bool {
+	e.lock.RLock()
+	secretPath, ok := e.secretPathMap[name]
+	e.lock.RUnlock()
+
+	return ok && common.FileExists(secretPath)
+}
+
+// RemoveSecret removes the keystore of the secret from disk
+func (e *EncryptedLocalSecretsManager) RemoveSecret(name string) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	secretPath, ok := e.secretPathMap[name]
+	if !ok {
+		return secrets.ErrSecretNotFound
+	}
+
+	delete(e.secretPathMap, name)
+	delete(e.decrypted, name)
+
+	if removeErr := os.Remove(secretPath); removeErr != nil {
+		return fmt.Errorf("unable to remove secret, %w", removeErr)
+	}
+
+	return nil
+}
+
+// MigrateLocalSecrets encrypts the plaintext secrets of the local SecretsManager, stored in the same
+// base working directory, and removes the plaintext files. Returns the names of the migrated secrets
+func (e *EncryptedLocalSecretsManager) MigrateLocalSecrets() ([]string, error) {
+	plaintextPaths := LocalSecretPaths(e.path)
+	migrated := make([]string, 0, len(localSecrets))
+
+	for _, name := range localSecrets {
+		plaintextPath := plaintextPaths[name]
+		if !common.FileExists(plaintextPath) {
+			continue
+		}
+
+		value, err := os.ReadFile(plaintextPath)
+		if err != nil {
+			return migrated, fmt.Errorf("unable to read secret from disk (%s), %w", plaintextPath, err)
+		}
+
+		if err := e.SetSecret(name, value); err != nil {
+			return migrated, err
+		}
+
+		// make sure the keystore is readable before removing the plaintext secret
+		e.lock.Lock()
+		delete(e.decrypted, name)
+		e.lock.Unlock()
+
+		if _, err := e.GetSecret(name); err != nil {
+			return migrated, err
+		}
+
+		if err := os.Remove(plaintextPath); err != nil {
+			return migrated, fmt.Errorf("unable to remove plaintext secret (%s), %w", plaintextPath, err)
+		}
+
+		migrated = append(migrated, name)
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,218 @@
+package encryptedlocal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/helper/common"
+	"github.com/vishnushankarsg/metad/secrets"
+)
+
+const testPassphrase = "passphrase"
+
+func init() {
+	// keep the key derivation cheap in tests
+	scryptN = 1 << 12
+}
+
+func newTestManager(t *testing.T, path, passphrase string) *EncryptedLocalSecretsManager {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra: map[string]interface{}{
+			secrets.Path:       path,
+			secrets.Passphrase: passphrase,
+		},
+	})
+	require.NoError(t, err)
+
+	encryptedManager, ok := manager.(*EncryptedLocalSecretsManager)
+	require.True(t, ok)
+
+	return encryptedManager
+}
+
+func TestEncryptedLocalSecretsManagerFactory(t *testing.T) {
+	workingDirectory := t.TempDir()
+
+	passphraseFile := filepath.Join(workingDirectory, "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte(testPassphrase+"\n"), 0600))
+
+	testTable := []struct {
+		name          string
+		config        *secrets.SecretsManagerConfig
+		params        *secrets.SecretsManagerParams
+		shouldSucceed bool
+	}{
+		{
+			"Valid configuration with path and passphrase",
+			nil,
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Path:       workingDirectory,
+					secrets.Passphrase: testPassphrase,
+				},
+			},
+			true,
+		},
+		{
+			"Valid configuration with path and passphrase file",
+			&secrets.SecretsManagerConfig{
+				Type: secrets.EncryptedLocal,
+				Extra: map[string]interface{}{
+					secrets.PassphraseFile: passphraseFile,
+				},
+			},
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Path: workingDirectory,
+				},
+			},
+			true,
+		},
+		{
+			"Invalid configuration without path info",
+			nil,
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Passphrase: testPassphrase,
+				},
+			},
+			false,
+		},
+		{
+			"Invalid configuration with empty passphrase",
+			nil,
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Path:       workingDirectory,
+					secrets.Passphrase: "",
+				},
+			},
+			false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			manager, err := SecretsManagerFactory(testCase.config, testCase.params)
+			if testCase.shouldSucceed {
+				assert.NotNil(t, manager)
+				assert.NoError(t, err)
+			} else {
+				assert.Nil(t, manager)
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestEncryptedLocalSecretsManager_SetGetSecret(t *testing.T) {
+	workingDirectory := t.TempDir()
+	value := []byte("secret value")
+
+	manager := newTestManager(t, workingDirectory, testPassphrase)
+
+	assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, value))
+	assert.True(t, manager.HasSecret(secrets.ValidatorKey))
+
+	// the secret can't be overwritten
+	assert.Error(t, manager.SetSecret(secrets.ValidatorKey, value))
+
+	// the value is not stored in plaintext
+	keystorePath := filepath.Join(
+		workingDirectory,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorKeyLocal+KeystoreExt,
+	)
+
+	encrypted, err := os.ReadFile(keystorePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), string(value))
+
+	// a new manager decrypts the keystore from disk
+	secret, err := newTestManager(t, workingDirectory, testPassphrase).GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, value, secret)
+
+	// the wrong passphrase can't decrypt the keystore
+	_, err = newTestManager(t, workingDirectory, "wrong").GetSecret(secrets.ValidatorKey)
+	assert.Error(t, err)
+
+	_, err = manager.GetSecret("unknown")
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	require.NoError(t, manager.RemoveSecret(secrets.ValidatorKey))
+	assert.False(t, common.FileExists(keystorePath))
+}
+
+func TestReadPassphrase(t *testing.T) {
+	passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte(testPassphrase+"\r\n"), 0600))
+
+	t.Setenv(secrets.PassphraseEnv, "from env")
+
+	// the file takes precedence over the environment variable
+	passphrase, err := ReadPassphrase(passphraseFile)
+	require.NoError(t, err)
+	assert.Equal(t, testPassphrase, passphrase)
+
+	passphrase, err = ReadPassphrase("")
+	require.NoError(t, err)
+	assert.Equal(t, "from env", passphrase)
+
+	_, err = ReadPassphrase(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	emptyFile := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("\n"), 0600))
+
+	_, err = ReadPassphrase(emptyFile)
+	assert.ErrorIs(t, err, ErrNoPassphrase)
+}
+
+func TestEncryptedLocalSecretsManager_MigrateLocalSecrets(t *testing.T) {
+	workingDirectory := t.TempDir()
+
+	plaintext := map[string][]byte{
+		secrets.ValidatorKey: []byte("validator key"),
+		secrets.NetworkKey:   []byte("network key"),
+	}
+
+	manager := newTestManager(t, workingDirectory, testPassphrase)
+	plaintextPaths := LocalSecretPaths(workingDirectory)
+
+	for name, value := range plaintext {
+		require.NoError(t, os.WriteFile(plaintextPaths[name], value, 0440))
+	}
+
+	migrated, err := manager.MigrateLocalSecrets()
+	require.NoError(t, err)
+	assert.Equal(t, []string{secrets.ValidatorKey, secrets.NetworkKey}, migrated)
+
+	reopened := newTestManager(t, workingDirectory, testPassphrase)
+
+	for name, value := range plaintext {
+		assert.False(t, common.FileExists(plaintextPaths[name]))
+
+		secret, err := reopened.GetSecret(name)
+		require.NoError(t, err)
+		assert.Equal(t, value, secret)
+	}
+
+	// nothing is left to migrate
+	migrated, err = reopened.MigrateLocalSecrets()
+	require.NoError(t, err)
+	assert.Empty(t, migrated)
+}
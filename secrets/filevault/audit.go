@@ -0,0 +1,232 @@
+package filevault
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/vishnushankarsg/metad/types"
+)
+
+var (
+	ErrAuditLogTampered = errors.New("audit log has been tampered with")
+)
+
+// AuditEntry is a record of the audit log. Each entry is chained to the previous one
+// by its hash, an HMAC keyed by the vault passphrase
+type AuditEntry struct {
+	Sequence  uint64     `json:"sequence"`
+	Time      time.Time  `json:"time"`
+	Caller    string     `json:"caller"`
+	Operation string     `json:"operation"`
+	Name      string     `json:"name"`
+	Error     string     `json:"error,omitempty"`
+	PrevHash  types.Hash `json:"prevHash"`
+	Hash      types.Hash `json:"hash"`
+}
+
+// AuditReport is the summary of the verified audit log
+type AuditReport struct {
+	Entries uint64
+	Head    types.Hash
+	First   *AuditEntry
+	Last    *AuditEntry
+}
+
+// auditHead is the sequence number and the hash of the last audit log entry,
+// authenticated by an HMAC keyed by the vault passphrase, so the last entries can't be cut off
+// along with the head. Replacing the whole vault file by its older copy is not detected
+type auditHead struct {
+	Sequence uint64     `json:"sequence"`
+	Hash     types.Hash `json:"hash"`
+	MAC      types.Hash `json:"mac"`
+}
+
+// computeMAC computes the HMAC of the head, covering its sequence number and hash
+func (h *auditHead) computeMAC(key []byte) types.Hash {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("head"))
+	mac.Write(sequenceKey(h.Sequence))
+	mac.Write(h.Hash.Bytes())
+
+	return types.BytesToHash(mac.Sum(nil))
+}
+
+// computeHash computes the hash of the entry, covering all its fields but the hash itself
+func (e *AuditEntry) computeHash(key []byte) (types.Hash, error) {
+	unhashed := *e
+	unhashed.Hash = types.ZeroHash
+
+	raw, err := json.Marshal(&unhashed)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(raw)
+
+	return types.BytesToHash(mac.Sum(nil)), nil
+}
+
+// appendAuditEntry chains the entry to the audit log head and appends it to the audit log
+func (f *FileVaultSecretsManager) appendAuditEntry(tx *bolt.Tx, entry *AuditEntry) error {
+	head, err := f.readAuditHead(tx)
+	if err != nil {
+		return err
+	}
+
+	entry.Sequence = head.Sequence + 1
+	entry.PrevHash = head.Hash
+
+	if entry.Hash, err = entry.computeHash(f.auditKey); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Bucket(auditBucket).Put(sequenceKey(entry.Sequence), raw); err != nil {
+		return err
+	}
+
+	head = &auditHead{Sequence: entry.Sequence, Hash: entry.Hash}
+	head.MAC = head.computeMAC(f.auditKey)
+
+	rawHead, err := json.Marshal(head)
+	if err != nil {
+		return err
+	}
+
+	return tx.Bucket(metaBucket).Put(headKey, rawHead)
+}
+
+// readAuditHead reads the audit log head and checks its HMAC, the head is empty for the empty audit log
+func (f *FileVaultSecretsManager) readAuditHead(tx *bolt.Tx) (*auditHead, error) {
+	head := &auditHead{}
+
+	raw := tx.Bucket(metaBucket).Get(headKey)
+	if raw == nil {
+		return head, nil
+	}
+
+	if err := json.Unmarshal(raw, head); err != nil {
+		return nil, fmt.Errorf("%w: invalid head, %v", ErrAuditLogTampered, err)
+	}
+
+	if !hmac.Equal(head.MAC.Bytes(), head.computeMAC(f.auditKey).Bytes()) {
+		return nil, fmt.Errorf("%w: head has been modified", ErrAuditLogTampered)
+	}
+
+	return head, nil
+}
+
+// AuditLog returns the entries of the audit log, without verifying them
+func (f *FileVaultSecretsManager) AuditLog() ([]*AuditEntry, error) {
+	var entries []*AuditEntry
+
+	err := f.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(auditBucket).ForEach(func(_, raw []byte) error {
+			entry := &AuditEntry{}
+			if err := json.Unmarshal(raw, entry); err != nil {
+				return err
+			}
+
+			entries = append(entries, entry)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// VerifyAuditLog verifies the audit log is complete and none of its entries has been modified:
+// the sequence numbers are consecutive, every entry is chained to the previous one,
+// its hash matches its content and the last entry is the recorded head, whose HMAC matches.
+// Rolling back the whole vault file to its older copy is out of scope, as the older copy is consistent
+func (f *FileVaultSecretsManager) VerifyAuditLog() (*AuditReport, error) {
+	report := &AuditReport{}
+
+	err := f.db.View(func(tx *bolt.Tx) error {
+		head, err := f.readAuditHead(tx)
+		if err != nil {
+			return err
+		}
+
+		var prev *AuditEntry
+
+		err = tx.Bucket(auditBucket).ForEach(func(key, raw []byte) error {
+			entry := &AuditEntry{}
+			if err := json.Unmarshal(raw, entry); err != nil {
+				return fmt.Errorf("%w: invalid entry %d, %v", ErrAuditLogTampered, report.Entries+1, err)
+			}
+
+			expectedSequence, prevHash := uint64(1), types.ZeroHash
+			if prev != nil {
+				expectedSequence, prevHash = prev.Sequence+1, prev.Hash
+			}
+
+			if entry.Sequence != expectedSequence || len(key) != 8 || binary.BigEndian.Uint64(key) != expectedSequence {
+				return fmt.Errorf("%w: entry %d is missing", ErrAuditLogTampered, expectedSequence)
+			}
+
+			if entry.PrevHash != prevHash {
+				return fmt.Errorf("%w: entry %d is not chained to the previous entry", ErrAuditLogTampered, entry.Sequence)
+			}
+
+			hash, err := entry.computeHash(f.auditKey)
+			if err != nil {
+				return err
+			}
+
+			if entry.Hash != hash {
+				return fmt.Errorf("%w: entry %d has been modified", ErrAuditLogTampered, entry.Sequence)
+			}
+
+			if report.First == nil {
+				report.First = entry
+			}
+
+			report.Entries++
+			prev = entry
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if report.Entries != head.Sequence || (prev != nil && prev.Hash != head.Hash) {
+			return fmt.Errorf("%w: the last entries are missing", ErrAuditLogTampered)
+		}
+
+		report.Last = prev
+		report.Head = head.Hash
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// sequenceKey returns the key of the audit log entry, keeping the entries ordered by their sequence numbers
+func sequenceKey(sequence uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, sequence)
+
+	return key
+}
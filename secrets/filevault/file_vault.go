@@ -0,0 +1,386 @@
+package filevault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/vishnushankarsg/metad/secrets/encryptedlocal"
+)
+
+const (
+	// VaultFile is the config Extra key of the path to the vault file
+	VaultFile = "vault-file"
+
+	// DefaultVaultFileName is the name of the vault file in the base working directory,
+	// used if the vault file is not configured
+	DefaultVaultFileName = "secrets.vault"
+)
+
+var (
+	// scryptN is the scrypt CPU/memory cost of the vault key derivation
+	scryptN = 1 << 18
+
+	// scryptR is the scrypt block size of the vault key derivation
+	scryptR = 8
+
+	// scryptP is the scrypt parallelization of the vault key derivation
+	scryptP = 1
+)
+
+var (
+	// bucket holding the encrypted secrets, by their names
+	secretsBucket = []byte("secrets")
+
+	// bucket holding the audit log entries, by their sequence numbers
+	auditBucket = []byte("audit")
+
+	// bucket holding the key derivation salt, the passphrase check and the audit log head
+	metaBucket = []byte("meta")
+
+	saltKey  = []byte("salt")
+	checkKey = []byte("check")
+	headKey  = []byte("head")
+
+	// checkValue is encrypted into the vault, so a wrong passphrase is detected on opening
+	checkValue = []byte("file-vault")
+)
+
+var (
+	ErrWrongPassphrase = errors.New("wrong passphrase for the vault file")
+)
+
+// Define the audited operations
+const (
+	OperationGet    = "get"
+	OperationSet    = "set"
+	OperationRemove = "remove"
+)
+
+// FileVaultSecretsManager is a SecretsManager that stores secrets in a local vault file,
+// encrypted by a passphrase (scrypt + AES-256-GCM). Every access to the secrets
+// is recorded in the tamper-evident audit log kept in the same file
+type FileVaultSecretsManager struct {
+	// Logger object
+	logger hclog.Logger
+
+	// Path to the vault file
+	path string
+
+	// The vault file
+	db *bolt.DB
+
+	// The cipher of the secrets
+	aead cipher.AEAD
+
+	// The key authenticating the audit log entries
+	auditKey []byte
+
+	// The caller recorded in the audit log
+	caller string
+}
+
+// SecretsManagerFactory implements the factory method.
+// The vault file is taken from the config, or placed in the base working directory of the params
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	vaultManager := &FileVaultSecretsManager{
+		logger: params.Logger.Named(string(secrets.FileVault)),
+		caller: defaultCaller(config),
+	}
+
+	path, err := vaultFilePath(config, params)
+	if err != nil {
+		return nil, err
+	}
+
+	vaultManager.path = path
+
+	passphrase, err := encryptedlocal.ResolvePassphrase(config, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := vaultManager.open(passphrase); err != nil {
+		return nil, err
+	}
+
+	return vaultManager, nil
+}
+
+// vaultFilePath returns the path to the vault file from the config or the params
+func vaultFilePath(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (string, error) {
+	if config != nil {
+		if raw, ok := config.Extra[VaultFile]; ok {
+			path, ok := raw.(string)
+			if !ok {
+				return "", errors.New("invalid type assertion")
+			}
+
+			return path, nil
+		}
+	}
+
+	if raw, ok := params.Extra[secrets.Path]; ok {
+		path, ok := raw.(string)
+		if !ok {
+			return "", errors.New("invalid type assertion")
+		}
+
+		return filepath.Join(path, DefaultVaultFileName), nil
+	}
+
+	return "", errors.New("no vault file specified for file vault secrets manager")
+}
+
+// defaultCaller identifies the process accessing the vault in the audit log,
+// by the OS user, the executable and its process ID, and the node name if configured
+func defaultCaller(config *secrets.SecretsManagerConfig) string {
+	username := "unknown"
+	if current, err := user.Current(); err == nil {
+		username = current.Username
+	}
+
+	executable := "unknown"
+	if path, err := os.Executable(); err == nil {
+		executable = filepath.Base(path)
+	}
+
+	caller := fmt.Sprintf("%s (%s, pid %d)", username, executable, os.Getpid())
+
+	if config != nil && config.Name != "" {
+		caller = fmt.Sprintf("%s: %s", config.Name, caller)
+	}
+
+	return caller
+}
+
+// open opens the vault file, initializing it on the first use, and derives the vault keys from the passphrase
+func (f *FileVaultSecretsManager) open(passphrase string) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0770); err != nil {
+		return fmt.Errorf("unable to create vault directory, %w", err)
+	}
+
+	db, err := bolt.Open(f.path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return fmt.Errorf("unable to open vault file (%s), is it used by another process? %w", f.path, err)
+	}
+
+	if err := f.setupKeys(db, passphrase); err != nil {
+		_ = db.Close()
+
+		return err
+	}
+
+	f.db = db
+
+	return nil
+}
+
+// setupKeys derives the vault keys from the passphrase, and checks the passphrase opens the vault
+func (f *FileVaultSecretsManager) setupKeys(db *bolt.DB, passphrase string) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{secretsBucket, auditBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+
+		meta := tx.Bucket(metaBucket)
+
+		salt := meta.Get(saltKey)
+		isNew := salt == nil
+
+		if isNew {
+			salt = make([]byte, 32)
+			if _, err := rand.Read(salt); err != nil {
+				return err
+			}
+
+			if err := meta.Put(saltKey, salt); err != nil {
+				return err
+			}
+		}
+
+		derived, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 64)
+		if err != nil {
+			return fmt.Errorf("unable to derive vault key, %w", err)
+		}
+
+		block, err := aes.NewCipher(derived[:32])
+		if err != nil {
+			return err
+		}
+
+		if f.aead, err = cipher.NewGCM(block); err != nil {
+			return err
+		}
+
+		f.auditKey = derived[32:]
+
+		if isNew {
+			check, err := f.encrypt(checkValue, checkKey)
+			if err != nil {
+				return err
+			}
+
+			return meta.Put(checkKey, check)
+		}
+
+		if _, err := f.decrypt(meta.Get(checkKey), checkKey); err != nil {
+			return ErrWrongPassphrase
+		}
+
+		return nil
+	})
+}
+
+// Setup is a no-op, the vault file is set up on opening
+func (f *FileVaultSecretsManager) Setup() error {
+	return nil
+}
+
+// Path returns the path to the vault file
+func (f *FileVaultSecretsManager) Path() string {
+	return f.path
+}
+
+// Close closes the vault file
+func (f *FileVaultSecretsManager) Close() error {
+	return f.db.Close()
+}
+
+// GetSecret decrypts the secret from the vault
+func (f *FileVaultSecretsManager) GetSecret(name string) ([]byte, error) {
+	var secret []byte
+
+	err := f.audited(OperationGet, name, func(tx *bolt.Tx) error {
+		encrypted := tx.Bucket(secretsBucket).Get([]byte(name))
+		if encrypted == nil {
+			return secrets.ErrSecretNotFound
+		}
+
+		decrypted, err := f.decrypt(encrypted, []byte(name))
+		if err != nil {
+			return fmt.Errorf("unable to decrypt secret (%s), %w", name, err)
+		}
+
+		secret = decrypted
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// SetSecret encrypts the secret and stores it in the vault
+func (f *FileVaultSecretsManager) SetSecret(name string, value []byte) error {
+	return f.audited(OperationSet, name, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(secretsBucket)
+
+		// Checks for existing secret
+		if bucket.Get([]byte(name)) != nil {
+			return fmt.Errorf("%s already initialized", name)
+		}
+
+		encrypted, err := f.encrypt(value, []byte(name))
+		if err != nil {
+			return fmt.Errorf("unable to encrypt secret, %w", err)
+		}
+
+		return bucket.Put([]byte(name), encrypted)
+	})
+}
+
+// HasSecret checks if the secret is present in the vault
+func (f *FileVaultSecretsManager) HasSecret(name string) bool {
+	found := false
+
+	_ = f.db.View(func(tx *bolt.Tx) error {
+		found = tx.Bucket(secretsBucket).Get([]byte(name)) != nil
+
+		return nil
+	})
+
+	return found
+}
+
+// RemoveSecret removes the secret from the vault
+func (f *FileVaultSecretsManager) RemoveSecret(name string) error {
+	return f.audited(OperationRemove, name, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(secretsBucket)
+		if bucket.Get([]byte(name)) == nil {
+			return secrets.ErrSecretNotFound
+		}
+
+		return bucket.Delete([]byte(name))
+	})
+}
+
+// audited runs the operation on the secret and appends its record to the audit log,
+// in the same transaction. The record is written even if the operation fails,
+// the operations change the secrets only once they can't fail anymore
+func (f *FileVaultSecretsManager) audited(operation, name string, fn func(tx *bolt.Tx) error) error {
+	var opErr error
+
+	err := f.db.Update(func(tx *bolt.Tx) error {
+		opErr = fn(tx)
+
+		entry := &AuditEntry{
+			Time:      time.Now().UTC(),
+			Caller:    f.caller,
+			Operation: operation,
+			Name:      name,
+		}
+
+		if opErr != nil {
+			entry.Error = opErr.Error()
+		}
+
+		return f.appendAuditEntry(tx, entry)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write audit log, %w", err)
+	}
+
+	return opErr
+}
+
+// encrypt encrypts the value, bound to the additional data, the nonce is prepended to the ciphertext
+func (f *FileVaultSecretsManager) encrypt(value, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, f.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return f.aead.Seal(nonce, nonce, value, additionalData), nil
+}
+
+// decrypt decrypts the value encrypted by encrypt
+func (f *FileVaultSecretsManager) decrypt(encrypted, additionalData []byte) ([]byte, error) {
+	nonceSize := f.aead.NonceSize()
+	if len(encrypted) < nonceSize {
+		return nil, errors.New("invalid ciphertext")
+	}
+
+	return f.aead.Open(nil, encrypted[:nonceSize], encrypted[nonceSize:], additionalData)
+}
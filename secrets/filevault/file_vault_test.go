@@ -0,0 +1,290 @@
+package filevault
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/vishnushankarsg/metad/secrets"
+)
+
+const testPassphrase = "passphrase"
+
+func init() {
+	// keep the key derivation cheap in tests
+	scryptN = 1 << 12
+}
+
+func newTestManager(t *testing.T, path, passphrase string) (*FileVaultSecretsManager, error) {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{
+			Type: secrets.FileVault,
+			Name: "node",
+			Extra: map[string]interface{}{
+				VaultFile: path,
+			},
+		},
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra: map[string]interface{}{
+				secrets.Passphrase: passphrase,
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vaultManager, ok := manager.(*FileVaultSecretsManager)
+	require.True(t, ok)
+
+	return vaultManager, nil
+}
+
+// tamper modifies the closed vault file
+func tamper(t *testing.T, path string, fn func(tx *bolt.Tx) error) {
+	t.Helper()
+
+	db, err := bolt.Open(path, 0600, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Update(fn))
+	require.NoError(t, db.Close())
+}
+
+func TestFileVaultSecretsManagerFactory(t *testing.T) {
+	workingDirectory := t.TempDir()
+
+	// the vault file defaults to the base working directory
+	manager, err := SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra: map[string]interface{}{
+			secrets.Path:       workingDirectory,
+			secrets.Passphrase: testPassphrase,
+		},
+	})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(workingDirectory, DefaultVaultFileName))
+
+	// the vault file is locked by the open manager
+	_, err = newTestManager(t, filepath.Join(workingDirectory, DefaultVaultFileName), testPassphrase)
+	assert.Error(t, err)
+
+	require.NoError(t, manager.(*FileVaultSecretsManager).Close()) //nolint:forcetypeassert
+
+	// the wrong passphrase can't open the vault
+	_, err = newTestManager(t, filepath.Join(workingDirectory, DefaultVaultFileName), "wrong")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	_, err = SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra: map[string]interface{}{
+			secrets.Passphrase: testPassphrase,
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestFileVaultSecretsManager_SetGetRemoveSecret(t *testing.T) {
+	vaultFile := filepath.Join(t.TempDir(), "vault", "secrets.vault")
+	value := []byte("secret value")
+
+	manager, err := newTestManager(t, vaultFile, testPassphrase)
+	require.NoError(t, err)
+
+	assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, value))
+	assert.True(t, manager.HasSecret(secrets.ValidatorKey))
+
+	// the secret can't be overwritten
+	assert.Error(t, manager.SetSecret(secrets.ValidatorKey, []byte("other value")))
+
+	_, err = manager.GetSecret(secrets.NetworkKey)
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	require.NoError(t, manager.Close())
+
+	// the value is not stored in plaintext
+	raw, err := os.ReadFile(vaultFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), string(value))
+
+	// the reopened vault decrypts the secret
+	manager, err = newTestManager(t, vaultFile, testPassphrase)
+	require.NoError(t, err)
+
+	secret, err := manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, value, secret)
+
+	require.NoError(t, manager.RemoveSecret(secrets.ValidatorKey))
+	assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+	assert.ErrorIs(t, manager.RemoveSecret(secrets.ValidatorKey), secrets.ErrSecretNotFound)
+
+	// every access is audited, the failed ones too
+	entries, err := manager.AuditLog()
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+
+	expected := []struct {
+		operation string
+		failed    bool
+	}{
+		{OperationSet, false},
+		{OperationSet, true},
+		{OperationGet, true},
+		{OperationGet, false},
+		{OperationRemove, false},
+		{OperationRemove, true},
+	}
+
+	for i, entry := range entries {
+		assert.Equal(t, uint64(i+1), entry.Sequence)
+		assert.Equal(t, expected[i].operation, entry.Operation)
+		assert.Equal(t, expected[i].failed, entry.Error != "")
+		assert.Contains(t, entry.Caller, "node: ")
+		assert.False(t, entry.Time.IsZero())
+	}
+
+	assert.Equal(t, secrets.NetworkKey, entries[2].Name)
+
+	report, err := manager.VerifyAuditLog()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), report.Entries)
+	assert.Equal(t, entries[0], report.First)
+	assert.Equal(t, entries[5], report.Last)
+	assert.Equal(t, entries[5].Hash, report.Head)
+}
+
+func TestFileVaultSecretsManager_VerifyAuditLog_Tampered(t *testing.T) {
+	setup := func(t *testing.T) string {
+		t.Helper()
+
+		vaultFile := filepath.Join(t.TempDir(), DefaultVaultFileName)
+
+		manager, err := newTestManager(t, vaultFile, testPassphrase)
+		require.NoError(t, err)
+
+		require.NoError(t, manager.SetSecret(secrets.ValidatorKey, []byte("value")))
+
+		for i := 0; i < 3; i++ {
+			_, err = manager.GetSecret(secrets.ValidatorKey)
+			require.NoError(t, err)
+		}
+
+		report, err := manager.VerifyAuditLog()
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), report.Entries)
+
+		require.NoError(t, manager.Close())
+
+		return vaultFile
+	}
+
+	verify := func(t *testing.T, vaultFile string) error {
+		t.Helper()
+
+		manager, err := newTestManager(t, vaultFile, testPassphrase)
+		require.NoError(t, err)
+
+		defer manager.Close()
+
+		_, err = manager.VerifyAuditLog()
+
+		return err
+	}
+
+	cases := []struct {
+		name   string
+		tamper func(tx *bolt.Tx) error
+	}{
+		{
+			"modified entry",
+			func(tx *bolt.Tx) error {
+				bucket := tx.Bucket(auditBucket)
+
+				entry := &AuditEntry{}
+				if err := json.Unmarshal(bucket.Get(sequenceKey(2)), entry); err != nil {
+					return err
+				}
+
+				entry.Caller = "someone else"
+
+				raw, err := json.Marshal(entry)
+				if err != nil {
+					return err
+				}
+
+				return bucket.Put(sequenceKey(2), raw)
+			},
+		},
+		{
+			"removed entry",
+			func(tx *bolt.Tx) error {
+				return tx.Bucket(auditBucket).Delete(sequenceKey(2))
+			},
+		},
+		{
+			"removed last entry",
+			func(tx *bolt.Tx) error {
+				return tx.Bucket(auditBucket).Delete(sequenceKey(4))
+			},
+		},
+		{
+			"truncated tail with rewritten head",
+			func(tx *bolt.Tx) error {
+				bucket := tx.Bucket(auditBucket)
+
+				entry := &AuditEntry{}
+				if err := json.Unmarshal(bucket.Get(sequenceKey(2)), entry); err != nil {
+					return err
+				}
+
+				for _, sequence := range []uint64{3, 4} {
+					if err := bucket.Delete(sequenceKey(sequence)); err != nil {
+						return err
+					}
+				}
+
+				// the head is consistent with the remaining entries, but its HMAC can't be recomputed
+				head := &auditHead{}
+				if err := json.Unmarshal(tx.Bucket(metaBucket).Get(headKey), head); err != nil {
+					return err
+				}
+
+				head.Sequence, head.Hash = entry.Sequence, entry.Hash
+
+				raw, err := json.Marshal(head)
+				if err != nil {
+					return err
+				}
+
+				return tx.Bucket(metaBucket).Put(headKey, raw)
+			},
+		},
+		{
+			"removed head",
+			func(tx *bolt.Tx) error {
+				return tx.Bucket(metaBucket).Delete(headKey)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			vaultFile := setup(t)
+			tamper(t, vaultFile, c.tamper)
+
+			assert.ErrorIs(t, verify(t, vaultFile), ErrAuditLogTampered)
+		})
+	}
+}
@@ -12,9 +12,12 @@ import (
 	"github.com/vishnushankarsg/metad/network"
 	"github.com/vishnushankarsg/metad/secrets"
 	"github.com/vishnushankarsg/metad/secrets/awsssm"
+	"github.com/vishnushankarsg/metad/secrets/encryptedlocal"
+	"github.com/vishnushankarsg/metad/secrets/filevault"
 	"github.com/vishnushankarsg/metad/secrets/gcpssm"
 	"github.com/vishnushankarsg/metad/secrets/hashicorpvault"
 	"github.com/vishnushankarsg/metad/secrets/local"
+	"github.com/vishnushankarsg/metad/secrets/remotesigner"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/hashicorp/go-hclog"
 	libp2pCrypto "github.com/libp2p/go-libp2p/core/crypto"
@@ -35,6 +38,20 @@ func SetupLocalSecretsManager(dataDir string) (secrets.SecretsManager, error) {
 	)
 }
 
+// SetupEncryptedLocalSecretsManager is a helper method for boilerplate encrypted local secrets manager setup
+func SetupEncryptedLocalSecretsManager(dataDir, passphrase string) (secrets.SecretsManager, error) {
+	return encryptedlocal.SecretsManagerFactory(
+		nil, // The passphrase is passed directly
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra: map[string]interface{}{
+				secrets.Path:       dataDir,
+				secrets.Passphrase: passphrase,
+			},
+		},
+	)
+}
+
 // setupHashicorpVault is a helper method for boilerplate hashicorp vault secrets manager setup
 func setupHashicorpVault(
 	secretsConfig *secrets.SecretsManagerConfig,
@@ -71,6 +88,30 @@ func setupGCPSSM(
 	)
 }
 
+// setupRemoteSigner is a helper method for boilerplate remote signer secrets manager setup
+func setupRemoteSigner(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return remotesigner.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// setupFileVault is a helper method for boilerplate file vault secrets manager setup
+func setupFileVault(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return filevault.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
 // InitECDSAValidatorKey creates new ECDSA key and set as a validator key
 func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address, error) {
 	if secretsManager.HasSecret(secrets.ValidatorKey) {
@@ -182,6 +223,20 @@ func LoadValidatorAddress(secretsManager secrets.SecretsManager) (types.Address,
 		return types.ZeroAddress, nil
 	}
 
+	if remoteSigner, ok := secretsManager.(secrets.KeySigner); ok {
+		pubKeyRaw, err := remoteSigner.PublicKey(secrets.ValidatorKey, secrets.ECDSAScheme)
+		if err != nil {
+			return types.ZeroAddress, err
+		}
+
+		pubKey, err := crypto.ParsePublicKey(pubKeyRaw)
+		if err != nil {
+			return types.ZeroAddress, err
+		}
+
+		return crypto.PubKeyToAddress(pubKey), nil
+	}
+
 	encodedKey, err := secretsManager.GetSecret(secrets.ValidatorKey)
 	if err != nil {
 		return types.ZeroAddress, err
@@ -201,6 +256,15 @@ func LoadBLSPublicKey(secretsManager secrets.SecretsManager) (string, error) {
 		return "", nil
 	}
 
+	if remoteSigner, ok := secretsManager.(secrets.KeySigner); ok {
+		pubkeyBytes, err := remoteSigner.PublicKey(secrets.ValidatorBLSKey, secrets.BLS12381Scheme)
+		if err != nil {
+			return "", err
+		}
+
+		return hex.EncodeToHex(pubkeyBytes), nil
+	}
+
 	encodedKey, err := secretsManager.GetSecret(secrets.ValidatorBLSKey)
 	if err != nil {
 		return "", err
@@ -225,6 +289,20 @@ func LoadNodeID(secretsManager secrets.SecretsManager) (string, error) {
 		return "", nil
 	}
 
+	if remoteSigner, ok := secretsManager.(secrets.KeySigner); ok {
+		remoteKey, err := network.NewRemoteLibp2pKey(remoteSigner)
+		if err != nil {
+			return "", err
+		}
+
+		nodeID, err := peer.IDFromPrivateKey(remoteKey)
+		if err != nil {
+			return "", err
+		}
+
+		return nodeID.String(), nil
+	}
+
 	encodedKey, err := secretsManager.GetSecret(secrets.NetworkKey)
 	if err != nil {
 		return "", err
@@ -280,6 +358,20 @@ func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secre
 		}
 
 		secretsManager = GCPSSM
+	case secrets.RemoteSigner:
+		remoteSigner, err := setupRemoteSigner(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = remoteSigner
+	case secrets.FileVault:
+		fileVault, err := setupFileVault(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = fileVault
 	default:
 		return secretsManager, errors.New("unsupported secrets manager")
 	}
@@ -0,0 +1,141 @@
+package remotesigner
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	bls "github.com/vishnushankarsg/metad/consensus/polybft/signer"
+	metaCrypto "github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/network"
+	"github.com/vishnushankarsg/metad/secrets"
+)
+
+var (
+	errUnknownScheme = errors.New("unknown signature scheme")
+	errInvalidDigest = errors.New("invalid digest length, expected 32 bytes")
+	errNotSigningKey = errors.New("secret is not a signing key")
+)
+
+// signingKeys are the names of the secrets the signing service signs with
+var signingKeys = map[string]struct{}{
+	secrets.ValidatorKey:    {},
+	secrets.ValidatorBLSKey: {},
+	secrets.NetworkKey:      {},
+}
+
+// IsSigningKey checks if the secret is a key the signing service signs with
+func IsSigningKey(name string) bool {
+	_, ok := signingKeys[name]
+
+	return ok
+}
+
+// signingKey is a private key held by the signing service
+type signingKey interface {
+	// publicKey returns the marshalled public key
+	publicKey() ([]byte, error)
+
+	// sign signs the payload, the domain is used only by the BN254 keys
+	sign(payload, domain []byte) ([]byte, error)
+}
+
+// parseSigningKey parses the key, encoded the way the secrets managers store it
+func parseSigningKey(scheme secrets.SignatureScheme, encoded []byte) (signingKey, error) {
+	switch scheme {
+	case secrets.ECDSAScheme:
+		key, err := metaCrypto.BytesToECDSAPrivateKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsaKey{key: key}, nil
+	case secrets.BN254Scheme:
+		key, err := bls.UnmarshalPrivateKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		return &bn254Key{key: key}, nil
+	case secrets.BLS12381Scheme:
+		key, err := metaCrypto.BytesToBLSSecretKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		return &bls12381Key{key: key}, nil
+	case secrets.Libp2pScheme:
+		key, err := network.ParseLibp2pKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		return &libp2pKey{key: key}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownScheme, scheme)
+	}
+}
+
+// ecdsaKey is the secp256k1 validator key, signing 32 byte digests
+type ecdsaKey struct {
+	key *ecdsa.PrivateKey
+}
+
+func (k *ecdsaKey) publicKey() ([]byte, error) {
+	return metaCrypto.MarshalPublicKey(&k.key.PublicKey), nil
+}
+
+func (k *ecdsaKey) sign(digest, _ []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, errInvalidDigest
+	}
+
+	return metaCrypto.Sign(k.key, digest)
+}
+
+// bn254Key is the BLS validator key of PolyBFT
+type bn254Key struct {
+	key *bls.PrivateKey
+}
+
+func (k *bn254Key) publicKey() ([]byte, error) {
+	return k.key.PublicKey().Marshal(), nil
+}
+
+func (k *bn254Key) sign(payload, domain []byte) ([]byte, error) {
+	signature, err := k.key.Sign(payload, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return signature.Marshal()
+}
+
+// bls12381Key is the BLS validator key of IBFT
+type bls12381Key struct {
+	key *bls_sig.SecretKey
+}
+
+func (k *bls12381Key) publicKey() ([]byte, error) {
+	return metaCrypto.BLSSecretKeyToPubkeyBytes(k.key)
+}
+
+func (k *bls12381Key) sign(payload, _ []byte) ([]byte, error) {
+	return metaCrypto.SignByBLS(k.key, payload)
+}
+
+// libp2pKey is the networking key
+type libp2pKey struct {
+	key crypto.PrivKey
+}
+
+func (k *libp2pKey) publicKey() ([]byte, error) {
+	return crypto.MarshalPublicKey(k.key.GetPublic())
+}
+
+func (k *libp2pKey) sign(payload, _ []byte) ([]byte, error) {
+	return k.key.Sign(payload)
+}
@@ -0,0 +1,553 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: secrets/remotesigner/proto/signer.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on KeyRequest with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *KeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KeyRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in KeyRequestMultiError, or
+// nil if none found.
+func (m *KeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Scheme
+
+	if len(errors) > 0 {
+		return KeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// KeyRequestMultiError is an error wrapping multiple validation errors
+// returned by KeyRequest.ValidateAll() if the designated constraints aren't met.
+type KeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KeyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KeyRequestMultiError) AllErrors() []error { return m }
+
+// KeyRequestValidationError is the validation error returned by
+// KeyRequest.Validate if the designated constraints aren't met.
+type KeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KeyRequestValidationError) ErrorName() string { return "KeyRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e KeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KeyRequestValidationError{}
+
+// Validate checks the field values on HasKeyResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *HasKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HasKeyResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in HasKeyResponseMultiError,
+// or nil if none found.
+func (m *HasKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HasKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Found
+
+	if len(errors) > 0 {
+		return HasKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// HasKeyResponseMultiError is an error wrapping multiple validation errors
+// returned by HasKeyResponse.ValidateAll() if the designated constraints
+// aren't met.
+type HasKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HasKeyResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HasKeyResponseMultiError) AllErrors() []error { return m }
+
+// HasKeyResponseValidationError is the validation error returned by
+// HasKeyResponse.Validate if the designated constraints aren't met.
+type HasKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HasKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HasKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HasKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HasKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HasKeyResponseValidationError) ErrorName() string { return "HasKeyResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e HasKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHasKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HasKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HasKeyResponseValidationError{}
+
+// Validate checks the field values on PublicKeyResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PublicKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PublicKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PublicKeyResponseMultiError, or nil if none found.
+func (m *PublicKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PublicKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for PublicKey
+
+	if len(errors) > 0 {
+		return PublicKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PublicKeyResponseMultiError is an error wrapping multiple validation errors
+// returned by PublicKeyResponse.ValidateAll() if the designated constraints
+// aren't met.
+type PublicKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PublicKeyResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PublicKeyResponseMultiError) AllErrors() []error { return m }
+
+// PublicKeyResponseValidationError is the validation error returned by
+// PublicKeyResponse.Validate if the designated constraints aren't met.
+type PublicKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PublicKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PublicKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PublicKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PublicKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PublicKeyResponseValidationError) ErrorName() string {
+	return "PublicKeyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PublicKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPublicKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PublicKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PublicKeyResponseValidationError{}
+
+// Validate checks the field values on SignRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SignRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SignRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SignRequestMultiError, or
+// nil if none found.
+func (m *SignRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SignRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Scheme
+
+	// no validation rules for Payload
+
+	// no validation rules for Domain
+
+	if len(errors) > 0 {
+		return SignRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SignRequestMultiError is an error wrapping multiple validation errors
+// returned by SignRequest.ValidateAll() if the designated constraints aren't met.
+type SignRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SignRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SignRequestMultiError) AllErrors() []error { return m }
+
+// SignRequestValidationError is the validation error returned by
+// SignRequest.Validate if the designated constraints aren't met.
+type SignRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SignRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SignRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SignRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SignRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SignRequestValidationError) ErrorName() string { return "SignRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SignRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSignRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SignRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SignRequestValidationError{}
+
+// Validate checks the field values on SignResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SignResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SignResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SignResponseMultiError, or
+// nil if none found.
+func (m *SignResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SignResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Signature
+
+	if len(errors) > 0 {
+		return SignResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SignResponseMultiError is an error wrapping multiple validation errors
+// returned by SignResponse.ValidateAll() if the designated constraints aren't met.
+type SignResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SignResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SignResponseMultiError) AllErrors() []error { return m }
+
+// SignResponseValidationError is the validation error returned by
+// SignResponse.Validate if the designated constraints aren't met.
+type SignResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SignResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SignResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SignResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SignResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SignResponseValidationError) ErrorName() string { return "SignResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SignResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSignResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SignResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SignResponseValidationError{}
@@ -0,0 +1,211 @@
+package remotesigner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/vishnushankarsg/metad/secrets/remotesigner/proto"
+)
+
+// CAFile is the config Extra key of the CA certificate verifying the TLS certificate of the signing service
+const CAFile = "ca-file"
+
+// requestTimeout is the timeout of the requests to the signing service
+const requestTimeout = 10 * time.Second
+
+// RemoteSignerSecretsManager is a SecretsManager that holds no secrets,
+// it signs by the keys of the remote signing service, which never leave the service
+type RemoteSignerSecretsManager struct {
+	// Logger object
+	logger hclog.Logger
+
+	// Token authenticating with the signing service
+	token string
+
+	// The gRPC connection to the signing service
+	conn   *grpc.ClientConn
+	client proto.RemoteSignerClient
+
+	// Map of the already fetched public keys, by the key name and scheme
+	publicKeys map[string][]byte
+
+	// Mux for the public keys
+	lock sync.RWMutex
+}
+
+// SecretsManagerFactory implements the factory method
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	// Check if the server URL is present
+	if config == nil || config.ServerURL == "" {
+		return nil, errors.New("no server URL specified for remote signer")
+	}
+
+	logger := params.Logger.Named(string(secrets.RemoteSigner))
+
+	transportCredentials := insecure.NewCredentials()
+
+	if raw, ok := config.Extra[CAFile]; ok {
+		caFile, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+
+		tlsCredentials, err := credentials.NewClientTLSFromFile(caFile, "")
+		if err != nil {
+			return nil, fmt.Errorf("unable to load remote signer CA certificate, %w", err)
+		}
+
+		transportCredentials = tlsCredentials
+	} else {
+		logger.Warn("connecting to the remote signer without TLS")
+	}
+
+	// The connection is established lazily, on the first request
+	conn, err := grpc.Dial(config.ServerURL, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to remote signer, %w", err)
+	}
+
+	signerManager := &RemoteSignerSecretsManager{
+		logger:     logger,
+		token:      config.Token,
+		conn:       conn,
+		client:     proto.NewRemoteSignerClient(conn),
+		publicKeys: make(map[string][]byte),
+	}
+
+	if err := signerManager.Setup(); err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	return signerManager, nil
+}
+
+// Setup checks the signing service is reachable
+func (r *RemoteSignerSecretsManager) Setup() error {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	if _, err := r.client.HasKey(ctx, &proto.KeyRequest{Name: secrets.ValidatorKey}); err != nil {
+		return fmt.Errorf("unable to reach remote signer, %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the connection to the signing service
+func (r *RemoteSignerSecretsManager) Close() error {
+	return r.conn.Close()
+}
+
+// GetSecret never returns the keys, which stay in the signing service
+func (r *RemoteSignerSecretsManager) GetSecret(name string) ([]byte, error) {
+	if IsSigningKey(name) {
+		return nil, secrets.ErrKeyNotExportable
+	}
+
+	return nil, secrets.ErrSecretNotFound
+}
+
+// SetSecret is not supported, the keys are provisioned in the signing service
+func (r *RemoteSignerSecretsManager) SetSecret(string, []byte) error {
+	return secrets.ErrSecretsNotWritable
+}
+
+// HasSecret checks if the signing service holds the key
+func (r *RemoteSignerSecretsManager) HasSecret(name string) bool {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	resp, err := r.client.HasKey(ctx, &proto.KeyRequest{Name: name})
+	if err != nil {
+		r.logger.Error("unable to check the key in remote signer", "name", name, "err", err)
+
+		return false
+	}
+
+	return resp.Found
+}
+
+// RemoveSecret is not supported, the keys are provisioned in the signing service
+func (r *RemoteSignerSecretsManager) RemoveSecret(string) error {
+	return secrets.ErrSecretsNotWritable
+}
+
+// PublicKey returns the marshalled public key of the key held by the signing service
+func (r *RemoteSignerSecretsManager) PublicKey(name string, scheme secrets.SignatureScheme) ([]byte, error) {
+	cacheKey := name + "/" + string(scheme)
+
+	r.lock.RLock()
+	publicKey, ok := r.publicKeys[cacheKey]
+	r.lock.RUnlock()
+
+	if ok {
+		return publicKey, nil
+	}
+
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	resp, err := r.client.PublicKey(ctx, &proto.KeyRequest{
+		Name:   name,
+		Scheme: string(scheme),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get public key (%s) from remote signer, %w", name, err)
+	}
+
+	r.lock.Lock()
+	r.publicKeys[cacheKey] = resp.PublicKey
+	r.lock.Unlock()
+
+	return resp.PublicKey, nil
+}
+
+// Sign signs the payload by the key held by the signing service
+func (r *RemoteSignerSecretsManager) Sign(
+	name string,
+	scheme secrets.SignatureScheme,
+	payload, domain []byte,
+) ([]byte, error) {
+	ctx, cancel := r.requestContext()
+	defer cancel()
+
+	resp, err := r.client.Sign(ctx, &proto.SignRequest{
+		Name:    name,
+		Scheme:  string(scheme),
+		Payload: payload,
+		Domain:  domain,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign by key (%s) in remote signer, %w", name, err)
+	}
+
+	return resp.Signature, nil
+}
+
+// requestContext returns the context of a request, carrying the token if set
+func (r *RemoteSignerSecretsManager) requestContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+
+	if r.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tokenMetadataKey, r.token)
+	}
+
+	return ctx, cancel
+}
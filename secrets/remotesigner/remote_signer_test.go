@@ -0,0 +1,269 @@
+package remotesigner
+
+import (
+	"net"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	bls "github.com/vishnushankarsg/metad/consensus/polybft/signer"
+	"github.com/vishnushankarsg/metad/consensus/polybft/wallet"
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/network"
+	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/vishnushankarsg/metad/secrets/local"
+)
+
+// newLocalManager creates the local secrets manager backing the signing service
+func newLocalManager(t *testing.T) secrets.SecretsManager {
+	t.Helper()
+
+	manager, err := local.SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra: map[string]interface{}{
+			secrets.Path: t.TempDir(),
+		},
+	})
+	require.NoError(t, err)
+
+	return manager
+}
+
+// startTestServer starts the stand-in signing service and returns its address
+func startTestServer(t *testing.T, manager secrets.SecretsManager, token string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer()
+	NewServer(hclog.NewNullLogger(), manager, token).Register(grpcServer)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	t.Cleanup(grpcServer.Stop)
+
+	return listener.Addr().String()
+}
+
+// newTestRemoteSigner connects to the signing service
+func newTestRemoteSigner(t *testing.T, addr, token string) (*RemoteSignerSecretsManager, error) {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{
+			Type:      secrets.RemoteSigner,
+			ServerURL: addr,
+			Token:     token,
+		},
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	signerManager, ok := manager.(*RemoteSignerSecretsManager)
+	require.True(t, ok)
+
+	t.Cleanup(func() {
+		_ = signerManager.Close()
+	})
+
+	return signerManager, nil
+}
+
+func TestRemoteSigner_Factory(t *testing.T) {
+	t.Parallel()
+
+	_, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{Type: secrets.RemoteSigner},
+		&secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()},
+	)
+	assert.Error(t, err)
+
+	addr := startTestServer(t, newLocalManager(t), "token")
+
+	_, err = newTestRemoteSigner(t, addr, "")
+	assert.Error(t, err)
+
+	_, err = newTestRemoteSigner(t, addr, "wrong")
+	assert.Error(t, err)
+
+	_, err = newTestRemoteSigner(t, addr, "token")
+	assert.NoError(t, err)
+}
+
+func TestRemoteSigner_IBFTKeys(t *testing.T) {
+	t.Parallel()
+
+	backend := newLocalManager(t)
+
+	ecdsaKey, ecdsaKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	require.NoError(t, err)
+	require.NoError(t, backend.SetSecret(secrets.ValidatorKey, ecdsaKeyEncoded))
+
+	blsKey, blsKeyEncoded, err := crypto.GenerateAndEncodeBLSSecretKey()
+	require.NoError(t, err)
+	require.NoError(t, backend.SetSecret(secrets.ValidatorBLSKey, blsKeyEncoded))
+
+	signer, err := newTestRemoteSigner(t, startTestServer(t, backend, ""), "")
+	require.NoError(t, err)
+
+	// the keys are never returned
+	assert.True(t, signer.HasSecret(secrets.ValidatorKey))
+	assert.False(t, signer.HasSecret(secrets.NetworkKey))
+	assert.False(t, signer.HasSecret(secrets.ValidatorBLSSignature))
+
+	_, err = signer.GetSecret(secrets.ValidatorKey)
+	assert.ErrorIs(t, err, secrets.ErrKeyNotExportable)
+
+	assert.ErrorIs(t, signer.SetSecret(secrets.NetworkKey, []byte{1}), secrets.ErrSecretsNotWritable)
+	assert.ErrorIs(t, signer.RemoveSecret(secrets.ValidatorKey), secrets.ErrSecretsNotWritable)
+
+	// ECDSA
+	digest := crypto.Keccak256([]byte("message"))
+
+	pubKey, err := signer.PublicKey(secrets.ValidatorKey, secrets.ECDSAScheme)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.MarshalPublicKey(&ecdsaKey.PublicKey), pubKey)
+
+	signature, err := signer.Sign(secrets.ValidatorKey, secrets.ECDSAScheme, digest, nil)
+	require.NoError(t, err)
+
+	recovered, err := crypto.RecoverPubkey(signature, digest)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&ecdsaKey.PublicKey), crypto.PubKeyToAddress(recovered))
+
+	_, err = signer.Sign(secrets.ValidatorKey, secrets.ECDSAScheme, []byte("not a digest"), nil)
+	assert.Error(t, err)
+
+	// BLS12-381
+	blsPubKey, err := signer.PublicKey(secrets.ValidatorBLSKey, secrets.BLS12381Scheme)
+	require.NoError(t, err)
+
+	expectedBLSPubKey, err := crypto.BLSSecretKeyToPubkeyBytes(blsKey)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBLSPubKey, blsPubKey)
+
+	blsSignature, err := signer.Sign(secrets.ValidatorBLSKey, secrets.BLS12381Scheme, digest, nil)
+	require.NoError(t, err)
+	assert.NoError(t, crypto.VerifyBLSSignatureFromBytes(blsPubKey, blsSignature, digest))
+
+	// unknown keys and schemes
+	_, err = signer.Sign(secrets.ValidatorBLSSignature, secrets.ECDSAScheme, digest, nil)
+	assert.Error(t, err)
+
+	_, err = signer.Sign(secrets.ValidatorKey, "unknown", digest, nil)
+	assert.Error(t, err)
+
+	_, err = signer.PublicKey(secrets.NetworkKey, secrets.Libp2pScheme)
+	assert.Error(t, err)
+}
+
+func TestRemoteSigner_PolyBFTAccount(t *testing.T) {
+	t.Parallel()
+
+	backend := newLocalManager(t)
+
+	account, err := wallet.GenerateAccount()
+	require.NoError(t, err)
+	require.NoError(t, account.Save(backend))
+
+	signer, err := newTestRemoteSigner(t, startTestServer(t, backend, ""), "")
+	require.NoError(t, err)
+
+	remoteSigner, err := wallet.NewSignerFromSecret(signer)
+	require.NoError(t, err)
+	assert.IsType(t, &wallet.RemoteSigner{}, remoteSigner)
+
+	key := wallet.NewKey(remoteSigner)
+	assert.Equal(t, account.Address(), key.Address())
+
+	msg := []byte("message")
+
+	signature, err := key.SignWithDomain(msg, bls.DomainCheckpointManager)
+	require.NoError(t, err)
+
+	blsSignature, err := bls.UnmarshalSignature(signature)
+	require.NoError(t, err)
+	assert.True(t, blsSignature.Verify(account.Bls.PublicKey(), msg, bls.DomainCheckpointManager))
+
+	// both signers produce the same ECDSA signatures
+	digest := crypto.Keccak256(msg)
+
+	expected, err := account.SignEcdsa(digest)
+	require.NoError(t, err)
+
+	actual, err := wallet.NewEcdsaSigner(key).Sign(digest)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestRemoteSigner_NetworkIdentity(t *testing.T) {
+	t.Parallel()
+
+	backend := newLocalManager(t)
+
+	libp2pKey, libp2pKeyEncoded, err := network.GenerateAndEncodeLibp2pKey()
+	require.NoError(t, err)
+	require.NoError(t, backend.SetSecret(secrets.NetworkKey, libp2pKeyEncoded))
+
+	signer, err := newTestRemoteSigner(t, startTestServer(t, backend, ""), "")
+	require.NoError(t, err)
+
+	remoteKey, err := network.NewRemoteLibp2pKey(signer)
+	require.NoError(t, err)
+	assert.True(t, remoteKey.GetPublic().Equals(libp2pKey.GetPublic()))
+
+	_, err = remoteKey.Raw()
+	assert.ErrorIs(t, err, secrets.ErrKeyNotExportable)
+
+	signature, err := remoteKey.Sign([]byte("message"))
+	require.NoError(t, err)
+
+	ok, err := libp2pKey.GetPublic().Verify([]byte("message"), signature)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// the node identified by the remote key handshakes with the other nodes
+	remoteServer, err := network.CreateServer(&network.CreateServerParams{
+		ConfigCallback: func(c *network.Config) {
+			c.NoDiscover = true
+			c.SecretsManager = signer
+		},
+	})
+	require.NoError(t, err)
+
+	localServer, err := network.CreateServer(&network.CreateServerParams{
+		ConfigCallback: func(c *network.Config) {
+			c.NoDiscover = true
+		},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = remoteServer.Close()
+		_ = localServer.Close()
+	})
+
+	expectedID, err := peer.IDFromPublicKey(libp2pKey.GetPublic())
+	require.NoError(t, err)
+	assert.Equal(t, expectedID, remoteServer.AddrInfo().ID)
+
+	require.NoError(t, network.JoinAndWait(
+		localServer,
+		remoteServer,
+		network.DefaultBufferTimeout,
+		network.DefaultJoinTimeout,
+	))
+
+	assert.True(t, localServer.IsConnected(expectedID))
+}
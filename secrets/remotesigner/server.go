@@ -0,0 +1,135 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/vishnushankarsg/metad/secrets/remotesigner/proto"
+)
+
+// tokenMetadataKey is the gRPC metadata key of the token authenticating the clients
+const tokenMetadataKey = "signer-token"
+
+// Server is a stand-in signing service speaking the remote signer protocol.
+// It signs by the keys held in the given SecretsManager, which never leave the service
+type Server struct {
+	proto.UnimplementedRemoteSignerServer
+
+	logger  hclog.Logger
+	manager secrets.SecretsManager
+
+	// token authenticating the clients, if set
+	token string
+}
+
+// NewServer creates the signing service backed by the SecretsManager
+func NewServer(logger hclog.Logger, manager secrets.SecretsManager, token string) *Server {
+	return &Server{
+		logger:  logger.Named("remote-signer"),
+		manager: manager,
+		token:   token,
+	}
+}
+
+// Register registers the signing service on the gRPC server
+func (s *Server) Register(grpcServer *grpc.Server) {
+	proto.RegisterRemoteSignerServer(grpcServer, s)
+}
+
+// HasKey checks whether the service holds the key
+func (s *Server) HasKey(ctx context.Context, req *proto.KeyRequest) (*proto.HasKeyResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	return &proto.HasKeyResponse{
+		Found: IsSigningKey(req.Name) && s.manager.HasSecret(req.Name),
+	}, nil
+}
+
+// PublicKey returns the marshalled public key of the key
+func (s *Server) PublicKey(ctx context.Context, req *proto.KeyRequest) (*proto.PublicKeyResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	key, err := s.loadKey(req.Name, secrets.SignatureScheme(req.Scheme))
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := key.publicKey()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &proto.PublicKeyResponse{PublicKey: publicKey}, nil
+}
+
+// Sign signs the payload by the key
+func (s *Server) Sign(ctx context.Context, req *proto.SignRequest) (*proto.SignResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	key, err := s.loadKey(req.Name, secrets.SignatureScheme(req.Scheme))
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := key.sign(req.Payload, req.Domain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.logger.Debug("signed payload", "key", req.Name, "scheme", req.Scheme)
+
+	return &proto.SignResponse{Signature: signature}, nil
+}
+
+// authorize checks the token sent by the client
+func (s *Server) authorize(ctx context.Context) error {
+	if s.token == "" {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	for _, token := range md.Get(tokenMetadataKey) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "invalid signer token")
+}
+
+// loadKey reads the key from the SecretsManager and parses it in the given scheme
+func (s *Server) loadKey(name string, scheme secrets.SignatureScheme) (signingKey, error) {
+	if !IsSigningKey(name) {
+		return nil, status.Error(codes.InvalidArgument, errNotSigningKey.Error())
+	}
+
+	if !s.manager.HasSecret(name) {
+		return nil, status.Error(codes.NotFound, secrets.ErrSecretNotFound.Error())
+	}
+
+	encoded, err := s.manager.GetSecret(name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	key, err := parseSigningKey(scheme, encoded)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return key, nil
+}
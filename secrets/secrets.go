@@ -19,8 +19,17 @@ const (
 
 	// Name is the name of the current node
 	Name = "name"
+
+	// Passphrase is the passphrase unlocking the encrypted secrets
+	Passphrase = "passphrase"
+
+	// PassphraseFile is the path to the file containing the passphrase unlocking the encrypted secrets
+	PassphraseFile = "passphrase-file"
 )
 
+// PassphraseEnv is the environment variable containing the passphrase unlocking the encrypted secrets
+const PassphraseEnv = "SECRETS_PASSPHRASE"
+
 // Define constant names for available secrets
 const (
 	// ValidatorKey is the private key secret of the validator node
@@ -51,7 +60,9 @@ const (
 )
 
 var (
-	ErrSecretNotFound = errors.New("secret not found")
+	ErrSecretNotFound     = errors.New("secret not found")
+	ErrKeyNotExportable   = errors.New("key is not exportable from the secrets manager")
+	ErrSecretsNotWritable = errors.New("secrets are not writable to the secrets manager")
 )
 
 type SecretsManagerType string
@@ -61,6 +72,9 @@ const (
 	// Local pertains to the local FS [Default]
 	Local SecretsManagerType = "local"
 
+	// EncryptedLocal pertains to the local FS, with the secrets encrypted by a passphrase
+	EncryptedLocal SecretsManagerType = "encrypted-local"
+
 	// HashicorpVault pertains to the Hashicorp Vault server
 	HashicorpVault SecretsManagerType = "hashicorp-vault"
 
@@ -69,6 +83,29 @@ const (
 
 	// GCPSSM pertains to the Google Cloud Computing secret store manager
 	GCPSSM SecretsManagerType = "gcp-ssm"
+
+	// RemoteSigner pertains to the remote signing service, which never exposes the keys
+	RemoteSigner SecretsManagerType = "remote-signer"
+
+	// FileVault pertains to the local vault file, with the secrets encrypted by a passphrase and the access audited
+	FileVault SecretsManagerType = "file-vault"
+)
+
+type SignatureScheme string
+
+// Define constant signature schemes of the keys
+const (
+	// ECDSAScheme signs 32 byte digests by a secp256k1 key, the signature is [R || S || V]
+	ECDSAScheme SignatureScheme = "ecdsa"
+
+	// BN254Scheme signs messages with a domain by a BLS key on the BN254 curve (PolyBFT)
+	BN254Scheme SignatureScheme = "bls-bn254"
+
+	// BLS12381Scheme signs messages by a BLS key on the BLS12-381 curve (IBFT)
+	BLS12381Scheme SignatureScheme = "bls12-381"
+
+	// Libp2pScheme signs messages by the libp2p networking key
+	Libp2pScheme SignatureScheme = "libp2p"
 )
 
 // SecretsManager defines the base public interface that all
@@ -90,6 +127,16 @@ type SecretsManager interface {
 	RemoveSecret(name string) error
 }
 
+// KeySigner is implemented by the secrets managers that sign with the keys they hold,
+// instead of handing the private keys to the caller
+type KeySigner interface {
+	// PublicKey returns the marshalled public key of the key
+	PublicKey(name string, scheme SignatureScheme) ([]byte, error)
+
+	// Sign signs the payload by the key, the domain is used only by the BN254Scheme
+	Sign(name string, scheme SignatureScheme, payload, domain []byte) ([]byte, error)
+}
+
 // SecretsManagerParams defines the configuration params for the
 // secrets manager
 type SecretsManagerParams struct {
@@ -114,5 +161,6 @@ type SecretsManagerFactory func(
 // SupportedServiceManager checks if the passed in service manager type is supported
 func SupportedServiceManager(service SecretsManagerType) bool {
 	return service == HashicorpVault || service == AWSSSM ||
-		service == Local || service == GCPSSM
+		service == Local || service == GCPSSM || service == EncryptedLocal ||
+		service == RemoteSigner || service == FileVault
 }
@@ -32,6 +32,21 @@ func TestSupportedServiceManager(t *testing.T) {
 			GCPSSM,
 			true,
 		},
+		{
+			"Valid encrypted local secrets manager",
+			EncryptedLocal,
+			true,
+		},
+		{
+			"Valid remote signer secrets manager",
+			RemoteSigner,
+			true,
+		},
+		{
+			"Valid file vault secrets manager",
+			FileVault,
+			true,
+		},
 		{
 			"Invalid secrets manager",
 			"MarsSecretsManager",
@@ -9,9 +9,12 @@ import (
 	consensusPolyBFT "github.com/vishnushankarsg/metad/consensus/polybft"
 	"github.com/vishnushankarsg/metad/secrets"
 	"github.com/vishnushankarsg/metad/secrets/awsssm"
+	"github.com/vishnushankarsg/metad/secrets/encryptedlocal"
+	"github.com/vishnushankarsg/metad/secrets/filevault"
 	"github.com/vishnushankarsg/metad/secrets/gcpssm"
 	"github.com/vishnushankarsg/metad/secrets/hashicorpvault"
 	"github.com/vishnushankarsg/metad/secrets/local"
+	"github.com/vishnushankarsg/metad/secrets/remotesigner"
 	"github.com/vishnushankarsg/metad/state"
 )
 
@@ -37,6 +40,9 @@ var consensusBackends = map[ConsensusType]consensus.Factory{
 // secret management solutions
 var secretsManagerBackends = map[secrets.SecretsManagerType]secrets.SecretsManagerFactory{
 	secrets.Local:          local.SecretsManagerFactory,
+	secrets.EncryptedLocal: encryptedlocal.SecretsManagerFactory,
+	secrets.RemoteSigner:   remotesigner.SecretsManagerFactory,
+	secrets.FileVault:      filevault.SecretsManagerFactory,
 	secrets.HashicorpVault: hashicorpvault.SecretsManagerFactory,
 	secrets.AWSSSM:         awsssm.SecretsManagerFactory,
 	secrets.GCPSSM:         gcpssm.SecretsManagerFactory,
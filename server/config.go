@@ -2,12 +2,14 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/vishnushankarsg/metad/chain"
 	"github.com/vishnushankarsg/metad/network"
 	"github.com/vishnushankarsg/metad/secrets"
+	"github.com/vishnushankarsg/metad/types"
 )
 
 const DefaultGRPCPort int = 9632
@@ -24,12 +26,21 @@ type Config struct {
 	PriceLimit         uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
+	PriceBump          uint64
+	// TxJournal is the path of the journal of the local transactions, disabled if empty
+	TxJournal string
+	// EnqueuedLifetime is the maximum time of keeping the enqueued transactions of an inactive account
+	EnqueuedLifetime time.Duration
+	// PrioritySenders are exempt from the price limit and eviction, their transactions are executed first
+	PrioritySenders []types.Address
 
 	Telemetry *Telemetry
 	Network   *network.Config
 
 	DataDir     string
 	RestoreFile *string
+	// RestoreStateFile is the path to the state snapshot restored on initialization of the empty chain
+	RestoreStateFile *string
 
 	Seal bool
 
@@ -44,6 +55,20 @@ type Config struct {
 	Relayer bool
 
 	NumBlockConfirmations uint64
+
+	// Prune enables the full mode, removing the states of all but the PruneRetain latest blocks.
+	// The archive mode, keeping all the states, is used otherwise
+	Prune       bool
+	PruneRetain uint64
+
+	// SnapSync enables downloading the state of a recent block from the peers to the empty chain
+	SnapSync bool
+
+	// MaxSenderTxs is the maximum number of transactions of a single sender in a block, unlimited if zero
+	MaxSenderTxs uint64
+
+	// TxSenderIndex enables the index of the transactions by their sender and nonce
+	TxSenderIndex bool
 }
 
 // Telemetry holds the config details for metric services
@@ -76,6 +76,40 @@ func TestRequestValidation(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "PeersBanRequest: invalid id format",
+			req: &PeersBanRequest{
+				Id:       "-1",
+				Duration: 60,
+			},
+			valid:    false,
+			errorMsg: "PeersBanRequest.Id: value does not match regex pattern",
+		},
+		{
+			name: "PeersBanRequest: zero duration",
+			req: &PeersBanRequest{
+				Id: "16Uiu2HAkzfvGmMz52QdkNYuCNouEJncdWZeuMq7jEPPrPYhzEuJh",
+			},
+			valid:    false,
+			errorMsg: "PeersBanRequest.Duration: value must be greater than 0",
+		},
+		{
+			name: "PeersBanRequest: valid request",
+			req: &PeersBanRequest{
+				Id:       "16Uiu2HAkzfvGmMz52QdkNYuCNouEJncdWZeuMq7jEPPrPYhzEuJh",
+				Duration: 60,
+				Reason:   "reason",
+			},
+			valid: true,
+		},
+		{
+			name: "PeersUnbanRequest: empty id",
+			req: &PeersUnbanRequest{
+				Id: "",
+			},
+			valid:    false,
+			errorMsg: "PeersUnbanRequest.Id: value does not match regex pattern",
+		},
 	}
 
 	for _, tt := range tests {
@@ -15,6 +15,7 @@ import (
 	"github.com/vishnushankarsg/metad/blockchain/storage"
 	"github.com/vishnushankarsg/metad/blockchain/storage/leveldb"
 	"github.com/vishnushankarsg/metad/blockchain/storage/memory"
+	consensusIBFT "github.com/vishnushankarsg/metad/consensus/ibft"
 	consensusPolyBFT "github.com/vishnushankarsg/metad/consensus/polybft"
 
 	"github.com/vishnushankarsg/metad/archive"
@@ -25,6 +26,7 @@ import (
 	"github.com/vishnushankarsg/metad/consensus/polybft/wallet"
 	"github.com/vishnushankarsg/metad/contracts"
 	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/gasprice"
 	"github.com/vishnushankarsg/metad/helper/common"
 	configHelper "github.com/vishnushankarsg/metad/helper/config"
 	"github.com/vishnushankarsg/metad/helper/progress"
@@ -37,6 +39,7 @@ import (
 	"github.com/vishnushankarsg/metad/state/runtime"
 	"github.com/vishnushankarsg/metad/state/runtime/addresslist"
 	"github.com/vishnushankarsg/metad/state/runtime/tracer"
+	"github.com/vishnushankarsg/metad/syncer"
 	"github.com/vishnushankarsg/metad/txpool"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/vishnushankarsg/metad/validate"
@@ -90,6 +93,12 @@ type Server struct {
 
 	// stateSyncRelayer is handling state syncs execution (Polybft exclusive)
 	stateSyncRelayer *statesyncrelayer.StateSyncRelayer
+
+	// statePruner is pruning the states of the old blocks (full node mode exclusive)
+	statePruner *statePruner
+
+	// snapSyncer is serving the state to the peers and downloading it on the first start
+	snapSyncer syncer.SnapSyncer
 }
 
 // newFileLogger returns logger instance that writes all logs to a specified file.
@@ -199,6 +208,18 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	var pruningStorage *itrie.PruningStorage
+
+	if config.Prune {
+		if pruningStorage, err = itrie.NewPruningStorage(stateStorage, logger); err != nil {
+			return nil, err
+		}
+
+		stateStorage = pruningStorage
+
+		logger.Info("running in the full mode, pruning the old states", "retain", config.PruneRetain)
+	}
+
 	m.stateStorage = stateStorage
 
 	st := itrie.NewState(stateStorage)
@@ -268,8 +289,8 @@ func NewServer(config *Config) (*Server, error) {
 	// compute the genesis root state
 	config.Chain.Genesis.StateRoot = genesisRoot
 
-	// use the eip155 signer
-	signer := crypto.NewEIP155Signer(chain.AllForksEnabled.At(0), uint64(m.config.Chain.Params.ChainID))
+	// use the london signer, it falls back to the eip155 signer for the legacy transactions
+	signer := crypto.NewLondonSigner(uint64(m.config.Chain.Params.ChainID), chain.AllForksEnabled.At(0).Homestead)
 
 	// create storage instance for blockchain
 	var db storage.Storage
@@ -296,6 +317,10 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if config.TxSenderIndex {
+		m.blockchain.EnableTxSenderIndex()
+	}
+
 	m.executor.GetHash = m.blockchain.GetHashHelper
 
 	{
@@ -320,6 +345,10 @@ func NewServer(config *Config) (*Server, error) {
 				MaxSlots:            m.config.MaxSlots,
 				PriceLimit:          m.config.PriceLimit,
 				MaxAccountEnqueued:  m.config.MaxAccountEnqueued,
+				PriceBump:           m.config.PriceBump,
+				Journal:             m.config.TxJournal,
+				EnqueuedLifetime:    m.config.EnqueuedLifetime,
+				PrioritySenders:     m.config.PrioritySenders,
 				DeploymentWhitelist: deploymentWhitelist,
 			},
 		)
@@ -345,20 +374,34 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
-	// initialize data in consensus layer
+	// initialize data in consensus layer,
+	// the headers of the state snapshot are verified by the consensus
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
 	}
 
-	// setup and start grpc server
-	if err := m.setupGRPC(); err != nil {
+	// restore the state snapshot before the consensus starts
+	if err := m.restoreStateSnapshot(); err != nil {
 		return nil, err
 	}
 
+	// serve the state to the peers snap syncing it
+	m.snapSyncer = syncer.NewSnapSyncer(logger, m.network, m.blockchain, m.stateStorage, m.epochSize())
+	m.snapSyncer.Start()
+
+	// the network is started before the consensus, so the state can be downloaded from the peers
 	if err := m.network.Start(); err != nil {
 		return nil, err
 	}
 
+	// snap sync the state before the consensus starts
+	m.snapSync()
+
+	// setup and start grpc server
+	if err := m.setupGRPC(); err != nil {
+		return nil, err
+	}
+
 	// setup and start jsonrpc server
 	if err := m.setupJSONRPC(); err != nil {
 		return nil, err
@@ -383,6 +426,12 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.txpool.Start()
 
+	// start pruning the old states in the full mode
+	if pruningStorage != nil {
+		m.statePruner = newStatePruner(logger, pruningStorage, m.blockchain, config.PruneRetain)
+		m.statePruner.start()
+	}
+
 	return m, nil
 }
 
@@ -412,6 +461,26 @@ func (s *Server) restoreChain() error {
 	return nil
 }
 
+func (s *Server) restoreStateSnapshot() error {
+	if s.config.RestoreStateFile == nil {
+		return nil
+	}
+
+	return archive.RestoreStateSnapshot(s.blockchain, s.stateStorage, s.logger, *s.config.RestoreStateFile)
+}
+
+// snapSync downloads the state of a recent block from the peers to the empty chain,
+// all the blocks are synced by the consensus otherwise
+func (s *Server) snapSync() {
+	if !s.config.SnapSync {
+		return
+	}
+
+	if err := s.snapSyncer.Sync(); err != nil {
+		s.logger.Error("snap sync failed, syncing all the blocks", "err", err)
+	}
+}
+
 type txpoolHub struct {
 	state state.State
 	*blockchain.Blockchain
@@ -475,9 +544,10 @@ func (s *Server) setupSecretsManager() error {
 		Logger: s.logger,
 	}
 
-	if secretsManagerType == secrets.Local {
+	if secretsManagerType == secrets.Local || secretsManagerType == secrets.EncryptedLocal ||
+		secretsManagerType == secrets.FileVault {
 		// Only the base directory is required for
-		// the local secrets manager
+		// the local secrets managers, the vault file defaults to it
 		secretsManagerParams.Extra = map[string]interface{}{
 			secrets.Path: s.config.DataDir,
 		}
@@ -549,6 +619,7 @@ func (s *Server) setupConsensus() error {
 			SecretsManager:        s.secretsManager,
 			BlockTime:             uint64(blockTime.Seconds()),
 			NumBlockConfirmations: s.config.NumBlockConfirmations,
+			MaxSenderTxs:          s.config.MaxSenderTxs,
 		},
 	)
 
@@ -587,9 +658,30 @@ func extractBlockTime(engineConfig map[string]interface{}) (common.Duration, err
 	return blockTime, nil
 }
 
+// epochSize returns the epoch size of the consensus engine, the validator set can change at its boundaries,
+// the IBFT default is used if it's not set, and 0 is returned for the engines without the epochs
+func (s *Server) epochSize() uint64 {
+	engineName := s.config.Chain.Params.GetEngine()
+
+	engineConfig, ok := s.config.Chain.Params.Engine[engineName].(map[string]interface{})
+	if !ok {
+		engineConfig = map[string]interface{}{}
+	}
+
+	if epochSize, ok := engineConfig[consensusIBFT.KeyEpochSize].(float64); ok {
+		return uint64(epochSize)
+	}
+
+	if ConsensusType(engineName) == IBFTConsensus {
+		return consensusIBFT.DefaultEpochSize
+	}
+
+	return 0
+}
+
 // setupRelayer sets up the relayer
 func (s *Server) setupRelayer() error {
-	account, err := wallet.NewAccountFromSecret(s.secretsManager)
+	signer, err := wallet.NewSignerFromSecret(s.secretsManager)
 	if err != nil {
 		return fmt.Errorf("failed to create account from secret: %w", err)
 	}
@@ -610,7 +702,7 @@ func (s *Server) setupRelayer() error {
 		ethgo.Address(contracts.StateReceiverContract),
 		trackerStartBlockConfig[contracts.StateReceiverContract],
 		s.logger.Named("relayer"),
-		wallet.NewEcdsaSigner(wallet.NewKey(account)),
+		wallet.NewEcdsaSigner(wallet.NewKey(signer)),
 	)
 
 	// start relayer
@@ -623,12 +715,14 @@ func (s *Server) setupRelayer() error {
 
 type jsonRPCHub struct {
 	state              state.State
+	stateStorage       itrie.Storage
 	restoreProgression *progress.ProgressionWrapper
 
 	*blockchain.Blockchain
 	*txpool.TxPool
 	*state.Executor
 	*network.Server
+	*gasprice.GasHelper
 	consensus.Consensus
 	consensus.BridgeDataProvider
 }
@@ -686,6 +780,53 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the Merkle proof of the account and of the given storage slots
+// in the state with the given root
+func (j *jsonRPCHub) GetProof(
+	root types.Hash,
+	addr types.Address,
+	slots []types.Hash,
+) (*jsonrpc.AccountProof, error) {
+	if pruning, ok := j.stateStorage.(*itrie.PruningStorage); ok && !pruning.HasState(root) {
+		return nil, fmt.Errorf("%w: %s", itrie.ErrStatePruned, root)
+	}
+
+	account, proof, err := itrie.GetAccountProof(root, addr, j.stateStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		// the proof proves that the account doesn't exist
+		account = &state.Account{
+			Balance:  big.NewInt(0),
+			Root:     types.EmptyRootHash,
+			CodeHash: crypto.Keccak256(nil),
+		}
+	}
+
+	res := &jsonrpc.AccountProof{
+		Account:       account,
+		Proof:         proof,
+		StorageProofs: make([]*jsonrpc.StorageProof, len(slots)),
+	}
+
+	for i, slot := range slots {
+		value, storageProof, err := itrie.GetStorageProof(account.Root, slot, j.stateStorage)
+		if err != nil {
+			return nil, err
+		}
+
+		res.StorageProofs[i] = &jsonrpc.StorageProof{
+			Key:   slot,
+			Value: value,
+			Proof: storageProof,
+		}
+	}
+
+	return res, nil
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
@@ -701,6 +842,9 @@ func (j *jsonRPCHub) ApplyTxn(
 		return
 	}
 
+	// the simulated calls don't have to pay the base fee if no gas price is set
+	transition.SetNoBaseFee(true)
+
 	if override != nil {
 		if err = transition.WithStateOverride(override); err != nil {
 			return
@@ -852,12 +996,14 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 func (s *Server) setupJSONRPC() error {
 	hub := &jsonRPCHub{
 		state:              s.state,
+		stateStorage:       s.stateStorage,
 		restoreProgression: s.restoreProgression,
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
 		Executor:           s.executor,
 		Consensus:          s.consensus,
 		Server:             s.network,
+		GasHelper:          gasprice.NewGasHelper(gasprice.DefaultGasHelperConfig(), s.blockchain),
 		BridgeDataProvider: s.consensus.GetBridgeProvider(),
 	}
 
@@ -929,6 +1075,18 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Stop serving the state to the peers
+	if s.snapSyncer != nil {
+		if err := s.snapSyncer.Close(); err != nil {
+			s.logger.Error("failed to close snap syncer", "err", err.Error())
+		}
+	}
+
+	// Stop the state pruning before closing the state storage
+	if s.statePruner != nil {
+		s.statePruner.close()
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
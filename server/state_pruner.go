@@ -0,0 +1,155 @@
+package server
+
+import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/vishnushankarsg/metad/blockchain"
+	itrie "github.com/vishnushankarsg/metad/state/immutable-trie"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// statePrunerBlockchain is the blockchain interface used by the state pruner
+type statePrunerBlockchain interface {
+	Header() *types.Header
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	SubscribeEvents() blockchain.Subscription
+}
+
+// statePruner prunes the states of the blocks older than the retained ones (full node mode)
+type statePruner struct {
+	logger     hclog.Logger
+	storage    *itrie.PruningStorage
+	blockchain statePrunerBlockchain
+
+	// retain is the number of the latest block states kept
+	retain uint64
+
+	// interval is the number of the blocks between two prunings
+	interval uint64
+
+	// lastPruned is the head block number of the last pruning
+	lastPruned uint64
+
+	pruneCh chan struct{}
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func newStatePruner(
+	logger hclog.Logger,
+	storage *itrie.PruningStorage,
+	blockchain statePrunerBlockchain,
+	retain uint64,
+) *statePruner {
+	// prune every half of the retained states, so at most 1.5 times the retained states are kept
+	interval := retain / 2
+	if interval == 0 {
+		interval = 1
+	}
+
+	return &statePruner{
+		logger:     logger.Named("state-pruner"),
+		storage:    storage,
+		blockchain: blockchain,
+		retain:     retain,
+		interval:   interval,
+		pruneCh:    make(chan struct{}, 1),
+		closeCh:    make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// start starts the pruning on the new blocks
+func (p *statePruner) start() {
+	sub := p.blockchain.SubscribeEvents()
+
+	// the events are consumed separately, so the pruning doesn't block the blockchain
+	go func() {
+		defer sub.Close()
+
+		eventCh := sub.GetEventCh()
+
+		for {
+			select {
+			case <-p.closeCh:
+				return
+			case ev := <-eventCh:
+				if ev == nil || ev.Type == blockchain.EventFork || len(ev.NewChain) == 0 {
+					continue
+				}
+
+				select {
+				case p.pruneCh <- struct{}{}:
+				default:
+					// a pruning is already pending
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer close(p.doneCh)
+
+		for {
+			select {
+			case <-p.closeCh:
+				return
+			case <-p.pruneCh:
+				p.prune()
+			}
+		}
+	}()
+}
+
+// close stops the pruning and waits for the running one to finish
+func (p *statePruner) close() {
+	close(p.closeCh)
+	<-p.doneCh
+}
+
+// prune removes the states of the blocks older than the retained ones,
+// once the interval has passed since the last pruning
+func (p *statePruner) prune() {
+	head := p.blockchain.Header()
+	if head == nil || head.Number < p.lastPruned+p.interval {
+		return
+	}
+
+	roots := p.retainedRoots(head)
+	if len(roots) == 0 {
+		// nothing would be retained, the state of the head block is missing
+		p.logger.Warn("skipping state pruning, the state of the head block not found", "block", head.Number)
+
+		return
+	}
+
+	pruned, err := p.storage.Prune(roots)
+	if err != nil {
+		p.logger.Error("failed to prune the state", "block", head.Number, "err", err)
+
+		return
+	}
+
+	p.lastPruned = head.Number
+
+	p.logger.Info("state pruned", "block", head.Number, "retained", len(roots), "nodes", pruned)
+}
+
+// retainedRoots returns the state roots of the retained blocks, ending with the given head.
+// The states which have already been pruned (e.g. when the number of the retained blocks was increased) are skipped
+func (p *statePruner) retainedRoots(head *types.Header) []types.Hash {
+	roots := make([]types.Hash, 0, p.retain)
+
+	for i := uint64(0); i < p.retain && i <= head.Number; i++ {
+		header, ok := p.blockchain.GetHeaderByNumber(head.Number - i)
+		if !ok {
+			break
+		}
+
+		if p.storage.HasState(header.StateRoot) {
+			roots = append(roots, header.StateRoot)
+		}
+	}
+
+	return roots
+}
@@ -5,8 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/vishnushankarsg/metad/blockchain"
+	"github.com/vishnushankarsg/metad/network"
 	"github.com/vishnushankarsg/metad/network/common"
 	"github.com/vishnushankarsg/metad/server/proto"
 	"github.com/vishnushankarsg/metad/types"
@@ -161,6 +164,68 @@ func (s *systemService) PeersList(
 	return resp, nil
 }
 
+// PeersBan implements the 'peers ban' operator service
+func (s *systemService) PeersBan(_ context.Context, req *proto.PeersBanRequest) (*proto.BannedPeer, error) {
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.Duration) * time.Second
+
+	ban, err := s.server.network.BanPeer(peerID, duration, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoBannedPeer(ban), nil
+}
+
+// PeersUnban implements the 'peers unban' operator service
+func (s *systemService) PeersUnban(_ context.Context, req *proto.PeersUnbanRequest) (*empty.Empty, error) {
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.server.network.UnbanPeer(peerID); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// PeersListBanned implements the 'peers list-banned' operator service
+func (s *systemService) PeersListBanned(
+	_ context.Context,
+	_ *empty.Empty,
+) (*proto.PeersListBannedResponse, error) {
+	bans := s.server.network.BannedPeers()
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Until.Before(bans[j].Until)
+	})
+
+	resp := &proto.PeersListBannedResponse{
+		Peers: make([]*proto.BannedPeer, len(bans)),
+	}
+
+	for i, ban := range bans {
+		resp.Peers[i] = toProtoBannedPeer(ban)
+	}
+
+	return resp, nil
+}
+
+// toProtoBannedPeer converts the ban to the proto.BannedPeer
+func toProtoBannedPeer(ban *network.BannedPeer) *proto.BannedPeer {
+	return &proto.BannedPeer{
+		Id:     ban.ID.String(),
+		Until:  ban.Until.Unix(),
+		Reason: ban.Reason,
+	}
+}
+
 // BlockByNumber implements the BlockByNumber operator service
 func (s *systemService) BlockByNumber(
 	ctx context.Context,
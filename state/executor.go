@@ -24,6 +24,9 @@ const (
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address specified in the EIP-2930 access list
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in the EIP-2930 access list
 )
 
 var emptyCodeHashTwo = types.BytesToHash(crypto.Keccak256(nil))
@@ -188,6 +191,7 @@ func (e *Executor) BeginTxn(
 		Difficulty: types.BytesToHash(new(big.Int).SetUint64(header.Difficulty).Bytes()),
 		GasLimit:   int64(header.GasLimit),
 		ChainID:    e.config.ChainID,
+		BaseFee:    new(big.Int).SetUint64(header.BaseFee),
 	}
 
 	txn := &Transition{
@@ -242,6 +246,9 @@ type Transition struct {
 	ctx     runtime.TxContext
 	gasPool uint64
 
+	// noBaseFee disables the base fee checks for transactions without any gas price set (eth_call)
+	noBaseFee bool
+
 	// result
 	receipts []*types.Receipt
 	totalGas uint64
@@ -299,6 +306,12 @@ func (t *Transition) WithStateOverride(override types.StateOverride) error {
 	return nil
 }
 
+// SetNoBaseFee disables the base fee checks for the transactions which don't set any gas price.
+// It is meant for the simulated executions (eth_call, eth_estimateGas), not for the block processing
+func (t *Transition) SetNoBaseFee(noBaseFee bool) {
+	t.noBaseFee = noBaseFee
+}
+
 func (t *Transition) TotalGas() uint64 {
 	return t.totalGas
 }
@@ -312,7 +325,7 @@ var emptyFrom = types.Address{}
 func (t *Transition) WriteFailedReceipt(txn *types.Transaction) error {
 	signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
-	if txn.From == emptyFrom && txn.Type == types.LegacyTx {
+	if txn.From == emptyFrom && txn.Type != types.StateTx {
 		// Decrypt the from address
 		from, err := signer.Sender(txn)
 		if err != nil {
@@ -344,7 +357,7 @@ func (t *Transition) WriteFailedReceipt(txn *types.Transaction) error {
 func (t *Transition) Write(txn *types.Transaction) error {
 	var err error
 
-	if txn.From == emptyFrom && txn.Type == types.LegacyTx {
+	if txn.From == emptyFrom && txn.Type != types.StateTx {
 		// Decrypt the from address
 		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
@@ -397,6 +410,33 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	return nil
 }
 
+// TransitionCheckpoint is the point of the block execution the transition can be reverted to
+type TransitionCheckpoint struct {
+	snapshot int
+	gasPool  uint64
+	totalGas uint64
+	receipts int
+}
+
+// Checkpoint takes the snapshot of the state and of the transactions written so far
+func (t *Transition) Checkpoint() TransitionCheckpoint {
+	return TransitionCheckpoint{
+		snapshot: t.state.Snapshot(),
+		gasPool:  t.gasPool,
+		totalGas: t.totalGas,
+		receipts: len(t.receipts),
+	}
+}
+
+// RevertToCheckpoint discards the transactions written after the given checkpoint
+func (t *Transition) RevertToCheckpoint(checkpoint TransitionCheckpoint) {
+	t.state.RevertToSnapshot(checkpoint.snapshot)
+
+	t.gasPool = checkpoint.gasPool
+	t.totalGas = checkpoint.totalGas
+	t.receipts = t.receipts[:checkpoint.receipts]
+}
+
 // Commit commits the final result
 func (t *Transition) Commit() (Snapshot, types.Hash) {
 	objs := t.state.Commit(t.config.EIP155)
@@ -446,8 +486,16 @@ func (t *Transition) ContextPtr() *runtime.TxContext {
 }
 
 func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
-	// deduct the upfront max gas cost
-	upfrontGasCost := new(big.Int).Set(msg.GasPrice)
+	// the balance has to cover the max gas cost (gas limit * gas fee cap) for the dynamic fee transactions
+	if msg.Type == types.DynamicFeeTx {
+		maxGasCost := new(big.Int).Mul(msg.GetGasFeeCap(), new(big.Int).SetUint64(msg.Gas))
+		if t.state.GetBalance(msg.From).Cmp(maxGasCost) < 0 {
+			return ErrNotEnoughFundsForGas
+		}
+	}
+
+	// deduct the upfront gas cost at the effective gas price
+	upfrontGasCost := t.effectiveGasPrice(msg)
 	upfrontGasCost.Mul(upfrontGasCost, new(big.Int).SetUint64(msg.Gas))
 
 	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
@@ -461,6 +509,55 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	return nil
 }
 
+// effectiveGasPrice returns the price per gas the sender pays for the message
+func (t *Transition) effectiveGasPrice(msg *types.Transaction) *big.Int {
+	if !t.config.EIP1559 {
+		return new(big.Int).Set(msg.GetGasFeeCap())
+	}
+
+	return msg.EffectiveGasPrice(t.ctx.BaseFee)
+}
+
+// txTypeCheck makes sure the message type is supported by the active forks
+func (t *Transition) txTypeCheck(msg *types.Transaction) error {
+	switch msg.Type {
+	case types.AccessListTx:
+		if !t.config.Berlin {
+			return ErrTxTypeNotSupported
+		}
+	case types.DynamicFeeTx:
+		if !t.config.EIP1559 {
+			return ErrTxTypeNotSupported
+		}
+	}
+
+	return nil
+}
+
+// feeCheck makes sure the message gas fees conform to the EIP-1559 rules
+func (t *Transition) feeCheck(msg *types.Transaction) error {
+	if !t.config.EIP1559 {
+		return nil
+	}
+
+	gasFeeCap, gasTipCap := msg.GetGasFeeCap(), msg.GetGasTipCap()
+
+	// simulated executions without any gas price set skip the base fee checks
+	if t.noBaseFee && gasFeeCap.Sign() == 0 && gasTipCap.Sign() == 0 {
+		return nil
+	}
+
+	if gasFeeCap.Cmp(gasTipCap) < 0 {
+		return ErrTipAboveFeeCap
+	}
+
+	if t.ctx.BaseFee != nil && gasFeeCap.Cmp(t.ctx.BaseFee) < 0 {
+		return ErrFeeCapTooLow
+	}
+
+	return nil
+}
+
 func (t *Transition) nonceCheck(msg *types.Transaction) error {
 	nonce := t.state.GetNonce(msg.From)
 
@@ -481,6 +578,9 @@ var (
 	ErrIntrinsicGasOverflow  = fmt.Errorf("overflow in intrinsic gas calculation")
 	ErrNotEnoughIntrinsicGas = fmt.Errorf("not enough gas supplied for intrinsic gas costs")
 	ErrNotEnoughFunds        = fmt.Errorf("not enough funds for transfer with given value")
+	ErrTxTypeNotSupported    = fmt.Errorf("transaction type not supported")
+	ErrTipAboveFeeCap        = fmt.Errorf("max priority fee per gas higher than max fee per gas")
+	ErrFeeCapTooLow          = fmt.Errorf("max fee per gas less than block base fee")
 )
 
 type TransitionApplicationError struct {
@@ -509,13 +609,25 @@ func NewGasLimitReachedTransitionApplicationError(err error) *GasLimitReachedTra
 	}
 }
 
-func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
+func (t *Transition) apply(msg *types.Transaction) (_ *runtime.ExecutionResult, err error) {
+	if t.ctx.Tracer != nil {
+		// the tracer sees the state before the gas is purchased,
+		// so the transaction failing the pre-checks is ended without any gas used
+		t.ctx.Tracer.TxStart(msg, t.ctx.Coinbase, t)
+
+		defer func() {
+			if err != nil {
+				t.ctx.Tracer.TxEnd(msg.Gas)
+			}
+		}()
+	}
+
 	if msg.Type == types.StateTx {
 		if err := checkAndProcessStateTx(msg, t); err != nil {
 			return nil, err
 		}
 	} else {
-		if err := checkAndProcessLegacyTx(msg, t); err != nil {
+		if err := checkAndProcessTx(msg, t); err != nil {
 			return nil, err
 		}
 	}
@@ -525,10 +637,6 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		return nil, NewGasLimitReachedTransitionApplicationError(err)
 	}
 
-	if t.ctx.Tracer != nil {
-		t.ctx.Tracer.TxStart(msg.Gas)
-	}
-
 	// 4. there is no overflow when calculating intrinsic gas
 	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul)
 	if err != nil {
@@ -542,7 +650,11 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		return nil, NewTransitionApplicationError(ErrNotEnoughIntrinsicGas, false)
 	}
 
-	gasPrice := new(big.Int).Set(msg.GasPrice)
+	if t.config.Berlin {
+		t.prepareAccessList(msg)
+	}
+
+	gasPrice := t.effectiveGasPrice(msg)
 	value := new(big.Int).Set(msg.Value)
 
 	// set the specific transaction fields in the context
@@ -560,24 +672,56 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	refund := t.state.GetRefund()
 	result.UpdateGasUsed(msg.Gas, refund)
 
-	if t.ctx.Tracer != nil {
-		t.ctx.Tracer.TxEnd(result.GasLeft)
-	}
-
 	// refund the sender
-	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), msg.GasPrice)
+	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
 	t.state.AddBalance(msg.From, remaining)
 
-	// pay the coinbase
-	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), msg.GasPrice)
+	// pay the coinbase, the base fee part of the gas price is burned after the EIP-1559 fork
+	coinbasePrice := gasPrice
+	if t.config.EIP1559 && t.ctx.BaseFee != nil {
+		coinbasePrice = new(big.Int).Sub(gasPrice, t.ctx.BaseFee)
+		if coinbasePrice.Sign() < 0 {
+			// the state transactions and the simulated executions without any gas price set
+			coinbasePrice.SetUint64(0)
+		}
+	}
+
+	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), coinbasePrice)
 	t.state.AddBalance(t.ctx.Coinbase, coinbaseFee)
 
+	if t.ctx.Tracer != nil {
+		t.ctx.Tracer.TxEnd(result.GasLeft)
+	}
+
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
 
 	return result, nil
 }
 
+// prepareAccessList resets the access list and warms up the sender, the destination,
+// the precompiles and the entries of the transaction access list (EIP-2929 and EIP-2930)
+func (t *Transition) prepareAccessList(msg *types.Transaction) {
+	t.state.ClearAccessList()
+	t.state.AddAddressToAccessList(msg.From)
+
+	if msg.To != nil {
+		t.state.AddAddressToAccessList(*msg.To)
+	}
+
+	for _, addr := range t.precompiles.Addresses(&t.config) {
+		t.state.AddAddressToAccessList(addr)
+	}
+
+	for _, tuple := range msg.AccessList {
+		t.state.AddAddressToAccessList(tuple.Address)
+
+		for _, key := range tuple.StorageKeys {
+			t.state.AddSlotToAccessList(tuple.Address, key)
+		}
+	}
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -732,7 +876,7 @@ func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
 	return false
 }
 
-func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) (result *runtime.ExecutionResult) {
 	gasLimit := c.Gas
 
 	if c.Depth > int(1024)+1 {
@@ -745,6 +889,11 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
+	// The created address stays warm even if the creation fails (EIP-2929)
+	if t.config.Berlin {
+		t.state.AddAddressToAccessList(c.Address)
+	}
+
 	// Check if there if there is a collision and the address already exists
 	if t.hasCodeOrNonce(c.Address) {
 		return &runtime.ExecutionResult{
@@ -770,9 +919,12 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
-	var result *runtime.ExecutionResult
+	callType := runtime.Create
+	if c.Type == runtime.Create2 {
+		callType = runtime.Create2
+	}
 
-	t.captureCallStart(c, evm.CREATE)
+	t.captureCallStart(c, callType)
 
 	defer func() {
 		// pass result to be set later
@@ -907,7 +1059,7 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 }
 
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
-	if c.Type == runtime.Create {
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
 		return t.applyCreate(c, h)
 	}
 
@@ -959,6 +1111,22 @@ func (t *Transition) GetRefund() uint64 {
 	return t.state.GetRefund()
 }
 
+func (t *Transition) AddressInAccessList(addr types.Address) bool {
+	return t.state.AddressInAccessList(addr)
+}
+
+func (t *Transition) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	return t.state.SlotInAccessList(addr, slot)
+}
+
+func (t *Transition) AddAddressToAccessList(addr types.Address) {
+	t.state.AddAddressToAccessList(addr)
+}
+
+func (t *Transition) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	t.state.AddSlotToAccessList(addr, slot)
+}
+
 func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
 	cost := uint64(0)
 
@@ -999,20 +1167,37 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		cost += zeros * 4
 	}
 
+	if len(msg.AccessList) > 0 {
+		cost += uint64(len(msg.AccessList)) * TxAccessListAddressGas
+		cost += uint64(msg.AccessList.StorageKeys()) * TxAccessListStorageKeyGas
+	}
+
 	return cost, nil
 }
 
-// checkAndProcessLegacyTx - first check if this message satisfies all consensus rules before
+// checkAndProcessTx - first check if this message satisfies all consensus rules before
 // applying the message. The rules include these clauses:
 // 1. the nonce of the message caller is correct
-// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
-func checkAndProcessLegacyTx(msg *types.Transaction, t *Transition) error {
+// 2. the transaction type is supported by the active forks
+// 3. the gas fees are valid in regard to the block base fee (EIP-1559)
+// 4. caller has enough balance to cover transaction fee(gaslimit * gasprice)
+func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
 	// 1. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
 	}
 
-	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
+	// 2. the transaction type is supported by the active forks
+	if err := t.txTypeCheck(msg); err != nil {
+		return NewTransitionApplicationError(err, true)
+	}
+
+	// 3. the gas fees are valid in regard to the block base fee (EIP-1559)
+	if err := t.feeCheck(msg); err != nil {
+		return NewTransitionApplicationError(err, true)
+	}
+
+	// 4. caller has enough balance to cover transaction fee(gaslimit * gasprice)
 	if err := t.subGasLimitPrice(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
 	}
@@ -1058,14 +1243,25 @@ func (t *Transition) captureCallStart(c *runtime.Contract, callType runtime.Call
 		return
 	}
 
+	from, to, input := c.Caller, c.Address, c.Input
+
+	switch callType {
+	case runtime.CallCode, runtime.DelegateCall:
+		// the code of the target runs in the context of the calling contract
+		from, to = c.Address, c.CodeAddress
+	case runtime.Create, runtime.Create2:
+		// the input of a contract creation is its init code
+		input = c.Code
+	}
+
 	t.ctx.Tracer.CallStart(
 		c.Depth,
-		c.Caller,
-		c.Address,
+		from,
+		to,
 		int(callType),
 		c.Gas,
 		c.Value,
-		c.Input,
+		input,
 	)
 }
 
@@ -1075,9 +1271,15 @@ func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.Executi
 		return
 	}
 
+	var gasUsed uint64
+	if result.GasLeft < c.Gas {
+		gasUsed = c.Gas - result.GasLeft
+	}
+
 	t.ctx.Tracer.CallEnd(
 		c.Depth,
 		result.ReturnValue,
+		gasUsed,
 		result.Err,
 	)
 }
@@ -61,3 +61,32 @@ func TestOverride(t *testing.T) {
 	require.Equal(t, types.Hash{0x0}, tt.state.GetState(types.Address{0x1}, types.ZeroHash))
 	require.Equal(t, types.Hash{0x1}, tt.state.GetState(types.Address{0x1}, types.Hash{0x1}))
 }
+
+func TestTransition_RevertToCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	state := newStateWithPreState(map[types.Address]*PreState{
+		{0x1}: {
+			Balance: 1,
+		},
+	})
+
+	tt := NewTransition(chain.ForksInTime{}, state, newTxn(state))
+	tt.gasPool = 100
+
+	require.NoError(t, tt.WriteFailedReceipt(&types.Transaction{From: types.Address{0x1}}))
+
+	checkpoint := tt.Checkpoint()
+
+	tt.state.SetBalance(types.Address{0x1}, big.NewInt(2))
+	tt.gasPool, tt.totalGas = 50, 50
+	require.NoError(t, tt.WriteFailedReceipt(&types.Transaction{From: types.Address{0x1}}))
+
+	tt.RevertToCheckpoint(checkpoint)
+
+	// the changes made after the checkpoint are discarded
+	require.Equal(t, big.NewInt(1), tt.state.GetBalance(types.Address{0x1}))
+	require.Equal(t, uint64(100), tt.gasPool)
+	require.Equal(t, uint64(0), tt.TotalGas())
+	require.Len(t, tt.Receipts(), 1)
+}
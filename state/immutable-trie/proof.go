@@ -0,0 +1,197 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+var (
+	ErrNodeNotFound     = errors.New("trie node not found")
+	ErrInvalidProofNode = errors.New("invalid proof node")
+)
+
+// Prove returns the value stored under the key in the trie with the given root
+// together with its Merkle proof, the RLP encoded nodes on the path from the root to the key.
+// If the key is not in the trie, the value is nil and the proof proves its absence
+func Prove(root types.Hash, key []byte, storage Storage) ([]byte, [][]byte, error) {
+	proof := [][]byte{}
+
+	value, err := traverse(root, key, func(hash []byte) ([]byte, bool) {
+		data, ok := storage.Get(hash)
+		if !ok || len(data) == 0 {
+			return nil, false
+		}
+
+		proof = append(proof, append([]byte{}, data...))
+
+		return data, true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return value, proof, nil
+}
+
+// VerifyProof checks the Merkle proof of the key against the given root
+// and returns the value proven to be stored under the key (nil if the key is not in the trie)
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[types.BytesToHash(crypto.Keccak256(node))] = node
+	}
+
+	return traverse(root, key, func(hash []byte) ([]byte, bool) {
+		data, ok := nodes[types.BytesToHash(hash)]
+
+		return data, ok
+	})
+}
+
+// GetAccountProof returns the account stored under the address in the state with the given root
+// together with its Merkle proof. The account is nil if it doesn't exist
+func GetAccountProof(root types.Hash, addr types.Address, storage Storage) (*state.Account, [][]byte, error) {
+	data, proof, err := Prove(root, crypto.Keccak256(addr.Bytes()), storage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if data == nil {
+		return nil, proof, nil
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return nil, nil, err
+	}
+
+	return &account, proof, nil
+}
+
+// GetStorageProof returns the value of the slot in the account storage with the given root
+// together with its Merkle proof
+func GetStorageProof(storageRoot types.Hash, slot types.Hash, storage Storage) (types.Hash, [][]byte, error) {
+	data, proof, err := Prove(storageRoot, crypto.Keccak256(slot.Bytes()), storage)
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+
+	if data == nil {
+		return types.Hash{}, proof, nil
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+
+	value, err := v.Bytes()
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+
+	return types.BytesToHash(value), proof, nil
+}
+
+// traverse follows the key from the root node down to its value,
+// resolving the nodes referenced by their hash with the given function
+func traverse(root types.Hash, key []byte, resolve func(hash []byte) ([]byte, bool)) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	p := &fastrlp.Parser{}
+
+	resolveNode := func(hash []byte) (*fastrlp.Value, error) {
+		data, ok := resolve(hash)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, types.BytesToHash(hash))
+		}
+
+		v, err := p.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if v.Type() != fastrlp.TypeArray {
+			return nil, ErrInvalidProofNode
+		}
+
+		return v, nil
+	}
+
+	node, err := resolveNode(root.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	search := bytesToHexNibbles(key)
+
+	for {
+		var child *fastrlp.Value
+
+		switch node.Elems() {
+		case 2:
+			// short node, either a leaf or an extension
+			nodeKey := decodeCompact(node.Get(0).Raw())
+
+			if hasTerminator(nodeKey) {
+				if !bytes.Equal(nodeKey, search) {
+					return nil, nil
+				}
+
+				return append([]byte{}, node.Get(1).Raw()...), nil
+			}
+
+			if len(nodeKey) > len(search) || !bytes.Equal(nodeKey, search[:len(nodeKey)]) {
+				return nil, nil
+			}
+
+			search = search[len(nodeKey):]
+			child = node.Get(1)
+
+		case 17:
+			// full node
+			if search[0] == 16 {
+				value := node.Get(16).Raw()
+				if len(value) == 0 {
+					return nil, nil
+				}
+
+				return append([]byte{}, value...), nil
+			}
+
+			child = node.Get(int(search[0]))
+			search = search[1:]
+
+		default:
+			return nil, ErrInvalidProofNode
+		}
+
+		switch {
+		case child.Type() == fastrlp.TypeArray:
+			// the node is embedded in its parent
+			node = child
+
+		case len(child.Raw()) == 0:
+			return nil, nil
+
+		case len(child.Raw()) == types.HashLength:
+			if node, err = resolveNode(append([]byte{}, child.Raw()...)); err != nil {
+				return nil, err
+			}
+
+		default:
+			return nil, ErrInvalidProofNode
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+func TestProof_Trie(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	txn := NewTrie().Txn(storage)
+	txn.batch = storage
+
+	keys := make([][]byte, 100)
+
+	for i := range keys {
+		keys[i] = crypto.Keccak256(big.NewInt(int64(i)).Bytes())
+		txn.Insert(keys[i], big.NewInt(int64(i+1)).Bytes())
+	}
+
+	rootBytes, err := txn.Hash()
+	require.NoError(t, err)
+
+	root := types.BytesToHash(rootBytes)
+
+	for i, key := range keys {
+		value, proof, err := Prove(root, key, storage)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(int64(i+1)).Bytes(), value)
+		require.NotEmpty(t, proof)
+
+		verified, err := VerifyProof(root, key, proof)
+		require.NoError(t, err)
+		assert.Equal(t, value, verified)
+	}
+
+	// absence of a key can be proven as well
+	missingKey := crypto.Keccak256([]byte("missing"))
+
+	value, proof, err := Prove(root, missingKey, storage)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	require.NotEmpty(t, proof)
+
+	verified, err := VerifyProof(root, missingKey, proof)
+	require.NoError(t, err)
+	assert.Nil(t, verified)
+
+	// a proof with a missing node is rejected
+	_, proof, err = Prove(root, keys[0], storage)
+	require.NoError(t, err)
+
+	_, err = VerifyProof(root, keys[0], proof[:len(proof)-1])
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+
+	// a proof with a tampered node is rejected
+	proof[len(proof)-1] = append([]byte{}, proof[len(proof)-1]...)
+	proof[len(proof)-1][len(proof[len(proof)-1])-1]++
+
+	_, err = VerifyProof(root, keys[0], proof)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+func TestProof_EmptyTrie(t *testing.T) {
+	t.Parallel()
+
+	value, proof, err := Prove(types.EmptyRootHash, []byte{0x1}, NewMemoryStorage())
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Empty(t, proof)
+}
+
+func TestProof_AccountAndStorage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		slot  = types.StringToHash("1")
+	)
+
+	storage := NewMemoryStorage()
+	snap := NewState(storage).NewSnapshot()
+
+	_, root := snap.Commit([]*state.Object{
+		{
+			Address:  addr1,
+			Balance:  big.NewInt(100),
+			Nonce:    1,
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(emptyCodeHash),
+			Storage: []*state.StorageObject{
+				{Key: slot.Bytes(), Val: types.StringToHash("0x1234").Bytes()},
+			},
+		},
+		{
+			Address:  addr2,
+			Balance:  big.NewInt(200),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(emptyCodeHash),
+		},
+	})
+	stateRoot := types.BytesToHash(root)
+
+	account, accountProof, err := GetAccountProof(stateRoot, addr1, storage)
+	require.NoError(t, err)
+	require.NotNil(t, account)
+	assert.Equal(t, uint64(1), account.Nonce)
+	assert.Equal(t, big.NewInt(100), account.Balance)
+	assert.NotEqual(t, types.EmptyRootHash, account.Root)
+
+	verified, err := VerifyProof(stateRoot, crypto.Keccak256(addr1.Bytes()), accountProof)
+	require.NoError(t, err)
+	assert.Equal(t, account.MarshalWith(&fastrlp.Arena{}).MarshalTo(nil), verified)
+
+	value, storageProof, err := GetStorageProof(account.Root, slot, storage)
+	require.NoError(t, err)
+	assert.Equal(t, types.StringToHash("0x1234"), value)
+
+	verified, err = VerifyProof(account.Root, crypto.Keccak256(slot.Bytes()), storageProof)
+	require.NoError(t, err)
+	assert.NotNil(t, verified)
+
+	// missing slot
+	value, _, err = GetStorageProof(account.Root, types.StringToHash("2"), storage)
+	require.NoError(t, err)
+	assert.Equal(t, types.ZeroHash, value)
+
+	// missing account
+	account, accountProof, err = GetAccountProof(stateRoot, types.StringToAddress("3"), storage)
+	require.NoError(t, err)
+	assert.Nil(t, account)
+
+	verified, err = VerifyProof(stateRoot, crypto.Keccak256(types.StringToAddress("3").Bytes()), accountProof)
+	require.NoError(t, err)
+	assert.Nil(t, verified)
+
+	// unknown state root
+	_, _, err = GetAccountProof(types.StringToHash("0xff"), addr1, storage)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
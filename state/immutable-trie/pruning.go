@@ -0,0 +1,309 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// pruneBatchSize is the number of the deleted nodes written at once during the pruning
+const pruneBatchSize = 10000
+
+var (
+	// ErrStatePruned is an error returned when the requested state has been pruned
+	ErrStatePruned = errors.New("state pruned")
+	// ErrStorageNotPrunable is an error returned when the storage can't be pruned
+	ErrStorageNotPrunable = errors.New("storage doesn't support pruning")
+)
+
+// PrunableStorage is the trie storage whose nodes can be iterated and deleted
+type PrunableStorage interface {
+	Storage
+
+	// Delete removes the keys from the storage
+	Delete(keys ...[]byte) error
+
+	// ForEachKey calls the handler for each key in the storage, until the handler returns false
+	ForEachKey(handler func(k []byte) bool) error
+}
+
+// PruningStorage is the trie storage removing the nodes
+// which aren't reachable from the retained state roots
+type PruningStorage struct {
+	PrunableStorage
+
+	logger hclog.Logger
+
+	// pruneLock makes sure only one pruning runs at a time
+	pruneLock sync.Mutex
+
+	// writeLock guards the written keys and makes the checks of the deleted keys atomic with the writes
+	writeLock sync.Mutex
+
+	// written are the keys written since the start of the last pruning
+	written map[string]struct{}
+
+	// prevWritten are the keys written between the starts of the two last prunings.
+	// They are never pruned, as the state of a block which isn't part of the chain yet references them
+	prevWritten map[string]struct{}
+}
+
+// NewPruningStorage wraps the storage, so the state which isn't reachable anymore can be pruned
+func NewPruningStorage(storage Storage, logger hclog.Logger) (*PruningStorage, error) {
+	prunable, ok := storage.(PrunableStorage)
+	if !ok {
+		return nil, ErrStorageNotPrunable
+	}
+
+	return &PruningStorage{
+		PrunableStorage: prunable,
+		logger:          logger.Named("state-pruning"),
+		written:         make(map[string]struct{}),
+		prevWritten:     make(map[string]struct{}),
+	}, nil
+}
+
+func (s *PruningStorage) Put(k, v []byte) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	s.written[string(k)] = struct{}{}
+	s.PrunableStorage.Put(k, v)
+}
+
+func (s *PruningStorage) Batch() Batch {
+	return &pruningBatch{
+		storage: s,
+		batch:   s.PrunableStorage.Batch(),
+	}
+}
+
+// HasState checks whether the state with the given root hasn't been pruned
+func (s *PruningStorage) HasState(root types.Hash) bool {
+	if root == types.EmptyRootHash {
+		return true
+	}
+
+	data, ok := s.Get(root.Bytes())
+
+	return ok && len(data) > 0
+}
+
+// Prune removes all the trie nodes which aren't reachable from the given state roots,
+// except the ones written since the start of the previous pruning, and returns the number of the removed nodes
+func (s *PruningStorage) Prune(roots []types.Hash) (int, error) {
+	s.pruneLock.Lock()
+	defer s.pruneLock.Unlock()
+
+	s.writeLock.Lock()
+	s.prevWritten, s.written = s.written, make(map[string]struct{})
+	s.writeLock.Unlock()
+
+	marked := make(map[string]struct{})
+
+	for _, root := range roots {
+		if err := s.markTrie(root.Bytes(), false, marked); err != nil {
+			return 0, fmt.Errorf("failed to mark the state %s: %w", root, err)
+		}
+	}
+
+	pruned, candidates := 0, make([][]byte, 0, pruneBatchSize)
+
+	deleteCandidates := func() error {
+		s.writeLock.Lock()
+		defer s.writeLock.Unlock()
+
+		keys := make([][]byte, 0, len(candidates))
+
+		for _, k := range candidates {
+			// the nodes may have been written again in the meantime
+			if _, ok := s.written[string(k)]; ok {
+				continue
+			}
+
+			if _, ok := s.prevWritten[string(k)]; ok {
+				continue
+			}
+
+			keys = append(keys, k)
+		}
+
+		candidates = candidates[:0]
+
+		if len(keys) == 0 {
+			return nil
+		}
+
+		if err := s.PrunableStorage.Delete(keys...); err != nil {
+			return fmt.Errorf("failed to delete the pruned nodes: %w", err)
+		}
+
+		pruned += len(keys)
+
+		return nil
+	}
+
+	var deleteErr error
+
+	err := s.ForEachKey(func(k []byte) bool {
+		// only the trie nodes are pruned, the code is kept
+		if len(k) != types.HashLength {
+			return true
+		}
+
+		if _, ok := marked[string(k)]; ok {
+			return true
+		}
+
+		candidates = append(candidates, append([]byte{}, k...))
+		if len(candidates) == pruneBatchSize {
+			deleteErr = deleteCandidates()
+		}
+
+		return deleteErr == nil
+	})
+	if err != nil {
+		return pruned, err
+	}
+
+	if deleteErr != nil {
+		return pruned, deleteErr
+	}
+
+	if err := deleteCandidates(); err != nil {
+		return pruned, err
+	}
+
+	s.logger.Debug("state pruned", "roots", len(roots), "retained", len(marked), "pruned", pruned)
+
+	return pruned, nil
+}
+
+// markTrie marks all the nodes of the trie with the given root,
+// including the storage tries of the accounts if it's the state trie
+func (s *PruningStorage) markTrie(root []byte, isStorage bool, marked map[string]struct{}) error {
+	if bytes.Equal(root, types.EmptyRootHash.Bytes()) {
+		return nil
+	}
+
+	return s.markNode(root, isStorage, marked, &fastrlp.Parser{})
+}
+
+// markNode marks the node with the given hash and all its descendants
+func (s *PruningStorage) markNode(
+	hash []byte,
+	isStorage bool,
+	marked map[string]struct{},
+	p *fastrlp.Parser,
+) error {
+	if _, ok := marked[string(hash)]; ok {
+		// the node and its descendants are already marked
+		return nil
+	}
+
+	data, ok := s.Get(hash)
+	if !ok || len(data) == 0 {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, types.BytesToHash(hash))
+	}
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	marked[string(hash)] = struct{}{}
+
+	// the parsed value is only valid until the parser is used again
+	return s.markValue(v, isStorage, marked)
+}
+
+// markValue marks the descendants of the RLP encoded node
+func (s *PruningStorage) markValue(v *fastrlp.Value, isStorage bool, marked map[string]struct{}) error {
+	switch v.Elems() {
+	case 2:
+		// short node, either a leaf or an extension
+		if hasTerminator(decodeCompact(v.Get(0).Raw())) {
+			return s.markLeaf(v.Get(1).Raw(), isStorage, marked)
+		}
+
+		return s.markChild(v.Get(1), isStorage, marked)
+
+	case 17:
+		// full node
+		for i := 0; i < 16; i++ {
+			if err := s.markChild(v.Get(i), isStorage, marked); err != nil {
+				return err
+			}
+		}
+
+		if value := v.Get(16).Raw(); len(value) > 0 {
+			return s.markLeaf(value, isStorage, marked)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("node has incorrect number of leafs")
+	}
+}
+
+// markChild marks the node referenced by its parent, either embedded or by its hash
+func (s *PruningStorage) markChild(child *fastrlp.Value, isStorage bool, marked map[string]struct{}) error {
+	switch {
+	case child.Type() == fastrlp.TypeArray:
+		return s.markValue(child, isStorage, marked)
+
+	case len(child.Raw()) == 0:
+		return nil
+
+	case len(child.Raw()) == types.HashLength:
+		return s.markNode(append([]byte{}, child.Raw()...), isStorage, marked, &fastrlp.Parser{})
+
+	default:
+		return fmt.Errorf("invalid node reference")
+	}
+}
+
+// markLeaf marks the storage trie of the account stored in the leaf of the state trie
+func (s *PruningStorage) markLeaf(value []byte, isStorage bool, marked map[string]struct{}) error {
+	if isStorage {
+		return nil
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(value); err != nil {
+		return err
+	}
+
+	return s.markTrie(account.Root.Bytes(), true, marked)
+}
+
+// pruningBatch is the batch write keeping track of the written keys
+type pruningBatch struct {
+	storage *PruningStorage
+	batch   Batch
+	keys    [][]byte
+}
+
+func (b *pruningBatch) Put(k, v []byte) {
+	b.keys = append(b.keys, append([]byte{}, k...))
+	b.batch.Put(k, v)
+}
+
+func (b *pruningBatch) Write() {
+	b.storage.writeLock.Lock()
+	defer b.storage.writeLock.Unlock()
+
+	for _, k := range b.keys {
+		b.storage.written[string(k)] = struct{}{}
+	}
+
+	b.batch.Write()
+}
@@ -0,0 +1,157 @@
+package itrie
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// commitTestState commits the accounts with the given balance and storage value on top of the root
+func commitTestState(t *testing.T, s *State, root types.Hash, value int64) types.Hash {
+	t.Helper()
+
+	snap, err := s.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	objs := make([]*state.Object, 0, 10)
+
+	for i := 0; i < 10; i++ {
+		objs = append(objs, &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(value),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(emptyCodeHash),
+			Storage: []*state.StorageObject{
+				{Key: types.StringToHash("1").Bytes(), Val: big.NewInt(value).Bytes()},
+				{Key: big.NewInt(value).Bytes(), Val: big.NewInt(value).Bytes()},
+			},
+		})
+	}
+
+	_, newRoot := snap.Commit(objs)
+
+	return types.BytesToHash(newRoot)
+}
+
+func TestPruningStorage_NotPrunable(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPruningStorage(&mockStorage{}, hclog.NewNullLogger())
+	assert.ErrorIs(t, err, ErrStorageNotPrunable)
+}
+
+func TestPruningStorage_Prune(t *testing.T) {
+	t.Parallel()
+
+	storage, err := NewPruningStorage(NewMemoryStorage(), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	s := NewState(storage)
+
+	roots := []types.Hash{types.EmptyRootHash}
+	for i := int64(1); i <= 5; i++ {
+		roots = append(roots, commitTestState(t, s, roots[len(roots)-1], i))
+	}
+
+	// the first pruning only removes the nodes written before the previous pruning, that is none
+	pruned, err := storage.Prune(roots[len(roots)-2:])
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+
+	for _, root := range roots {
+		assert.True(t, storage.HasState(root))
+	}
+
+	roots = append(roots, commitTestState(t, s, roots[len(roots)-1], 6))
+
+	// the second pruning removes the states written before the first one, except the retained ones
+	pruned, err = storage.Prune(roots[len(roots)-2:])
+	require.NoError(t, err)
+	assert.NotZero(t, pruned)
+
+	for _, root := range roots[1 : len(roots)-2] {
+		assert.False(t, storage.HasState(root))
+
+		_, err := s.NewSnapshotAt(root)
+		assert.ErrorIs(t, err, ErrStatePruned)
+	}
+
+	// the retained states are complete
+	for i, root := range roots[len(roots)-2:] {
+		value := int64(len(roots) - 2 + i)
+
+		snap, err := s.NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		for j := 0; j < 10; j++ {
+			account, err := snap.GetAccount(types.BytesToAddress(big.NewInt(int64(j + 1)).Bytes()))
+			require.NoError(t, err)
+			require.NotNil(t, account)
+			assert.Equal(t, big.NewInt(value), account.Balance)
+
+			_, _, err = GetStorageProof(account.Root, types.StringToHash("1"), storage)
+			require.NoError(t, err)
+		}
+	}
+
+	// the pruning is idempotent
+	pruned, err = storage.Prune(roots[len(roots)-2:])
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+}
+
+func TestPruningStorage_PruneDeleteError(t *testing.T) {
+	t.Parallel()
+
+	errDelete := errors.New("write failed")
+
+	storage, err := NewPruningStorage(&failingDeleteStorage{
+		PrunableStorage: NewMemoryStorage().(PrunableStorage), //nolint:forcetypeassert
+		err:             errDelete,
+	}, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	s := NewState(storage)
+
+	roots := []types.Hash{types.EmptyRootHash}
+	for i := int64(1); i <= 3; i++ {
+		roots = append(roots, commitTestState(t, s, roots[len(roots)-1], i))
+	}
+
+	// nothing is deleted by the first pruning
+	_, err = storage.Prune(roots[len(roots)-1:])
+	require.NoError(t, err)
+
+	roots = append(roots, commitTestState(t, s, roots[len(roots)-1], 4))
+
+	// the failed write of the deleted nodes is reported
+	pruned, err := storage.Prune(roots[len(roots)-1:])
+	assert.ErrorIs(t, err, errDelete)
+	assert.Zero(t, pruned)
+
+	for _, root := range roots {
+		assert.True(t, storage.HasState(root))
+	}
+}
+
+type mockStorage struct {
+	Storage
+}
+
+// failingDeleteStorage is the prunable storage whose deletes fail
+type failingDeleteStorage struct {
+	PrunableStorage
+
+	err error
+}
+
+func (s *failingDeleteStorage) Delete(keys ...[]byte) error {
+	return s.err
+}
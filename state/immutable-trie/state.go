@@ -57,6 +57,11 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 		return s.newTrie(), nil
 	}
 
+	// the cached trie may refer to the nodes which have been pruned
+	if pruning, ok := s.storage.(*PruningStorage); ok && !pruning.HasState(root) {
+		return nil, fmt.Errorf("%w: %s", ErrStatePruned, root)
+	}
+
 	tt, ok := s.cache.Get(root)
 	if ok {
 		t, ok := tt.(*Trie)
@@ -0,0 +1,371 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+var (
+	// ErrInvalidStateDump is an error returned when the imported state doesn't match its hashes
+	ErrInvalidStateDump = errors.New("invalid state dump")
+)
+
+// DumpAccount is the account of the flat state dump, along with its code and storage.
+// The account and its storage slots are keyed by the hashes, as they are stored in the trie
+type DumpAccount struct {
+	AddressHash types.Hash
+	Account     *state.Account
+	Code        []byte
+	Storage     []*DumpStorageEntry
+}
+
+// DumpStorageEntry is the storage slot of the flat state dump
+type DumpStorageEntry struct {
+	KeyHash types.Hash
+	// Value is the RLP encoded value, as stored in the trie
+	Value []byte
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (d *DumpAccount) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(d.AddressHash.Bytes()))
+	vv.Set(arena.NewCopyBytes(d.Account.MarshalWith(arena).MarshalTo(nil)))
+	vv.Set(arena.NewCopyBytes(d.Code))
+
+	storage := arena.NewArray()
+
+	for _, entry := range d.Storage {
+		vvv := arena.NewArray()
+		vvv.Set(arena.NewBytes(entry.KeyHash.Bytes()))
+		vvv.Set(arena.NewBytes(entry.Value))
+
+		storage.Set(vvv)
+	}
+
+	vv.Set(storage)
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (d *DumpAccount) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 4 {
+		return fmt.Errorf("incorrect number of elements to decode DumpAccount, expected 4 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetHash(d.AddressHash[:]); err != nil {
+		return err
+	}
+
+	accountBytes, err := elems[1].GetBytes(nil)
+	if err != nil {
+		return err
+	}
+
+	d.Account = &state.Account{}
+	if err := d.Account.UnmarshalRlp(accountBytes); err != nil {
+		return err
+	}
+
+	if d.Code, err = elems[2].GetBytes(nil); err != nil {
+		return err
+	}
+
+	storageElems, err := elems[3].GetElems()
+	if err != nil {
+		return err
+	}
+
+	d.Storage = make([]*DumpStorageEntry, len(storageElems))
+
+	for i, storageElem := range storageElems {
+		entryElems, err := storageElem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(entryElems) < 2 {
+			return fmt.Errorf("incorrect number of elements to decode DumpStorageEntry, expected 2 but found %d",
+				len(entryElems))
+		}
+
+		entry := &DumpStorageEntry{}
+
+		if err := entryElems[0].GetHash(entry.KeyHash[:]); err != nil {
+			return err
+		}
+
+		if entry.Value, err = entryElems[1].GetBytes(nil); err != nil {
+			return err
+		}
+
+		d.Storage[i] = entry
+	}
+
+	return nil
+}
+
+// DumpState walks the state with the given root and calls the handler
+// for each account, in the order of the address hashes
+func DumpState(root types.Hash, storage Storage, handler func(*DumpAccount) error) error {
+	return walkLeaves(root.Bytes(), storage, func(key, value []byte) error {
+		account := &state.Account{}
+		if err := account.UnmarshalRlp(value); err != nil {
+			return err
+		}
+
+		dump := &DumpAccount{
+			AddressHash: types.BytesToHash(key),
+			Account:     account,
+		}
+
+		if !bytes.Equal(account.CodeHash, emptyCodeHash) {
+			code, ok := storage.GetCode(types.BytesToHash(account.CodeHash))
+			if !ok {
+				return fmt.Errorf("code %s not found", types.BytesToHash(account.CodeHash))
+			}
+
+			dump.Code = code
+		}
+
+		err := walkLeaves(account.Root.Bytes(), storage, func(key, value []byte) error {
+			dump.Storage = append(dump.Storage, &DumpStorageEntry{
+				KeyHash: types.BytesToHash(key),
+				Value:   value,
+			})
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to dump the storage of %s: %w", dump.AddressHash, err)
+		}
+
+		return handler(dump)
+	})
+}
+
+// StateImporter rebuilds the state from the accounts of the flat state dump
+type StateImporter struct {
+	storage Storage
+	txn     *Txn
+}
+
+// NewStateImporter creates the importer writing the state to the given storage
+func NewStateImporter(storage Storage) *StateImporter {
+	return &StateImporter{
+		storage: storage,
+		txn:     NewTrie().Txn(storage),
+	}
+}
+
+// Import writes the storage and the code of the account and adds it to the state
+func (i *StateImporter) Import(account *DumpAccount) error {
+	// the storage is written right away, so only the account trie is kept in the memory
+	batch := i.storage.Batch()
+
+	storageTxn := NewTrie().Txn(i.storage)
+	storageTxn.batch = batch
+
+	for _, entry := range account.Storage {
+		storageTxn.Insert(entry.KeyHash.Bytes(), entry.Value)
+	}
+
+	storageRoot, err := storageTxn.Hash()
+	if err != nil {
+		return err
+	}
+
+	if types.BytesToHash(storageRoot) != account.Account.Root {
+		return fmt.Errorf("%w: storage root of %s mismatch", ErrInvalidStateDump, account.AddressHash)
+	}
+
+	batch.Write()
+
+	if !bytes.Equal(account.Account.CodeHash, emptyCodeHash) {
+		if !bytes.Equal(crypto.Keccak256(account.Code), account.Account.CodeHash) {
+			return fmt.Errorf("%w: code hash of %s mismatch", ErrInvalidStateDump, account.AddressHash)
+		}
+
+		i.storage.SetCode(types.BytesToHash(account.Account.CodeHash), account.Code)
+	}
+
+	i.txn.Insert(account.AddressHash.Bytes(), account.Account.MarshalWith(&fastrlp.Arena{}).MarshalTo(nil))
+
+	return nil
+}
+
+// Commit writes the state trie and returns its root
+func (i *StateImporter) Commit() (types.Hash, error) {
+	batch := i.storage.Batch()
+	i.txn.batch = batch
+
+	root, err := i.txn.Hash()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	batch.Write()
+
+	return types.BytesToHash(root), nil
+}
+
+// errStopWalk is returned by the leaf handler to stop walking the trie
+var errStopWalk = errors.New("stop walking the trie")
+
+// walkLeaves calls the handler for each leaf of the trie with the given root, in the order of the keys
+func walkLeaves(root []byte, storage Storage, handler func(key, value []byte) error) error {
+	return walkLeavesFrom(root, nil, storage, handler)
+}
+
+// walkLeavesFrom calls the handler for each leaf of the trie with the given root,
+// in the order of the keys, skipping the leaves whose keys are less than the origin.
+// The walk stops without an error once the handler returns errStopWalk
+func walkLeavesFrom(root, origin []byte, storage Storage, handler func(key, value []byte) error) error {
+	if bytes.Equal(root, types.EmptyRootHash.Bytes()) {
+		return nil
+	}
+
+	w := &leafWalker{
+		storage: storage,
+		handler: handler,
+	}
+
+	if len(origin) > 0 {
+		// the origin without the terminator, as the paths of the nodes
+		w.origin = bytesToHexNibbles(origin)
+		w.origin = w.origin[:len(w.origin)-1]
+	}
+
+	if err := w.walkNode(root, nil); err != nil && !errors.Is(err, errStopWalk) {
+		return err
+	}
+
+	return nil
+}
+
+// leafWalker walks the leaves of the trie in the order of the keys
+type leafWalker struct {
+	storage Storage
+	origin  []byte
+	handler func(key, value []byte) error
+}
+
+// skip returns true if all the keys under the given path of nibbles are less than the origin
+func (w *leafWalker) skip(path []byte) bool {
+	if len(path) > len(w.origin) {
+		return bytes.Compare(path[:len(w.origin)], w.origin) < 0
+	}
+
+	return bytes.Compare(path, w.origin[:len(path)]) < 0
+}
+
+// walkNode walks the leaves of the node with the given hash, located at the given path of nibbles
+func (w *leafWalker) walkNode(hash, path []byte) error {
+	data, ok := w.storage.Get(hash)
+	if !ok || len(data) == 0 {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, types.BytesToHash(hash))
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	return w.walkValue(v, path)
+}
+
+// walkValue walks the leaves of the RLP encoded node, located at the given path of nibbles
+func (w *leafWalker) walkValue(v *fastrlp.Value, path []byte) error {
+	switch v.Elems() {
+	case 2:
+		// short node, either a leaf or an extension
+		key := decodeCompact(v.Get(0).Raw())
+		if hasTerminator(key) {
+			return w.walkLeaf(joinNibbles(path, key[:len(key)-1]...), v.Get(1).Raw())
+		}
+
+		return w.walkChild(v.Get(1), joinNibbles(path, key...))
+
+	case 17:
+		// full node, its value goes before the children
+		if value := v.Get(16).Raw(); len(value) > 0 {
+			if err := w.walkLeaf(path, value); err != nil {
+				return err
+			}
+		}
+
+		for i := 0; i < 16; i++ {
+			if err := w.walkChild(v.Get(i), joinNibbles(path, byte(i))); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("node has incorrect number of leafs")
+	}
+}
+
+// walkChild walks the leaves of the node referenced by its parent, either embedded or by its hash
+func (w *leafWalker) walkChild(child *fastrlp.Value, path []byte) error {
+	if w.skip(path) {
+		return nil
+	}
+
+	switch {
+	case child.Type() == fastrlp.TypeArray:
+		return w.walkValue(child, path)
+
+	case len(child.Raw()) == 0:
+		return nil
+
+	case len(child.Raw()) == types.HashLength:
+		return w.walkNode(child.Raw(), path)
+
+	default:
+		return fmt.Errorf("invalid node reference")
+	}
+}
+
+// walkLeaf calls the handler with the key built from the path of nibbles and the copy of the value
+func (w *leafWalker) walkLeaf(path, value []byte) error {
+	if len(path)%2 != 0 {
+		return fmt.Errorf("leaf key has odd number of nibbles")
+	}
+
+	if w.skip(path) {
+		return nil
+	}
+
+	key := make([]byte, len(path)/2)
+	for i := range key {
+		key[i] = path[2*i]<<4 | path[2*i+1]
+	}
+
+	return w.handler(key, append([]byte{}, value...))
+}
+
+// joinNibbles returns the new path of nibbles, not sharing the memory with the given one
+func joinNibbles(path []byte, nibbles ...byte) []byte {
+	res := make([]byte, 0, len(path)+len(nibbles))
+	res = append(res, path...)
+
+	return append(res, nibbles...)
+}
@@ -0,0 +1,138 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+func TestStateDump_ExportImport(t *testing.T) {
+	t.Parallel()
+
+	code := []byte{0x60, 0x01, 0x60, 0x02}
+
+	storage := NewMemoryStorage()
+	objs := make([]*state.Object, 0, 20)
+
+	for i := 0; i < 20; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i * 100)),
+			Nonce:    uint64(i),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(emptyCodeHash),
+		}
+
+		if i%2 == 0 {
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.Code = code
+			obj.DirtyCode = true
+
+			for j := 0; j < i; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash(big.NewInt(int64(j)).Bytes()).Bytes(),
+					Val: types.BytesToHash(big.NewInt(int64(j + 1)).Bytes()).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, rootBytes := NewState(storage).NewSnapshot().Commit(objs)
+	root := types.BytesToHash(rootBytes)
+
+	var accounts []*DumpAccount
+
+	require.NoError(t, DumpState(root, storage, func(account *DumpAccount) error {
+		// the accounts go through the encoding, as in the snapshot file
+		data := account.MarshalRLPWith(&fastrlp.Arena{}).MarshalTo(nil)
+
+		decoded := &DumpAccount{}
+		if err := types.UnmarshalRlp(decoded.UnmarshalRLPFrom, data); err != nil {
+			return err
+		}
+
+		accounts = append(accounts, decoded)
+
+		return nil
+	}))
+	require.Len(t, accounts, len(objs))
+
+	// the accounts are ordered by the address hash
+	for i := 1; i < len(accounts); i++ {
+		assert.Less(t, accounts[i-1].AddressHash.String(), accounts[i].AddressHash.String())
+	}
+
+	newStorage := NewMemoryStorage()
+	importer := NewStateImporter(newStorage)
+
+	for _, account := range accounts {
+		require.NoError(t, importer.Import(account))
+	}
+
+	importedRoot, err := importer.Commit()
+	require.NoError(t, err)
+	assert.Equal(t, root, importedRoot)
+
+	snap, err := NewState(newStorage).NewSnapshotAt(importedRoot)
+	require.NoError(t, err)
+
+	for _, obj := range objs {
+		account, err := snap.GetAccount(obj.Address)
+		require.NoError(t, err)
+		require.NotNil(t, account)
+		assert.Equal(t, obj.Balance, account.Balance)
+		assert.Equal(t, obj.Nonce, account.Nonce)
+
+		for _, entry := range obj.Storage {
+			value := snap.GetStorage(obj.Address, account.Root, types.BytesToHash(entry.Key))
+			assert.Equal(t, types.BytesToHash(entry.Val), value)
+		}
+
+		if obj.DirtyCode {
+			importedCode, ok := snap.GetCode(obj.CodeHash)
+			require.True(t, ok)
+			assert.Equal(t, code, importedCode)
+		}
+	}
+}
+
+func TestStateDump_ImportInvalid(t *testing.T) {
+	t.Parallel()
+
+	account := &DumpAccount{
+		AddressHash: types.StringToHash("1"),
+		Account: &state.Account{
+			Balance:  big.NewInt(1),
+			Root:     types.StringToHash("2"),
+			CodeHash: emptyCodeHash,
+		},
+	}
+
+	// the storage doesn't match the storage root
+	assert.ErrorIs(t, NewStateImporter(NewMemoryStorage()).Import(account), ErrInvalidStateDump)
+
+	// the code doesn't match the code hash
+	account.Account.Root = types.EmptyRootHash
+	account.Account.CodeHash = crypto.Keccak256([]byte{0x1})
+	account.Code = []byte{0x2}
+
+	assert.ErrorIs(t, NewStateImporter(NewMemoryStorage()).Import(account), ErrInvalidStateDump)
+}
+
+func TestStateDump_MissingState(t *testing.T) {
+	t.Parallel()
+
+	err := DumpState(types.StringToHash("1"), NewMemoryStorage(), func(*DumpAccount) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+}
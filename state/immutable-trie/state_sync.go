@@ -0,0 +1,299 @@
+package itrie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// IterateRange calls the handler for each leaf of the trie with the given root,
+// in the order of the keys, starting from the origin, until the handler returns false
+func IterateRange(
+	root types.Hash,
+	origin []byte,
+	storage Storage,
+	handler func(key, value []byte) bool,
+) error {
+	return walkLeavesFrom(root.Bytes(), origin, storage, func(key, value []byte) error {
+		if !handler(key, value) {
+			return errStopWalk
+		}
+
+		return nil
+	})
+}
+
+// GetLeaf returns the value stored under the key in the trie with the given root,
+// nil if the key is not in the trie
+func GetLeaf(root types.Hash, key []byte, storage Storage) ([]byte, error) {
+	return traverse(root, key, func(hash []byte) ([]byte, bool) {
+		data, ok := storage.Get(hash)
+
+		return data, ok && len(data) > 0
+	})
+}
+
+// ProveRange returns the Merkle proofs of the first and the last keys of the range,
+// merged into the single set of the nodes
+func ProveRange(root types.Hash, first, last []byte, storage Storage) ([][]byte, error) {
+	_, proof, err := Prove(root, first, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if last == nil || bytes.Equal(first, last) {
+		return proof, nil
+	}
+
+	_, lastProof, err := Prove(root, last, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[types.Hash]struct{}, len(proof))
+	for _, node := range proof {
+		known[types.BytesToHash(crypto.Keccak256(node))] = struct{}{}
+	}
+
+	for _, node := range lastProof {
+		if _, ok := known[types.BytesToHash(crypto.Keccak256(node))]; !ok {
+			proof = append(proof, node)
+		}
+	}
+
+	return proof, nil
+}
+
+// TrieBuilder writes the trie built from its leaves, e.g. downloaded from the peers
+type TrieBuilder struct {
+	storage Storage
+	txn     *Txn
+}
+
+// NewTrieBuilder creates the builder writing the trie to the given storage
+func NewTrieBuilder(storage Storage) *TrieBuilder {
+	return &TrieBuilder{
+		storage: storage,
+		txn:     NewTrie().Txn(storage),
+	}
+}
+
+// Insert adds the leaf to the trie
+func (b *TrieBuilder) Insert(key, value []byte) {
+	b.txn.Insert(key, value)
+}
+
+// Commit writes the trie and returns its root
+func (b *TrieBuilder) Commit() (types.Hash, error) {
+	batch := b.storage.Batch()
+	b.txn.batch = batch
+
+	root, err := b.txn.Hash()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	batch.Write()
+
+	return types.BytesToHash(root), nil
+}
+
+// StateHealer looks for the trie nodes and the codes of the state missing in the storage,
+// so they can be downloaded from the peers until the state is complete
+type StateHealer struct {
+	storage Storage
+
+	// stack is the list of the nodes to check, the walk goes depth first to keep it short
+	stack []healTask
+
+	// missingNodes are the missing trie nodes, along with the type of their trie
+	missingNodes map[types.Hash]bool
+	missingCodes map[types.Hash]struct{}
+
+	// checkedRoots are the storage roots which are checked already, shared by many accounts
+	checkedRoots map[types.Hash]struct{}
+}
+
+// healTask is the trie node to check
+type healTask struct {
+	hash    types.Hash
+	account bool // the node belongs to the account trie
+}
+
+// NewStateHealer creates the healer of the state with the given root
+func NewStateHealer(root types.Hash, storage Storage) *StateHealer {
+	h := &StateHealer{
+		storage:      storage,
+		missingNodes: map[types.Hash]bool{},
+		missingCodes: map[types.Hash]struct{}{},
+		checkedRoots: map[types.Hash]struct{}{},
+	}
+
+	if root != types.EmptyRootHash {
+		h.stack = append(h.stack, healTask{hash: root, account: true})
+	}
+
+	return h
+}
+
+// Missing walks the state and returns the hashes of the missing trie nodes and codes,
+// up to the given number. Nothing is returned once the state is complete
+func (h *StateHealer) Missing(max int) ([]types.Hash, []types.Hash, error) {
+	for len(h.stack) > 0 && len(h.missingNodes)+len(h.missingCodes) < max {
+		task := h.stack[len(h.stack)-1]
+		h.stack = h.stack[:len(h.stack)-1]
+
+		data, ok := h.storage.Get(task.hash.Bytes())
+		if !ok || len(data) == 0 {
+			h.missingNodes[task.hash] = task.account
+
+			continue
+		}
+
+		if err := h.checkNode(task, data); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	nodes := make([]types.Hash, 0, len(h.missingNodes))
+	for hash := range h.missingNodes {
+		nodes = append(nodes, hash)
+	}
+
+	codes := make([]types.Hash, 0, len(h.missingCodes))
+	for hash := range h.missingCodes {
+		codes = append(codes, hash)
+	}
+
+	return nodes, codes, nil
+}
+
+// AddNode writes the missing trie node, its children are checked in the next walk
+func (h *StateHealer) AddNode(hash types.Hash, data []byte) error {
+	account, ok := h.missingNodes[hash]
+	if !ok {
+		return fmt.Errorf("trie node %s isn't missing", hash)
+	}
+
+	if types.BytesToHash(crypto.Keccak256(data)) != hash {
+		return fmt.Errorf("%w: hash of trie node %s mismatch", ErrInvalidProofNode, hash)
+	}
+
+	h.storage.Put(hash.Bytes(), data)
+
+	delete(h.missingNodes, hash)
+	h.stack = append(h.stack, healTask{hash: hash, account: account})
+
+	return nil
+}
+
+// AddCode writes the missing code
+func (h *StateHealer) AddCode(hash types.Hash, code []byte) error {
+	if _, ok := h.missingCodes[hash]; !ok {
+		return fmt.Errorf("code %s isn't missing", hash)
+	}
+
+	if types.BytesToHash(crypto.Keccak256(code)) != hash {
+		return fmt.Errorf("%w: code hash %s mismatch", ErrInvalidStateDump, hash)
+	}
+
+	h.storage.SetCode(hash, code)
+
+	delete(h.missingCodes, hash)
+
+	return nil
+}
+
+// checkNode queues the children of the RLP encoded trie node
+func (h *StateHealer) checkNode(task healTask, data []byte) error {
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	return h.checkValue(task, v)
+}
+
+// checkValue queues the children of the trie node and checks the accounts of its leaves
+func (h *StateHealer) checkValue(task healTask, v *fastrlp.Value) error {
+	switch v.Elems() {
+	case 2:
+		// short node, either a leaf or an extension
+		if hasTerminator(decodeCompact(v.Get(0).Raw())) {
+			return h.checkLeaf(task, v.Get(1).Raw())
+		}
+
+		return h.checkChild(task, v.Get(1))
+
+	case 17:
+		for i := 0; i < 16; i++ {
+			if err := h.checkChild(task, v.Get(i)); err != nil {
+				return err
+			}
+		}
+
+		if value := v.Get(16).Raw(); len(value) > 0 {
+			return h.checkLeaf(task, value)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("node has incorrect number of leafs")
+	}
+}
+
+// checkChild queues the node referenced by its parent, the embedded nodes are checked right away
+func (h *StateHealer) checkChild(task healTask, child *fastrlp.Value) error {
+	switch {
+	case child.Type() == fastrlp.TypeArray:
+		return h.checkValue(task, child)
+
+	case len(child.Raw()) == 0:
+		return nil
+
+	case len(child.Raw()) == types.HashLength:
+		h.stack = append(h.stack, healTask{hash: types.BytesToHash(child.Raw()), account: task.account})
+
+		return nil
+
+	default:
+		return fmt.Errorf("invalid node reference")
+	}
+}
+
+// checkLeaf queues the storage root and checks the code of the account stored in the leaf
+func (h *StateHealer) checkLeaf(task healTask, value []byte) error {
+	if !task.account {
+		return nil
+	}
+
+	account := &state.Account{}
+	if err := account.UnmarshalRlp(value); err != nil {
+		return err
+	}
+
+	if account.Root != types.EmptyRootHash {
+		if _, ok := h.checkedRoots[account.Root]; !ok {
+			h.checkedRoots[account.Root] = struct{}{}
+			h.stack = append(h.stack, healTask{hash: account.Root})
+		}
+	}
+
+	if !bytes.Equal(account.CodeHash, emptyCodeHash) {
+		codeHash := types.BytesToHash(account.CodeHash)
+
+		if _, ok := h.storage.GetCode(codeHash); !ok {
+			h.missingCodes[codeHash] = struct{}{}
+		}
+	}
+
+	return nil
+}
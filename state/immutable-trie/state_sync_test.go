@@ -0,0 +1,185 @@
+package itrie
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/state"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// newTestSyncState writes the state of the given number of accounts,
+// every other one with the code and the storage
+func newTestSyncState(t *testing.T, accounts int) (types.Hash, *memStorage) {
+	t.Helper()
+
+	code := []byte{0x60, 0x01, 0x60, 0x02}
+	storage, ok := NewMemoryStorage().(*memStorage)
+	require.True(t, ok)
+
+	objs := make([]*state.Object, 0, accounts)
+
+	for i := 0; i < accounts; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i + 1)),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.BytesToHash(emptyCodeHash),
+		}
+
+		if i%2 == 0 {
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.Code = code
+			obj.DirtyCode = true
+
+			for j := 0; j < 10; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash(big.NewInt(int64(j)).Bytes()).Bytes(),
+					Val: types.BytesToHash(big.NewInt(int64(i + j + 1)).Bytes()).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root := NewState(storage).NewSnapshot().Commit(objs)
+
+	return types.BytesToHash(root), storage
+}
+
+func TestIterateRange(t *testing.T) {
+	t.Parallel()
+
+	root, storage := newTestSyncState(t, 50)
+
+	var all [][]byte
+
+	require.NoError(t, IterateRange(root, nil, storage, func(key, value []byte) bool {
+		all = append(all, key)
+
+		return true
+	}))
+	require.Len(t, all, 50)
+
+	t.Run("should start from the origin", func(t *testing.T) {
+		t.Parallel()
+
+		for _, origin := range [][]byte{all[10], incKey(all[10])} {
+			var keys [][]byte
+
+			require.NoError(t, IterateRange(root, origin, storage, func(key, value []byte) bool {
+				keys = append(keys, key)
+
+				return true
+			}))
+
+			require.NotEmpty(t, keys)
+			assert.True(t, bytes.Compare(keys[0], origin) >= 0)
+			assert.Equal(t, all[len(all)-len(keys):], keys)
+		}
+	})
+
+	t.Run("should stop when the handler returns false", func(t *testing.T) {
+		t.Parallel()
+
+		var keys [][]byte
+
+		require.NoError(t, IterateRange(root, nil, storage, func(key, value []byte) bool {
+			keys = append(keys, key)
+
+			return len(keys) < 5
+		}))
+
+		assert.Equal(t, all[:5], keys)
+	})
+
+	t.Run("should prove the range", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := ProveRange(root, all[3], all[20], storage)
+		require.NoError(t, err)
+
+		for _, key := range [][]byte{all[3], all[20]} {
+			value, err := VerifyProof(root, key, proof)
+			require.NoError(t, err)
+
+			expected, err := GetLeaf(root, key, storage)
+			require.NoError(t, err)
+			assert.Equal(t, expected, value)
+		}
+	})
+}
+
+func TestStateHealer(t *testing.T) {
+	t.Parallel()
+
+	root, source := newTestSyncState(t, 30)
+
+	// only the part of the trie nodes is available locally
+	target, ok := NewMemoryStorage().(*memStorage)
+	require.True(t, ok)
+
+	i := 0
+
+	for key, value := range source.db {
+		if i%3 == 0 {
+			target.db[key] = value
+		}
+
+		i++
+	}
+
+	healer := NewStateHealer(root, target)
+
+	for {
+		nodes, codes, err := healer.Missing(8)
+		require.NoError(t, err)
+
+		if len(nodes) == 0 && len(codes) == 0 {
+			break
+		}
+
+		for _, hash := range nodes {
+			node, ok := source.Get(hash.Bytes())
+			require.True(t, ok)
+			require.NoError(t, healer.AddNode(hash, node))
+		}
+
+		for _, hash := range codes {
+			code, ok := source.GetCode(hash)
+			require.True(t, ok)
+			require.NoError(t, healer.AddCode(hash, code))
+		}
+	}
+
+	// the healed state is complete
+	require.NoError(t, DumpState(root, target, func(*DumpAccount) error {
+		return nil
+	}))
+
+	t.Run("should not accept the invalid node", func(t *testing.T) {
+		t.Parallel()
+
+		healer := NewStateHealer(root, NewMemoryStorage())
+
+		nodes, _, err := healer.Missing(8)
+		require.NoError(t, err)
+		require.Equal(t, []types.Hash{root}, nodes)
+
+		assert.ErrorIs(t, healer.AddNode(root, []byte{0x1}), ErrInvalidProofNode)
+		assert.Error(t, healer.AddNode(types.StringToHash("1"), []byte{0x1}))
+	})
+}
+
+// incKey returns the copy of the key increased by one
+func incKey(key []byte) []byte {
+	next := new(big.Int).Add(new(big.Int).SetBytes(key), big.NewInt(1))
+
+	return types.BytesToHash(next.Bytes()).Bytes()
+}
@@ -82,6 +82,30 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool) {
 	return data, true
 }
 
+// Delete removes the keys from the storage
+func (kv *KVStorage) Delete(keys ...[]byte) error {
+	batch := &leveldb.Batch{}
+	for _, k := range keys {
+		batch.Delete(k)
+	}
+
+	return kv.db.Write(batch, nil)
+}
+
+// ForEachKey calls the handler for each key in the storage, until the handler returns false
+func (kv *KVStorage) ForEachKey(handler func(k []byte) bool) error {
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !handler(iter.Key()) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -152,6 +176,42 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db, l: new(sync.Mutex)}
 }
 
+// Delete removes the keys from the storage
+func (m *memStorage) Delete(keys ...[]byte) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	for _, k := range keys {
+		delete(m.db, hex.EncodeToHex(k))
+	}
+
+	return nil
+}
+
+// ForEachKey calls the handler for each key in the storage, until the handler returns false
+func (m *memStorage) ForEachKey(handler func(k []byte) bool) error {
+	m.l.Lock()
+	keys := make([]string, 0, len(m.db))
+
+	for k := range m.db {
+		keys = append(keys, k)
+	}
+	m.l.Unlock()
+
+	for _, k := range keys {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			return err
+		}
+
+		if !handler(key) {
+			break
+		}
+	}
+
+	return nil
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
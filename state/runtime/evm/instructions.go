@@ -459,13 +459,50 @@ func opMStore8(c *state) {
 	c.memory[offset.Uint64()] = byte(val.Uint64() & 0xff)
 }
 
+// --- access lists ---
+
+// eip-2929 gas costs
+const (
+	coldAccountAccessCost uint64 = 2600
+	coldSloadCost         uint64 = 2100
+	warmStorageReadCost   uint64 = 100
+)
+
+// addressAccessCost warms up the address and returns the cost of accessing it
+func (c *state) addressAccessCost(addr types.Address) uint64 {
+	if c.host.AddressInAccessList(addr) {
+		return warmStorageReadCost
+	}
+
+	c.host.AddAddressToAccessList(addr)
+
+	return coldAccountAccessCost
+}
+
+// warmUpSlot warms up the storage slot of the current contract and returns true if it was cold
+func (c *state) warmUpSlot(slot types.Hash) bool {
+	if _, slotOk := c.host.SlotInAccessList(c.msg.Address, slot); slotOk {
+		return false
+	}
+
+	c.host.AddSlotToAccessList(c.msg.Address, slot)
+
+	return true
+}
+
 // --- storage ---
 
 func opSload(c *state) {
 	loc := c.top()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = warmStorageReadCost
+		if c.warmUpSlot(bigToHash(loc)) {
+			gas = coldSloadCost
+		}
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 800
 	} else if c.config.EIP150 {
@@ -500,38 +537,39 @@ func opSStore(c *state) {
 
 	legacyGasMetering := !c.config.Istanbul && (c.config.Petersburg || !c.config.Constantinople)
 
-	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
 	cost := uint64(0)
 
+	// eip-2929
+	if c.config.Berlin && c.warmUpSlot(key) {
+		cost = coldSloadCost
+	}
+
+	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
+
 	switch status {
-	case runtime.StorageUnchanged:
-		if c.config.Istanbul {
+	case runtime.StorageUnchanged, runtime.StorageModifiedAgain:
+		if c.config.Berlin {
+			// eip-2929
+			cost += warmStorageReadCost
+		} else if c.config.Istanbul {
 			// eip-2200
-			cost = 800
+			cost += 800
 		} else if legacyGasMetering {
-			cost = 5000
+			cost += 5000
 		} else {
-			cost = 200
+			cost += 200
 		}
 
-	case runtime.StorageModified:
-		cost = 5000
-
-	case runtime.StorageModifiedAgain:
-		if c.config.Istanbul {
-			// eip-2200
-			cost = 800
-		} else if legacyGasMetering {
-			cost = 5000
+	case runtime.StorageModified, runtime.StorageDeleted:
+		if c.config.Berlin {
+			// eip-2929
+			cost += 5000 - coldSloadCost
 		} else {
-			cost = 200
+			cost += 5000
 		}
 
 	case runtime.StorageAdded:
-		cost = 20000
-
-	case runtime.StorageDeleted:
-		cost = 5000
+		cost += 20000
 	}
 
 	if !c.consumeGas(cost) {
@@ -575,7 +613,10 @@ func opBalance(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(addr)
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 700
 	} else if c.config.EIP150 {
@@ -611,6 +652,20 @@ func opChainID(c *state) {
 	c.push1().SetUint64(uint64(c.host.GetTxContext().ChainID))
 }
 
+func opBaseFee(c *state) {
+	if !c.config.EIP1559 {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	if baseFee := c.host.GetTxContext().BaseFee; baseFee != nil {
+		c.push1().Set(baseFee)
+	} else {
+		c.push1().SetUint64(0)
+	}
+}
+
 func opOrigin(c *state) {
 	c.push1().SetBytes(c.host.GetTxContext().Origin.Bytes())
 }
@@ -658,7 +713,10 @@ func opExtCodeSize(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(addr)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -693,7 +751,10 @@ func opExtCodeHash(c *state) {
 	address, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(address)
+	} else if c.config.Istanbul {
 		gas = 700
 	} else {
 		gas = 400
@@ -767,7 +828,10 @@ func opExtCodeCopy(c *state) {
 	}
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(address)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -933,6 +997,13 @@ func opSelfDestruct(c *state) {
 		}
 	}
 
+	// eip-2929
+	if c.config.Berlin && !c.host.AddressInAccessList(address) {
+		c.host.AddAddressToAccessList(address)
+
+		gas += coldAccountAccessCost
+	}
+
 	if !c.consumeGas(gas) {
 		return
 	}
@@ -1084,6 +1155,9 @@ func opCreate(op OpCode) instruction {
 		}
 
 		contract.Type = runtime.Create
+		if op == CREATE2 {
+			contract.Type = runtime.Create2
+		}
 
 		// Correct call
 		result := c.host.Callx(contract, c.host)
@@ -1214,7 +1288,10 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 	}
 
 	var gasCost uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gasCost = c.addressAccessCost(addr)
+	} else if c.config.EIP150 {
 		gasCost = 700
 	} else {
 		gasCost = 40
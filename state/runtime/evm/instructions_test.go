@@ -106,6 +106,9 @@ type mockHostForInstructions struct {
 	nonce       uint64
 	code        []byte
 	callxResult *runtime.ExecutionResult
+	txContext   runtime.TxContext
+	accessList  map[types.Address]map[types.Hash]struct{}
+	storage     map[types.Hash]types.Hash
 }
 
 func (m *mockHostForInstructions) GetNonce(types.Address) uint64 {
@@ -120,6 +123,71 @@ func (m *mockHostForInstructions) GetCode(addr types.Address) []byte {
 	return m.code
 }
 
+func (m *mockHostForInstructions) GetTxContext() runtime.TxContext {
+	return m.txContext
+}
+
+func (m *mockHostForInstructions) GetBalance(types.Address) *big.Int {
+	return big.NewInt(0)
+}
+
+func (m *mockHostForInstructions) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockHostForInstructions) SetStorage(
+	_ types.Address,
+	key types.Hash,
+	value types.Hash,
+	_ *chain.ForksInTime,
+) runtime.StorageStatus {
+	if m.storage == nil {
+		m.storage = map[types.Hash]types.Hash{}
+	}
+
+	oldValue := m.storage[key]
+	m.storage[key] = value
+
+	switch {
+	case oldValue == value:
+		return runtime.StorageUnchanged
+	case oldValue == types.ZeroHash:
+		return runtime.StorageAdded
+	case value == types.ZeroHash:
+		return runtime.StorageDeleted
+	default:
+		return runtime.StorageModified
+	}
+}
+
+func (m *mockHostForInstructions) AddressInAccessList(addr types.Address) bool {
+	_, ok := m.accessList[addr]
+
+	return ok
+}
+
+func (m *mockHostForInstructions) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	slots, addressOk := m.accessList[addr]
+	_, slotOk := slots[slot]
+
+	return addressOk, slotOk
+}
+
+func (m *mockHostForInstructions) AddAddressToAccessList(addr types.Address) {
+	if m.accessList == nil {
+		m.accessList = map[types.Address]map[types.Hash]struct{}{}
+	}
+
+	if _, ok := m.accessList[addr]; !ok {
+		m.accessList[addr] = map[types.Hash]struct{}{}
+	}
+}
+
+func (m *mockHostForInstructions) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	m.AddAddressToAccessList(addr)
+	m.accessList[addr][slot] = struct{}{}
+}
+
 var (
 	addr1 = types.StringToAddress("1")
 )
@@ -650,6 +718,10 @@ func Test_opCall(t *testing.T) {
 				callxResult: &runtime.ExecutionResult{
 					ReturnValue: []byte{0x03},
 				},
+				// the called address is warm, so the call fits into the available gas
+				accessList: map[types.Address]map[types.Hash]struct{}{
+					types.ZeroAddress: {},
+				},
 			},
 		},
 	}
@@ -677,3 +749,91 @@ func Test_opCall(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseFee(t *testing.T) {
+	t.Run("EIP-1559 enabled", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &allEnabledForks
+		s.host = &mockHostForInstructions{
+			txContext: runtime.TxContext{BaseFee: big.NewInt(1000)},
+		}
+
+		opBaseFee(s)
+
+		assert.False(t, s.stop)
+		assert.Equal(t, big.NewInt(1000), s.pop())
+	})
+
+	t.Run("EIP-1559 disabled", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+		s.host = &mockHostForInstructions{}
+
+		opBaseFee(s)
+
+		assert.True(t, s.stop)
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
+
+func TestAccessListGas(t *testing.T) {
+	berlinForks := chain.AllForksEnabled.At(0)
+	istanbulForks := chain.AllForksEnabled.At(0)
+	istanbulForks.Berlin = false
+	istanbulForks.London = false
+	istanbulForks.EIP1559 = false
+
+	runOp := func(config *chain.ForksInTime, host runtime.Host, op instruction, args ...*big.Int) uint64 {
+		t.Helper()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.gas = 100000
+		s.config = config
+		s.host = host
+		s.msg = &runtime.Contract{Address: addr1}
+
+		for i := len(args) - 1; i >= 0; i-- {
+			s.push(args[i])
+		}
+
+		op(s)
+
+		assert.False(t, s.stop)
+
+		return 100000 - s.gas
+	}
+
+	t.Run("SLOAD", func(t *testing.T) {
+		host := &mockHostForInstructions{}
+
+		assert.Equal(t, coldSloadCost, runOp(&berlinForks, host, opSload, big.NewInt(1)))
+		assert.Equal(t, warmStorageReadCost, runOp(&berlinForks, host, opSload, big.NewInt(1)))
+		assert.Equal(t, uint64(800), runOp(&istanbulForks, &mockHostForInstructions{}, opSload, big.NewInt(1)))
+	})
+
+	t.Run("SSTORE", func(t *testing.T) {
+		host := &mockHostForInstructions{}
+
+		// cold slot that gets created
+		assert.Equal(t, coldSloadCost+20000, runOp(&berlinForks, host, opSStore, big.NewInt(1), big.NewInt(1)))
+		// warm slot that gets modified
+		assert.Equal(t, uint64(2900), runOp(&berlinForks, host, opSStore, big.NewInt(1), big.NewInt(2)))
+		// warm slot that is unchanged
+		assert.Equal(t, warmStorageReadCost, runOp(&berlinForks, host, opSStore, big.NewInt(1), big.NewInt(2)))
+	})
+
+	t.Run("BALANCE", func(t *testing.T) {
+		host := &mockHostForInstructions{}
+
+		assert.Equal(t, coldAccountAccessCost, runOp(&berlinForks, host, opBalance, big.NewInt(2)))
+		assert.Equal(t, warmStorageReadCost, runOp(&berlinForks, host, opBalance, big.NewInt(2)))
+		assert.True(t, host.AddressInAccessList(types.StringToAddress("2")))
+		assert.Equal(t, uint64(700), runOp(&istanbulForks, &mockHostForInstructions{}, opBalance, big.NewInt(2)))
+	})
+}
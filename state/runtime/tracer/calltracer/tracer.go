@@ -0,0 +1,346 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/vishnushankarsg/metad/helper/hex"
+	"github.com/vishnushankarsg/metad/state/runtime"
+	"github.com/vishnushankarsg/metad/state/runtime/evm"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// maxLogDataSize bounds the data of a captured log,
+// the memory expansion for larger logs can't be paid for
+const maxLogDataSize = 1 << 25
+
+type Config struct {
+	OnlyTopCall bool `json:"onlyTopCall"` // trace only the top-level call
+	WithLog     bool `json:"withLog"`     // include the logs emitted by the calls
+}
+
+// CallLog is a log emitted during a call
+type CallLog struct {
+	Address types.Address `json:"address"`
+	Topics  []types.Hash  `json:"topics"`
+	Data    string        `json:"data"`
+}
+
+// CallFrame is a call made during the transaction execution,
+// together with the calls it made itself
+type CallFrame struct {
+	Type         string         `json:"type"`
+	From         types.Address  `json:"from"`
+	To           *types.Address `json:"to,omitempty"`
+	Value        string         `json:"value,omitempty"`
+	Gas          string         `json:"gas"`
+	GasUsed      string         `json:"gasUsed"`
+	Input        string         `json:"input"`
+	Output       string         `json:"output,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	Calls        []*CallFrame   `json:"calls,omitempty"`
+	Logs         []*CallLog     `json:"logs,omitempty"`
+}
+
+// clearLogs removes the logs of the frame and of all its sub calls,
+// as the logs of a failed call are discarded
+func (f *CallFrame) clearLogs() {
+	f.Logs = nil
+
+	for _, call := range f.Calls {
+		call.clearLogs()
+	}
+}
+
+// CallTracer builds the tree of the calls made during the transaction execution
+type CallTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	gasLimit  uint64
+	depth     int
+	callstack []*CallFrame
+
+	// selfdestruct is the self destruction captured before its execution
+	selfdestruct *CallFrame
+}
+
+func NewCallTracer(config Config) *CallTracer {
+	return &CallTracer{
+		Config:     config,
+		cancelLock: sync.RWMutex{},
+	}
+}
+
+func (t *CallTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *CallTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *CallTracer) Clear() {
+	t.reason = nil
+	t.interrupt = false
+	t.gasLimit = 0
+	t.depth = 0
+	t.callstack = t.callstack[:0]
+	t.selfdestruct = nil
+}
+
+func (t *CallTracer) TxStart(tx *types.Transaction, coinbase types.Address, host tracer.RuntimeHost) {
+	t.gasLimit = tx.Gas
+}
+
+func (t *CallTracer) TxEnd(gasLeft uint64) {
+	if len(t.callstack) == 0 {
+		return
+	}
+
+	// the top-level call uses the whole gas of the transaction, including the intrinsic gas
+	t.callstack[0].GasUsed = hex.EncodeUint64(t.gasLimit - gasLeft)
+}
+
+func (t *CallTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	t.depth = depth
+
+	if t.Config.OnlyTopCall && depth > 1 {
+		return
+	}
+
+	if depth == 1 {
+		gas = t.gasLimit
+	}
+
+	frame := &CallFrame{
+		Type:  callTypeToString(runtime.CallType(callType)),
+		From:  from,
+		To:    &to,
+		Gas:   hex.EncodeUint64(gas),
+		Input: hex.EncodeToHex(input),
+	}
+
+	// the static and delegate calls don't transfer any value
+	if callType != int(runtime.StaticCall) && callType != int(runtime.DelegateCall) {
+		if value == nil {
+			value = big.NewInt(0)
+		}
+
+		frame.Value = hex.EncodeBig(value)
+	}
+
+	t.callstack = append(t.callstack, frame)
+}
+
+func (t *CallTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+	t.depth = depth - 1
+
+	if (t.Config.OnlyTopCall && depth > 1) || len(t.callstack) == 0 {
+		return
+	}
+
+	frame := t.callstack[len(t.callstack)-1]
+	frame.GasUsed = hex.EncodeUint64(gasUsed)
+
+	if err == nil || errors.Is(err, runtime.ErrExecutionReverted) {
+		frame.Output = hex.EncodeToHex(output)
+	}
+
+	if err != nil {
+		frame.Error = err.Error()
+
+		if errors.Is(err, runtime.ErrExecutionReverted) {
+			if reason, unpackErr := abi.UnpackRevertError(output); unpackErr == nil {
+				frame.RevertReason = reason
+			}
+		}
+
+		if frame.Type == "CREATE" || frame.Type == "CREATE2" {
+			frame.To = nil
+		}
+
+		frame.clearLogs()
+	}
+
+	if len(t.callstack) == 1 {
+		// keep the top-level call as the result
+		return
+	}
+
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	parent := t.callstack[len(t.callstack)-1]
+	parent.Calls = append(parent.Calls, frame)
+}
+
+func (t *CallTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if len(t.callstack) == 0 || (t.Config.OnlyTopCall && t.depth > 1) {
+		return
+	}
+
+	switch {
+	case opCode >= evm.LOG0 && opCode <= evm.LOG4:
+		if t.Config.WithLog {
+			t.captureLog(memory, stack, opCode, contractAddress, sp)
+		}
+
+	case opCode == evm.SELFDESTRUCT:
+		t.captureSelfdestruct(stack, contractAddress, sp, host)
+	}
+}
+
+// captureLog adds the log about to be emitted to the current call
+func (t *CallTracer) captureLog(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+) {
+	size := opCode - evm.LOG0
+	if sp < size+2 {
+		return
+	}
+
+	offset, length := stack[sp-1], stack[sp-2]
+	if !offset.IsUint64() || !length.IsUint64() || length.Uint64() > maxLogDataSize {
+		return
+	}
+
+	data := make([]byte, length.Uint64())
+	if offset.Uint64() < uint64(len(memory)) {
+		copy(data, memory[offset.Uint64():])
+	}
+
+	topics := make([]types.Hash, size)
+	for i := 0; i < size; i++ {
+		topics[i] = types.BytesToHash(stack[sp-3-i].Bytes())
+	}
+
+	frame := t.callstack[len(t.callstack)-1]
+	frame.Logs = append(frame.Logs, &CallLog{
+		Address: contractAddress,
+		Topics:  topics,
+		Data:    hex.EncodeToHex(data),
+	})
+}
+
+// captureSelfdestruct captures the transfer of the contract balance to the beneficiary,
+// which is added as a sub call once the self destruction succeeds
+func (t *CallTracer) captureSelfdestruct(
+	stack []*big.Int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+) {
+	if sp < 1 {
+		return
+	}
+
+	beneficiary := types.BytesToAddress(stack[sp-1].Bytes())
+
+	t.selfdestruct = &CallFrame{
+		Type:    "SELFDESTRUCT",
+		From:    contractAddress,
+		To:      &beneficiary,
+		Value:   hex.EncodeBig(host.GetBalance(contractAddress)),
+		Gas:     hex.EncodeUint64(0),
+		GasUsed: hex.EncodeUint64(0),
+		Input:   hex.EncodeToHex(nil),
+	}
+}
+
+func (t *CallTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+	if t.selfdestruct == nil {
+		return
+	}
+
+	if err == nil && len(t.callstack) > 0 {
+		frame := t.callstack[len(t.callstack)-1]
+		frame.Calls = append(frame.Calls, t.selfdestruct)
+	}
+
+	t.selfdestruct = nil
+}
+
+func (t *CallTracer) GetResult() (interface{}, error) {
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	if len(t.callstack) == 0 {
+		return nil, errors.New("no call has been traced")
+	}
+
+	return t.callstack[0], nil
+}
+
+func callTypeToString(callType runtime.CallType) string {
+	switch callType {
+	case runtime.Call:
+		return "CALL"
+	case runtime.CallCode:
+		return "CALLCODE"
+	case runtime.DelegateCall:
+		return "DELEGATECALL"
+	case runtime.StaticCall:
+		return "STATICCALL"
+	case runtime.Create:
+		return "CREATE"
+	case runtime.Create2:
+		return "CREATE2"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,227 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/helper/hex"
+	"github.com/vishnushankarsg/metad/state/runtime"
+	"github.com/vishnushankarsg/metad/state/runtime/evm"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+var (
+	testFrom   = types.StringToAddress("1")
+	testTo     = types.StringToAddress("2")
+	testCallee = types.StringToAddress("3")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockHost struct {
+	balance *big.Int
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHost) GetBalance(types.Address) *big.Int {
+	return m.balance
+}
+
+func (m *mockHost) GetNonce(types.Address) uint64 {
+	return 0
+}
+
+func (m *mockHost) GetCode(types.Address) []byte {
+	return nil
+}
+
+// revertReason is the ABI encoded Error("failed")
+var revertReason = []byte{
+	0x08, 0xc3, 0x79, 0xa0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x20,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x06,
+	'f', 'a', 'i', 'l', 'e', 'd', 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+}
+
+// traceLog drives the tracer through a LOG1 opcode emitting the given data
+func traceLog(tracer *CallTracer, address types.Address, topic types.Hash, data []byte) {
+	stack := []*big.Int{
+		new(big.Int).SetBytes(topic.Bytes()),
+		big.NewInt(int64(len(data))),
+		big.NewInt(0),
+	}
+
+	tracer.CaptureState(data, stack, evm.LOG1, address, len(stack), &mockHost{}, &mockState{})
+}
+
+func TestCallTracer_CallTree(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{WithLog: true})
+
+	tracer.TxStart(&types.Transaction{Gas: 100000}, types.ZeroAddress, &mockHost{})
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 79000, big.NewInt(10), []byte{0x1, 0x2, 0x3, 0x4})
+	traceLog(tracer, testTo, types.StringToHash("1"), []byte{0xaa})
+
+	// successful sub call
+	tracer.CallStart(2, testTo, testCallee, int(runtime.StaticCall), 5000, nil, []byte{0x5})
+	traceLog(tracer, testCallee, types.StringToHash("2"), []byte{0xbb})
+	tracer.CallEnd(2, []byte{0x6}, 1000, nil)
+
+	// reverted sub call, its logs are discarded
+	tracer.CallStart(2, testTo, testCallee, int(runtime.Call), 5000, big.NewInt(0), nil)
+	traceLog(tracer, testCallee, types.StringToHash("3"), []byte{0xcc})
+	tracer.CallEnd(2, revertReason, 2000, runtime.ErrExecutionReverted)
+
+	tracer.CallEnd(1, []byte{0x7}, 30000, nil)
+	tracer.TxEnd(60000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	to, callee := testTo, testCallee
+
+	assert.Equal(t, &CallFrame{
+		Type:    "CALL",
+		From:    testFrom,
+		To:      &to,
+		Value:   "0xa",
+		Gas:     "0x186a0",
+		GasUsed: "0x9c40",
+		Input:   "0x01020304",
+		Output:  "0x07",
+		Logs: []*CallLog{
+			{Address: testTo, Topics: []types.Hash{types.StringToHash("1")}, Data: "0xaa"},
+		},
+		Calls: []*CallFrame{
+			{
+				Type:    "STATICCALL",
+				From:    testTo,
+				To:      &callee,
+				Gas:     "0x1388",
+				GasUsed: "0x3e8",
+				Input:   "0x05",
+				Output:  "0x06",
+				Logs: []*CallLog{
+					{Address: testCallee, Topics: []types.Hash{types.StringToHash("2")}, Data: "0xbb"},
+				},
+			},
+			{
+				Type:         "CALL",
+				From:         testTo,
+				To:           &callee,
+				Value:        "0x0",
+				Gas:          "0x1388",
+				GasUsed:      "0x7d0",
+				Input:        "0x",
+				Output:       hex.EncodeToHex(revertReason),
+				Error:        runtime.ErrExecutionReverted.Error(),
+				RevertReason: "failed",
+			},
+		},
+	}, res)
+}
+
+func TestCallTracer_OnlyTopCall(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{OnlyTopCall: true, WithLog: true})
+
+	tracer.TxStart(&types.Transaction{Gas: 100000}, types.ZeroAddress, &mockHost{})
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 79000, big.NewInt(0), nil)
+	tracer.CallStart(2, testTo, testCallee, int(runtime.Call), 5000, big.NewInt(0), nil)
+	traceLog(tracer, testCallee, types.StringToHash("1"), nil)
+	tracer.CallEnd(2, nil, 1000, nil)
+	tracer.CallEnd(1, nil, 30000, nil)
+	tracer.TxEnd(60000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	frame, ok := res.(*CallFrame)
+	require.True(t, ok)
+	assert.Empty(t, frame.Calls)
+	assert.Empty(t, frame.Logs)
+}
+
+func TestCallTracer_FailedCreate(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{})
+
+	tracer.TxStart(&types.Transaction{Gas: 100000}, types.ZeroAddress, &mockHost{})
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Create), 79000, big.NewInt(0), []byte{0x60})
+	tracer.CallEnd(1, nil, 79000, runtime.ErrOutOfGas)
+	tracer.TxEnd(0)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	frame, ok := res.(*CallFrame)
+	require.True(t, ok)
+	assert.Equal(t, "CREATE", frame.Type)
+	assert.Nil(t, frame.To)
+	assert.Equal(t, runtime.ErrOutOfGas.Error(), frame.Error)
+	assert.Empty(t, frame.Output)
+}
+
+func TestCallTracer_Selfdestruct(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{})
+	host := &mockHost{balance: big.NewInt(500)}
+	stack := []*big.Int{new(big.Int).SetBytes(testCallee.Bytes())}
+
+	tracer.TxStart(&types.Transaction{Gas: 100000}, types.ZeroAddress, host)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 79000, big.NewInt(0), nil)
+	tracer.CaptureState(nil, stack, evm.SELFDESTRUCT, testTo, 1, host, &mockState{})
+	tracer.ExecuteState(testTo, 0, "SELFDESTRUCT", 79000, 5000, nil, 1, nil, host)
+	tracer.CallEnd(1, nil, 5000, nil)
+	tracer.TxEnd(74000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	frame, ok := res.(*CallFrame)
+	require.True(t, ok)
+	require.Len(t, frame.Calls, 1)
+	assert.Equal(t, "SELFDESTRUCT", frame.Calls[0].Type)
+	assert.Equal(t, testCallee, *frame.Calls[0].To)
+	assert.Equal(t, "0x1f4", frame.Calls[0].Value)
+}
+
+func TestCallTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	cancelErr := errors.New("timeout")
+	state := &mockState{}
+
+	tracer := NewCallTracer(Config{})
+	tracer.TxStart(&types.Transaction{Gas: 100000}, types.ZeroAddress, &mockHost{})
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 79000, big.NewInt(0), nil)
+	tracer.Cancel(cancelErr)
+	tracer.CaptureState(nil, nil, int(evm.STOP), testTo, 0, &mockHost{}, state)
+
+	assert.True(t, state.halted)
+
+	res, err := tracer.GetResult()
+	assert.Nil(t, res)
+	assert.Equal(t, cancelErr, err)
+}
@@ -0,0 +1,143 @@
+package fourbytetracer
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/vishnushankarsg/metad/chain"
+	"github.com/vishnushankarsg/metad/helper/hex"
+	"github.com/vishnushankarsg/metad/state/runtime"
+	"github.com/vishnushankarsg/metad/state/runtime/precompiled"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// selectorLength is the length of the function selector at the beginning of the call input
+const selectorLength = 4
+
+// FourByteTracer counts the function selectors of the calls made during the transaction execution,
+// together with the size of the call arguments
+type FourByteTracer struct {
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	precompiles map[types.Address]struct{}
+	ids         map[string]int
+}
+
+// NewFourByteTracer creates the tracer skipping the calls of the precompiled contracts
+// which are active in the given forks of the traced block
+func NewFourByteTracer(forks chain.ForksInTime) *FourByteTracer {
+	precompiles := make(map[types.Address]struct{})
+	for _, addr := range precompiled.NewPrecompiled().Addresses(&forks) {
+		precompiles[addr] = struct{}{}
+	}
+
+	return &FourByteTracer{
+		cancelLock:  sync.RWMutex{},
+		precompiles: precompiles,
+		ids:         make(map[string]int),
+	}
+}
+
+func (t *FourByteTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *FourByteTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *FourByteTracer) Clear() {
+	t.reason = nil
+	t.interrupt = false
+	t.ids = make(map[string]int)
+}
+
+func (t *FourByteTracer) TxStart(tx *types.Transaction, coinbase types.Address, host tracer.RuntimeHost) {
+}
+
+func (t *FourByteTracer) TxEnd(gasLeft uint64) {
+}
+
+func (t *FourByteTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	// the contract creations don't call any function
+	if callType == int(runtime.Create) || callType == int(runtime.Create2) {
+		return
+	}
+
+	if len(input) < selectorLength {
+		return
+	}
+
+	if _, ok := t.precompiles[to]; ok {
+		return
+	}
+
+	id := fmt.Sprintf("%s-%d", hex.EncodeToHex(input[:selectorLength]), len(input)-selectorLength)
+	t.ids[id]++
+}
+
+func (t *FourByteTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+}
+
+func (t *FourByteTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+	}
+}
+
+func (t *FourByteTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func (t *FourByteTracer) GetResult() (interface{}, error) {
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	res := make(map[string]int, len(t.ids))
+	for id, count := range t.ids {
+		res[id] = count
+	}
+
+	return res, nil
+}
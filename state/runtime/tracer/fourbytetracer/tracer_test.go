@@ -0,0 +1,73 @@
+package fourbytetracer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/chain"
+	"github.com/vishnushankarsg/metad/state/runtime"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+func TestFourByteTracer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from       = types.StringToAddress("1")
+		to         = types.StringToAddress("1000")
+		precompile = types.StringToAddress("1")
+		selector   = []byte{0xa9, 0x05, 0x9c, 0xbb}
+	)
+
+	tracer := NewFourByteTracer(chain.AllForksEnabled.At(0))
+
+	tracer.CallStart(1, from, to, int(runtime.Call), 1000, big.NewInt(0), append(selector, make([]byte, 64)...))
+	tracer.CallStart(2, to, to, int(runtime.StaticCall), 1000, nil, append(selector, make([]byte, 64)...))
+	tracer.CallStart(2, to, to, int(runtime.DelegateCall), 1000, nil, selector)
+
+	// skipped calls
+	tracer.CallStart(2, to, to, int(runtime.Call), 1000, big.NewInt(0), selector[:3])
+	tracer.CallStart(2, to, to, int(runtime.Create), 1000, big.NewInt(0), selector)
+	tracer.CallStart(2, to, to, int(runtime.Create2), 1000, big.NewInt(0), selector)
+	tracer.CallStart(2, to, precompile, int(runtime.StaticCall), 1000, nil, selector)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{
+		"0xa9059cbb-64": 2,
+		"0xa9059cbb-0":  1,
+	}, res)
+
+	tracer.Clear()
+
+	res, err = tracer.GetResult()
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestFourByteTracer_Forks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from     = types.StringToAddress("1000")
+		blake2f  = types.StringToAddress("9")
+		selector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+	)
+
+	// the blake2f precompile is only active since the Istanbul fork
+	for forks, expected := range map[chain.ForksInTime]map[string]int{
+		{Byzantium: true}:           {"0xa9059cbb-0": 1},
+		chain.AllForksEnabled.At(0): {},
+	} {
+		tracer := NewFourByteTracer(forks)
+		tracer.CallStart(1, from, blake2f, int(runtime.Call), 1000, big.NewInt(0), selector)
+
+		res, err := tracer.GetResult()
+		require.NoError(t, err)
+		assert.Equal(t, expected, res)
+	}
+}
@@ -0,0 +1,358 @@
+package prestatetracer
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/helper/hex"
+	"github.com/vishnushankarsg/metad/state/runtime/evm"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// maxInitCodeSize bounds the init code read from the memory to compute a CREATE2 address,
+// the memory expansion for larger init code can't be paid for
+const maxInitCodeSize = 1 << 25
+
+type Config struct {
+	DiffMode bool `json:"diffMode"` // return the differences between the pre and the post state
+}
+
+// Account is the state of an account touched by the transaction
+type Account struct {
+	Balance string                    `json:"balance,omitempty"`
+	Nonce   uint64                    `json:"nonce,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+// State is the state of the accounts touched by the transaction
+type State map[types.Address]*Account
+
+// DiffResult is the result of the tracer in the diff mode
+type DiffResult struct {
+	Pre  State `json:"pre"`
+	Post State `json:"post"`
+}
+
+// account is the captured state of an account
+type account struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+func (a *account) exists() bool {
+	return a.nonce > 0 || a.balance.Sign() > 0 || len(a.code) > 0
+}
+
+// PrestateTracer captures the state of all the accounts touched by the transaction
+// before its execution and, in the diff mode, after its execution
+type PrestateTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	host tracer.RuntimeHost
+	pre  map[types.Address]*account
+	post map[types.Address]*account
+}
+
+func NewPrestateTracer(config Config) *PrestateTracer {
+	return &PrestateTracer{
+		Config:     config,
+		cancelLock: sync.RWMutex{},
+		pre:        make(map[types.Address]*account),
+	}
+}
+
+func (t *PrestateTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *PrestateTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *PrestateTracer) Clear() {
+	t.reason = nil
+	t.interrupt = false
+	t.host = nil
+	t.pre = make(map[types.Address]*account)
+	t.post = nil
+}
+
+func (t *PrestateTracer) TxStart(tx *types.Transaction, coinbase types.Address, host tracer.RuntimeHost) {
+	t.host = host
+
+	t.lookupAccount(tx.From)
+	t.lookupAccount(coinbase)
+
+	if tx.To != nil {
+		t.lookupAccount(*tx.To)
+	} else {
+		t.lookupAccount(crypto.CreateAddress(tx.From, host.GetNonce(tx.From)))
+	}
+}
+
+func (t *PrestateTracer) TxEnd(gasLeft uint64) {
+	if !t.Config.DiffMode || t.host == nil {
+		return
+	}
+
+	t.post = make(map[types.Address]*account, len(t.pre))
+
+	for addr, pre := range t.pre {
+		post := t.readAccount(addr)
+
+		for slot := range pre.storage {
+			post.storage[slot] = t.host.GetStorage(addr, slot)
+		}
+
+		t.post[addr] = post
+	}
+}
+
+func (t *PrestateTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	t.lookupAccount(to)
+}
+
+func (t *PrestateTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+}
+
+func (t *PrestateTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	// capture the accounts and the storage slots before the opcode modifies them
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp >= 1 {
+			t.lookupStorage(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+		}
+
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH, evm.SELFDESTRUCT:
+		if sp >= 1 {
+			t.lookupAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+		}
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp >= 2 {
+			t.lookupAccount(types.BytesToAddress(stack[sp-2].Bytes()))
+		}
+
+	case evm.CREATE:
+		t.lookupAccount(crypto.CreateAddress(contractAddress, host.GetNonce(contractAddress)))
+
+	case evm.CREATE2:
+		if sp < 4 {
+			return
+		}
+
+		offset, length := stack[sp-2], stack[sp-3]
+		if !offset.IsUint64() || !length.IsUint64() || length.Uint64() > maxInitCodeSize {
+			return
+		}
+
+		initCode := make([]byte, length.Uint64())
+		if offset.Uint64() < uint64(len(memory)) {
+			copy(initCode, memory[offset.Uint64():])
+		}
+
+		salt := types.BytesToHash(stack[sp-4].Bytes())
+
+		t.lookupAccount(crypto.CreateAddress2(contractAddress, salt, initCode))
+	}
+}
+
+func (t *PrestateTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+// lookupAccount captures the state of the account if it hasn't been captured yet
+func (t *PrestateTracer) lookupAccount(addr types.Address) {
+	if _, ok := t.pre[addr]; ok || t.host == nil {
+		return
+	}
+
+	t.pre[addr] = t.readAccount(addr)
+}
+
+// lookupStorage captures the value of the storage slot if it hasn't been captured yet
+func (t *PrestateTracer) lookupStorage(addr types.Address, slot types.Hash) {
+	t.lookupAccount(addr)
+
+	acc, ok := t.pre[addr]
+	if !ok {
+		return
+	}
+
+	if _, ok := acc.storage[slot]; !ok {
+		acc.storage[slot] = t.host.GetStorage(addr, slot)
+	}
+}
+
+func (t *PrestateTracer) readAccount(addr types.Address) *account {
+	balance := big.NewInt(0)
+	if b := t.host.GetBalance(addr); b != nil {
+		balance.Set(b)
+	}
+
+	return &account{
+		balance: balance,
+		nonce:   t.host.GetNonce(addr),
+		code:    append([]byte{}, t.host.GetCode(addr)...),
+		storage: make(map[types.Hash]types.Hash),
+	}
+}
+
+func (t *PrestateTracer) GetResult() (interface{}, error) {
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	if t.Config.DiffMode {
+		return t.diffResult(), nil
+	}
+
+	res := make(State, len(t.pre))
+
+	for addr, acc := range t.pre {
+		// the accounts created by the transaction didn't exist before
+		if !acc.exists() {
+			continue
+		}
+
+		res[addr] = &Account{
+			Balance: hex.EncodeBig(acc.balance),
+			Nonce:   acc.nonce,
+			Code:    encodeCode(acc.code),
+			Storage: acc.storage,
+		}
+	}
+
+	return res, nil
+}
+
+// diffResult returns the pre and post state of the accounts modified by the transaction,
+// limited to the modified fields in the post state
+func (t *PrestateTracer) diffResult() *DiffResult {
+	res := &DiffResult{
+		Pre:  make(State),
+		Post: make(State),
+	}
+
+	for addr, pre := range t.pre {
+		post, ok := t.post[addr]
+		if !ok {
+			continue
+		}
+
+		preAccount := &Account{
+			Balance: hex.EncodeBig(pre.balance),
+			Nonce:   pre.nonce,
+			Code:    encodeCode(pre.code),
+			Storage: make(map[types.Hash]types.Hash),
+		}
+		postAccount := &Account{
+			Storage: make(map[types.Hash]types.Hash),
+		}
+
+		modified := false
+
+		if pre.balance.Cmp(post.balance) != 0 {
+			modified = true
+			postAccount.Balance = hex.EncodeBig(post.balance)
+		}
+
+		if pre.nonce != post.nonce {
+			modified = true
+			postAccount.Nonce = post.nonce
+		}
+
+		if !bytes.Equal(pre.code, post.code) {
+			modified = true
+			postAccount.Code = encodeCode(post.code)
+		}
+
+		for slot, preValue := range pre.storage {
+			postValue := post.storage[slot]
+			if preValue == postValue {
+				continue
+			}
+
+			modified = true
+
+			preAccount.Storage[slot] = preValue
+
+			// the cleared slots are left out of the post state
+			if postValue != types.ZeroHash {
+				postAccount.Storage[slot] = postValue
+			}
+		}
+
+		if !modified {
+			continue
+		}
+
+		if pre.exists() {
+			res.Pre[addr] = preAccount
+		}
+
+		res.Post[addr] = postAccount
+	}
+
+	return res
+}
+
+func encodeCode(code []byte) string {
+	if len(code) == 0 {
+		return ""
+	}
+
+	return hex.EncodeToHex(code)
+}
@@ -0,0 +1,175 @@
+package prestatetracer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/state/runtime"
+	"github.com/vishnushankarsg/metad/state/runtime/evm"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+var (
+	testFrom     = types.StringToAddress("1")
+	testTo       = types.StringToAddress("2")
+	testCoinbase = types.StringToAddress("3")
+	testUnknown  = types.StringToAddress("4")
+	testSlot     = types.StringToHash("1")
+)
+
+type mockState struct{}
+
+func (m *mockState) Halt() {}
+
+type mockHost struct {
+	balances map[types.Address]*big.Int
+	nonces   map[types.Address]uint64
+	code     map[types.Address][]byte
+	storage  map[types.Address]map[types.Hash]types.Hash
+}
+
+func newMockHost() *mockHost {
+	return &mockHost{
+		balances: map[types.Address]*big.Int{
+			testFrom:     big.NewInt(1000),
+			testCoinbase: big.NewInt(10),
+		},
+		nonces: map[types.Address]uint64{
+			testFrom: 1,
+		},
+		code: map[types.Address][]byte{
+			testTo: {0x60, 0x00},
+		},
+		storage: map[types.Address]map[types.Hash]types.Hash{
+			testTo: {testSlot: types.StringToHash("1")},
+		},
+	}
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return m.storage[addr][slot]
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	return m.balances[addr]
+}
+
+func (m *mockHost) GetNonce(addr types.Address) uint64 {
+	return m.nonces[addr]
+}
+
+func (m *mockHost) GetCode(addr types.Address) []byte {
+	return m.code[addr]
+}
+
+// traceTx drives the tracer through a transaction reading a storage slot of the callee
+// and the balance of an unknown account, then applies the given state changes
+func traceTx(tracer *PrestateTracer, host *mockHost, apply func()) {
+	tracer.TxStart(&types.Transaction{From: testFrom, To: &testTo, Gas: 100000}, testCoinbase, host)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 79000, big.NewInt(0), nil)
+
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(testSlot.Bytes())}, evm.SSTORE, testTo, 1, host, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(testUnknown.Bytes())}, evm.BALANCE, testTo, 1, host, &mockState{})
+
+	apply()
+
+	tracer.CallEnd(1, nil, 5000, nil)
+	tracer.TxEnd(74000)
+}
+
+func TestPrestateTracer_Prestate(t *testing.T) {
+	t.Parallel()
+
+	host := newMockHost()
+	tracer := NewPrestateTracer(Config{})
+
+	traceTx(tracer, host, func() {
+		host.balances[testFrom] = big.NewInt(500)
+		host.storage[testTo][testSlot] = types.StringToHash("2")
+	})
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	// the unknown account doesn't exist and is left out
+	assert.Equal(t, State{
+		testFrom: {
+			Balance: "0x3e8",
+			Nonce:   1,
+			Storage: map[types.Hash]types.Hash{},
+		},
+		testTo: {
+			Balance: "0x0",
+			Code:    "0x6000",
+			Storage: map[types.Hash]types.Hash{testSlot: types.StringToHash("1")},
+		},
+		testCoinbase: {
+			Balance: "0xa",
+			Storage: map[types.Hash]types.Hash{},
+		},
+	}, res)
+}
+
+func TestPrestateTracer_DiffMode(t *testing.T) {
+	t.Parallel()
+
+	host := newMockHost()
+	tracer := NewPrestateTracer(Config{DiffMode: true})
+
+	traceTx(tracer, host, func() {
+		host.balances[testFrom] = big.NewInt(500)
+		host.nonces[testFrom] = 2
+		host.balances[testCoinbase] = big.NewInt(20)
+		host.storage[testTo][testSlot] = types.ZeroHash
+		host.balances[testUnknown] = big.NewInt(1)
+	})
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	assert.Equal(t, &DiffResult{
+		Pre: State{
+			testFrom: {
+				Balance: "0x3e8",
+				Nonce:   1,
+				Storage: map[types.Hash]types.Hash{},
+			},
+			testTo: {
+				Balance: "0x0",
+				Code:    "0x6000",
+				Storage: map[types.Hash]types.Hash{testSlot: types.StringToHash("1")},
+			},
+			testCoinbase: {
+				Balance: "0xa",
+				Storage: map[types.Hash]types.Hash{},
+			},
+		},
+		Post: State{
+			testFrom: {
+				Balance: "0x1f4",
+				Nonce:   2,
+				Storage: map[types.Hash]types.Hash{},
+			},
+			// the cleared slot is left out
+			testTo: {
+				Storage: map[types.Hash]types.Hash{},
+			},
+			testCoinbase: {
+				Balance: "0x14",
+				Storage: map[types.Hash]types.Hash{},
+			},
+			// the created account has no pre state
+			testUnknown: {
+				Balance: "0x1",
+				Storage: map[types.Hash]types.Hash{},
+			},
+		},
+	}, res)
+}
@@ -43,6 +43,18 @@ func (m *mockHost) GetStorage(a types.Address, h types.Hash) types.Hash {
 	return m.getStorageFunc(a, h)
 }
 
+func (m *mockHost) GetBalance(types.Address) *big.Int {
+	panic("Not implemented")
+}
+
+func (m *mockHost) GetNonce(types.Address) uint64 {
+	panic("Not implemented")
+}
+
+func (m *mockHost) GetCode(types.Address) []byte {
+	panic("Not implemented")
+}
+
 func TestStructLogErrorString(t *testing.T) {
 	t.Parallel()
 
@@ -178,7 +190,7 @@ func TestStructTracerTxStart(t *testing.T) {
 
 	tracer := NewStructTracer(testEmptyConfig)
 
-	tracer.TxStart(gasLimit)
+	tracer.TxStart(&types.Transaction{Gas: gasLimit}, types.ZeroAddress, nil)
 
 	assert.Equal(
 		t,
@@ -201,7 +213,7 @@ func TestStructTracerTxEnd(t *testing.T) {
 
 	tracer := NewStructTracer(testEmptyConfig)
 
-	tracer.TxStart(gasLimit)
+	tracer.TxStart(&types.Transaction{Gas: gasLimit}, types.ZeroAddress, nil)
 	tracer.TxEnd(gasLeft)
 
 	assert.Equal(
@@ -286,7 +298,7 @@ func TestStructTracerCallEnd(t *testing.T) {
 
 			tracer := NewStructTracer(testEmptyConfig)
 
-			tracer.CallEnd(test.depth, test.output, test.err)
+			tracer.CallEnd(test.depth, test.output, 0, test.err)
 
 			assert.Equal(
 				t,
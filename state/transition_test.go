@@ -4,7 +4,10 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/vishnushankarsg/metad/chain"
 	"github.com/vishnushankarsg/metad/state/runtime"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer"
+	"github.com/vishnushankarsg/metad/state/runtime/tracer/structtracer"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -159,3 +162,231 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestApply_DynamicFee(t *testing.T) {
+	t.Parallel()
+
+	coinbase := types.StringToAddress("1000")
+	receiver := types.StringToAddress("2000")
+	baseFee := big.NewInt(10)
+
+	newDynamicFeeTx := func(gasTipCap, gasFeeCap int64) *types.Transaction {
+		return &types.Transaction{
+			Type:      types.DynamicFeeTx,
+			From:      addr1,
+			To:        &receiver,
+			Value:     big.NewInt(1),
+			Gas:       TxGas,
+			GasPrice:  big.NewInt(0),
+			GasTipCap: big.NewInt(gasTipCap),
+			GasFeeCap: big.NewInt(gasFeeCap),
+		}
+	}
+
+	newLondonTransition := func() *Transition {
+		state := newStateWithPreState(map[types.Address]*PreState{
+			addr1: {
+				Balance: 1000000000,
+			},
+		})
+
+		transition := NewTransition(chain.AllForksEnabled.At(0), state, newTxn(state))
+		transition.ctx = runtime.TxContext{
+			Coinbase: coinbase,
+			BaseFee:  baseFee,
+		}
+		transition.gasPool = TxGas
+
+		return transition
+	}
+
+	t.Run("should burn the base fee and pay the tip to the coinbase", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newLondonTransition()
+
+		result, err := transition.apply(newDynamicFeeTx(2, 100))
+		assert.NoError(t, err)
+		assert.Equal(t, TxGas, result.GasUsed)
+
+		// the sender pays the base fee and the tip
+		expectedBalance := new(big.Int).SetUint64(1000000000 - TxGas*12 - 1)
+		assert.Equal(t, expectedBalance, transition.GetBalance(addr1))
+
+		// the coinbase gets only the tip
+		assert.Equal(t, new(big.Int).SetUint64(TxGas*2), transition.GetBalance(coinbase))
+		assert.Equal(t, big.NewInt(1), transition.GetBalance(receiver))
+	})
+
+	t.Run("should fail when the fee cap is below the base fee", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newLondonTransition().apply(newDynamicFeeTx(1, 9))
+		assert.EqualError(t, err, ErrFeeCapTooLow.Error())
+	})
+
+	t.Run("should fail when the tip is above the fee cap", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newLondonTransition().apply(newDynamicFeeTx(20, 10))
+		assert.EqualError(t, err, ErrTipAboveFeeCap.Error())
+	})
+
+	t.Run("should skip the base fee check for the simulated calls without gas price", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newLondonTransition()
+		transition.SetNoBaseFee(true)
+
+		_, err := transition.apply(newDynamicFeeTx(0, 0))
+		assert.NoError(t, err)
+	})
+}
+
+// txRecordingTracer records the gas left the transactions are ended with
+type txRecordingTracer struct {
+	*structtracer.StructTracer
+
+	started int
+	ended   []uint64
+}
+
+func (t *txRecordingTracer) TxStart(tx *types.Transaction, coinbase types.Address, host tracer.RuntimeHost) {
+	t.started++
+	t.StructTracer.TxStart(tx, coinbase, host)
+}
+
+func (t *txRecordingTracer) TxEnd(gasLeft uint64) {
+	t.ended = append(t.ended, gasLeft)
+	t.StructTracer.TxEnd(gasLeft)
+}
+
+func TestApply_TracerTxEnd(t *testing.T) {
+	t.Parallel()
+
+	receiver := types.StringToAddress("2000")
+
+	newTracedTransition := func(gasPool uint64) (*Transition, *txRecordingTracer) {
+		state := newStateWithPreState(map[types.Address]*PreState{
+			addr1: {
+				Balance: 1000000000,
+			},
+		})
+
+		txTracer := &txRecordingTracer{StructTracer: structtracer.NewStructTracer(structtracer.Config{})}
+
+		transition := NewTransition(chain.AllForksEnabled.At(0), state, newTxn(state))
+		transition.ctx = runtime.TxContext{Tracer: txTracer}
+		transition.gasPool = gasPool
+
+		return transition, txTracer
+	}
+
+	newTx := func(nonce uint64) *types.Transaction {
+		return &types.Transaction{
+			Nonce:    nonce,
+			From:     addr1,
+			To:       &receiver,
+			Value:    big.NewInt(1),
+			Gas:      TxGas,
+			GasPrice: big.NewInt(1),
+		}
+	}
+
+	t.Run("should end the executed transaction after the fees are paid", func(t *testing.T) {
+		t.Parallel()
+
+		transition, tracer := newTracedTransition(TxGas)
+
+		_, err := transition.apply(newTx(0))
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, tracer.started)
+		assert.Equal(t, []uint64{0}, tracer.ended)
+	})
+
+	t.Run("should end the transaction failing the pre-checks", func(t *testing.T) {
+		t.Parallel()
+
+		transition, tracer := newTracedTransition(TxGas)
+
+		_, err := transition.apply(newTx(1))
+		assert.EqualError(t, err, ErrNonceIncorrect.Error())
+
+		assert.Equal(t, 1, tracer.started)
+		assert.Equal(t, []uint64{TxGas}, tracer.ended)
+	})
+
+	t.Run("should end the transaction not fitting into the block", func(t *testing.T) {
+		t.Parallel()
+
+		transition, tracer := newTracedTransition(TxGas - 1)
+
+		_, err := transition.apply(newTx(0))
+		assert.EqualError(t, err, ErrBlockLimitReached.Error())
+
+		assert.Equal(t, 1, tracer.started)
+		assert.Equal(t, []uint64{TxGas}, tracer.ended)
+	})
+}
+
+func TestApply_AccessList(t *testing.T) {
+	t.Parallel()
+
+	receiver := types.StringToAddress("2000")
+	slot := types.StringToHash("1")
+
+	accessListTx := &types.Transaction{
+		Type:     types.AccessListTx,
+		From:     addr1,
+		To:       &receiver,
+		Value:    big.NewInt(1),
+		Gas:      TxGas + TxAccessListAddressGas + TxAccessListStorageKeyGas,
+		GasPrice: big.NewInt(1),
+		AccessList: types.TxAccessList{
+			{Address: receiver, StorageKeys: []types.Hash{slot}},
+		},
+	}
+
+	newBerlinTransition := func(forks chain.ForksInTime) *Transition {
+		state := newStateWithPreState(map[types.Address]*PreState{
+			addr1: {
+				Balance: 1000000000,
+			},
+		})
+
+		transition := NewTransition(forks, state, newTxn(state))
+		transition.gasPool = accessListTx.Gas
+
+		return transition
+	}
+
+	t.Run("should charge the access list and warm up its entries", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newBerlinTransition(chain.AllForksEnabled.At(0))
+
+		result, err := transition.apply(accessListTx.Copy())
+		assert.NoError(t, err)
+		assert.Equal(t, accessListTx.Gas, result.GasUsed)
+
+		assert.True(t, transition.AddressInAccessList(addr1))
+		assert.True(t, transition.AddressInAccessList(types.StringToAddress("1")))
+
+		addressOk, slotOk := transition.SlotInAccessList(receiver, slot)
+		assert.True(t, addressOk)
+		assert.True(t, slotOk)
+	})
+
+	t.Run("should reject the access list transaction before Berlin", func(t *testing.T) {
+		t.Parallel()
+
+		forks := chain.AllForksEnabled.At(0)
+		forks.Berlin = false
+		forks.London = false
+		forks.EIP1559 = false
+
+		_, err := newBerlinTransition(forks).apply(accessListTx.Copy())
+		assert.EqualError(t, err, ErrTxTypeNotSupported.Error())
+	})
+}
@@ -26,6 +26,9 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// accessListIndex is the prefix of the EIP-2929 access list entries in the trie
+	accessListIndex = types.BytesToHash([]byte{4}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -283,13 +286,17 @@ func (txn *Txn) SetStorage(
 	if original == value {
 		if original == zeroHash { // reset to original nonexistent slot (2.2.2.1)
 			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			if config.Berlin {
+				txn.AddRefund(19900)
+			} else if config.Istanbul {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.Berlin {
+				txn.AddRefund(2800)
+			} else if config.Istanbul {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
@@ -478,6 +485,46 @@ func (txn *Txn) GetRefund() uint64 {
 	return data.(uint64)
 }
 
+// Access list
+
+func accessListAddressKey(addr types.Address) []byte {
+	return append(append([]byte{}, accessListIndex...), addr.Bytes()...)
+}
+
+func accessListSlotKey(addr types.Address, slot types.Hash) []byte {
+	return append(accessListAddressKey(addr), slot.Bytes()...)
+}
+
+// AddressInAccessList returns true if the address is warm (EIP-2929)
+func (txn *Txn) AddressInAccessList(addr types.Address) bool {
+	_, exists := txn.txn.Get(accessListAddressKey(addr))
+
+	return exists
+}
+
+// SlotInAccessList returns whether the address and the storage slot are warm (EIP-2929)
+func (txn *Txn) SlotInAccessList(addr types.Address, slot types.Hash) (addressOk bool, slotOk bool) {
+	_, slotOk = txn.txn.Get(accessListSlotKey(addr, slot))
+
+	return txn.AddressInAccessList(addr), slotOk
+}
+
+// AddAddressToAccessList marks the address as warm
+func (txn *Txn) AddAddressToAccessList(addr types.Address) {
+	txn.txn.Insert(accessListAddressKey(addr), true)
+}
+
+// AddSlotToAccessList marks the address and the storage slot as warm
+func (txn *Txn) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	txn.AddAddressToAccessList(addr)
+	txn.txn.Insert(accessListSlotKey(addr, slot), true)
+}
+
+// ClearAccessList removes all the addresses and storage slots from the access list
+func (txn *Txn) ClearAccessList() {
+	txn.txn.DeletePrefix(accessListIndex)
+}
+
 // GetCommittedState returns the state of the address in the trie
 func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash {
 	obj, ok := txn.getStateObject(addr)
@@ -583,8 +630,9 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) {
 		txn.txn.Insert(k, obj2)
 	}
 
-	// delete refunds
+	// delete refunds and the access list
 	txn.txn.Delete(refundIndex)
+	txn.ClearAccessList()
 }
 
 func (txn *Txn) Commit(deleteEmptyObjects bool) []*Object {
@@ -70,3 +70,34 @@ func TestSnapshotUpdateData(t *testing.T) {
 	txn.RevertToSnapshot(ss)
 	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
 }
+
+func TestAccessList(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	txn.AddAddressToAccessList(addr1)
+	assert.True(t, txn.AddressInAccessList(addr1))
+
+	addressOk, slotOk := txn.SlotInAccessList(addr1, hash1)
+	assert.True(t, addressOk)
+	assert.False(t, slotOk)
+
+	// the access list changes are reverted together with the state
+	ss := txn.Snapshot()
+	txn.AddSlotToAccessList(addr2, hash1)
+
+	addressOk, slotOk = txn.SlotInAccessList(addr2, hash1)
+	assert.True(t, addressOk)
+	assert.True(t, slotOk)
+
+	txn.RevertToSnapshot(ss)
+
+	addressOk, slotOk = txn.SlotInAccessList(addr2, hash1)
+	assert.False(t, addressOk)
+	assert.False(t, slotOk)
+
+	// the access list entries are not committed to the state
+	assert.Empty(t, txn.Commit(false))
+
+	txn.ClearAccessList()
+	assert.False(t, txn.AddressInAccessList(addr1))
+}
@@ -290,6 +290,11 @@ func (m *syncPeerClient) CloseStream(peerID peer.ID) error {
 	return m.network.CloseProtocolStream(syncerProto, peerID)
 }
 
+// ReportInvalidBlock penalizes the peer for serving a block that fails verification
+func (m *syncPeerClient) ReportInvalidBlock(peerID peer.ID, err error) {
+	m.network.ReportPeer(peerID, network.PenaltyInvalidBlock, fmt.Sprintf("invalid block: %v", err))
+}
+
 // GetBlocks returns a stream of blocks from given height to peer's latest
 func (m *syncPeerClient) GetBlocks(
 	peerID peer.ID,
@@ -0,0 +1,336 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.7
+// source: syncer/proto/snap.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SnapSyncClient is the client API for SnapSync service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SnapSyncClient interface {
+	// Returns server's status
+	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SnapStatus, error)
+	// Returns the pivot block preceded by the recent blocks
+	GetPivotBlocks(ctx context.Context, in *PivotBlocksRequest, opts ...grpc.CallOption) (*PivotBlocksResponse, error)
+	// Returns the headers starting from the given one
+	GetHeaders(ctx context.Context, in *HeadersRequest, opts ...grpc.CallOption) (*HeadersResponse, error)
+	// Returns the range of the accounts of the state with the proofs of its boundaries
+	GetAccountRange(ctx context.Context, in *AccountRangeRequest, opts ...grpc.CallOption) (*AccountRangeResponse, error)
+	// Returns the ranges of the storage slots of the accounts
+	GetStorageRanges(ctx context.Context, in *StorageRangesRequest, opts ...grpc.CallOption) (*StorageRangesResponse, error)
+	// Returns the trie nodes by their hashes
+	GetTrieNodes(ctx context.Context, in *TrieNodesRequest, opts ...grpc.CallOption) (*TrieNodesResponse, error)
+	// Returns the contract codes by their hashes
+	GetByteCodes(ctx context.Context, in *ByteCodesRequest, opts ...grpc.CallOption) (*ByteCodesResponse, error)
+}
+
+type snapSyncClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSnapSyncClient(cc grpc.ClientConnInterface) SnapSyncClient {
+	return &snapSyncClient{cc}
+}
+
+func (c *snapSyncClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SnapStatus, error) {
+	out := new(SnapStatus)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapSyncClient) GetPivotBlocks(ctx context.Context, in *PivotBlocksRequest, opts ...grpc.CallOption) (*PivotBlocksResponse, error) {
+	out := new(PivotBlocksResponse)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetPivotBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapSyncClient) GetHeaders(ctx context.Context, in *HeadersRequest, opts ...grpc.CallOption) (*HeadersResponse, error) {
+	out := new(HeadersResponse)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetHeaders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapSyncClient) GetAccountRange(ctx context.Context, in *AccountRangeRequest, opts ...grpc.CallOption) (*AccountRangeResponse, error) {
+	out := new(AccountRangeResponse)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetAccountRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapSyncClient) GetStorageRanges(ctx context.Context, in *StorageRangesRequest, opts ...grpc.CallOption) (*StorageRangesResponse, error) {
+	out := new(StorageRangesResponse)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetStorageRanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapSyncClient) GetTrieNodes(ctx context.Context, in *TrieNodesRequest, opts ...grpc.CallOption) (*TrieNodesResponse, error) {
+	out := new(TrieNodesResponse)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetTrieNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapSyncClient) GetByteCodes(ctx context.Context, in *ByteCodesRequest, opts ...grpc.CallOption) (*ByteCodesResponse, error) {
+	out := new(ByteCodesResponse)
+	err := c.cc.Invoke(ctx, "/v1.SnapSync/GetByteCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SnapSyncServer is the server API for SnapSync service.
+// All implementations must embed UnimplementedSnapSyncServer
+// for forward compatibility
+type SnapSyncServer interface {
+	// Returns server's status
+	GetStatus(context.Context, *emptypb.Empty) (*SnapStatus, error)
+	// Returns the pivot block preceded by the recent blocks
+	GetPivotBlocks(context.Context, *PivotBlocksRequest) (*PivotBlocksResponse, error)
+	// Returns the headers starting from the given one
+	GetHeaders(context.Context, *HeadersRequest) (*HeadersResponse, error)
+	// Returns the range of the accounts of the state with the proofs of its boundaries
+	GetAccountRange(context.Context, *AccountRangeRequest) (*AccountRangeResponse, error)
+	// Returns the ranges of the storage slots of the accounts
+	GetStorageRanges(context.Context, *StorageRangesRequest) (*StorageRangesResponse, error)
+	// Returns the trie nodes by their hashes
+	GetTrieNodes(context.Context, *TrieNodesRequest) (*TrieNodesResponse, error)
+	// Returns the contract codes by their hashes
+	GetByteCodes(context.Context, *ByteCodesRequest) (*ByteCodesResponse, error)
+	mustEmbedUnimplementedSnapSyncServer()
+}
+
+// UnimplementedSnapSyncServer must be embedded to have forward compatible implementations.
+type UnimplementedSnapSyncServer struct {
+}
+
+func (UnimplementedSnapSyncServer) GetStatus(context.Context, *emptypb.Empty) (*SnapStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedSnapSyncServer) GetPivotBlocks(context.Context, *PivotBlocksRequest) (*PivotBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPivotBlocks not implemented")
+}
+func (UnimplementedSnapSyncServer) GetHeaders(context.Context, *HeadersRequest) (*HeadersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
+func (UnimplementedSnapSyncServer) GetAccountRange(context.Context, *AccountRangeRequest) (*AccountRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountRange not implemented")
+}
+func (UnimplementedSnapSyncServer) GetStorageRanges(context.Context, *StorageRangesRequest) (*StorageRangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageRanges not implemented")
+}
+func (UnimplementedSnapSyncServer) GetTrieNodes(context.Context, *TrieNodesRequest) (*TrieNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrieNodes not implemented")
+}
+func (UnimplementedSnapSyncServer) GetByteCodes(context.Context, *ByteCodesRequest) (*ByteCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByteCodes not implemented")
+}
+func (UnimplementedSnapSyncServer) mustEmbedUnimplementedSnapSyncServer() {}
+
+// UnsafeSnapSyncServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SnapSyncServer will
+// result in compilation errors.
+type UnsafeSnapSyncServer interface {
+	mustEmbedUnimplementedSnapSyncServer()
+}
+
+func RegisterSnapSyncServer(s grpc.ServiceRegistrar, srv SnapSyncServer) {
+	s.RegisterService(&SnapSync_ServiceDesc, srv)
+}
+
+func _SnapSync_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapSync_GetPivotBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PivotBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetPivotBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetPivotBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetPivotBlocks(ctx, req.(*PivotBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapSync_GetHeaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeadersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetHeaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetHeaders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetHeaders(ctx, req.(*HeadersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapSync_GetAccountRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetAccountRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetAccountRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetAccountRange(ctx, req.(*AccountRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapSync_GetStorageRanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageRangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetStorageRanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetStorageRanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetStorageRanges(ctx, req.(*StorageRangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapSync_GetTrieNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrieNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetTrieNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetTrieNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetTrieNodes(ctx, req.(*TrieNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapSync_GetByteCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ByteCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapSyncServer).GetByteCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SnapSync/GetByteCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapSyncServer).GetByteCodes(ctx, req.(*ByteCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SnapSync_ServiceDesc is the grpc.ServiceDesc for SnapSync service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SnapSync_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.SnapSync",
+	HandlerType: (*SnapSyncServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _SnapSync_GetStatus_Handler,
+		},
+		{
+			MethodName: "GetPivotBlocks",
+			Handler:    _SnapSync_GetPivotBlocks_Handler,
+		},
+		{
+			MethodName: "GetHeaders",
+			Handler:    _SnapSync_GetHeaders_Handler,
+		},
+		{
+			MethodName: "GetAccountRange",
+			Handler:    _SnapSync_GetAccountRange_Handler,
+		},
+		{
+			MethodName: "GetStorageRanges",
+			Handler:    _SnapSync_GetStorageRanges_Handler,
+		},
+		{
+			MethodName: "GetTrieNodes",
+			Handler:    _SnapSync_GetTrieNodes_Handler,
+		},
+		{
+			MethodName: "GetByteCodes",
+			Handler:    _SnapSync_GetByteCodes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "syncer/proto/snap.proto",
+}
@@ -0,0 +1,353 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/vishnushankarsg/metad/network/grpc"
+	"github.com/vishnushankarsg/metad/state"
+	itrie "github.com/vishnushankarsg/metad/state/immutable-trie"
+	"github.com/vishnushankarsg/metad/syncer/proto"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+const (
+	// maxSnapRangeResults is the maximum number of the accounts or the storage slots in the response
+	maxSnapRangeResults = 4096
+
+	// maxSnapStorageAccounts is the maximum number of the accounts in the storage ranges request
+	maxSnapStorageAccounts = 128
+
+	// maxSnapTrieNodes is the maximum number of the trie nodes or the codes in the response
+	maxSnapTrieNodes = 1024
+
+	// maxSnapPivotBlocks is the maximum number of the blocks in the pivot blocks response
+	maxSnapPivotBlocks = 256
+
+	// maxSnapHeaders is the maximum number of the headers in the headers response
+	maxSnapHeaders = 1024
+
+	// snapResponseSizeLimit is the size of the response in bytes after which no more data is added,
+	// so the response fits into the gRPC message
+	snapResponseSizeLimit = 2 * 1024 * 1024
+)
+
+var (
+	errInvalidSnapHash = errors.New("invalid hash")
+)
+
+type snapSyncService struct {
+	proto.UnimplementedSnapSyncServer
+
+	blockchain   SnapBlockchain   // reference to the blockchain module
+	network      Network          // reference to the network module
+	stateStorage itrie.Storage    // reference to the state storage
+	stream       *grpc.GrpcStream // reference to the grpc stream
+}
+
+func newSnapSyncService(
+	network Network,
+	blockchain SnapBlockchain,
+	stateStorage itrie.Storage,
+) *snapSyncService {
+	return &snapSyncService{
+		blockchain:   blockchain,
+		network:      network,
+		stateStorage: stateStorage,
+	}
+}
+
+// Start starts snapSyncService
+func (s *snapSyncService) Start() {
+	s.stream = grpc.NewGrpcStream()
+
+	proto.RegisterSnapSyncServer(s.stream.GrpcServer(), s)
+	s.stream.Serve()
+	s.network.RegisterProtocol(snapProto, s.stream)
+}
+
+// Close closes snapSyncService
+func (s *snapSyncService) Close() error {
+	return s.stream.Close()
+}
+
+// GetStatus is a gRPC endpoint to return the latest block number as a node status
+func (s *snapSyncService) GetStatus(
+	ctx context.Context,
+	req *empty.Empty,
+) (*proto.SnapStatus, error) {
+	var number uint64
+	if header := s.blockchain.Header(); header != nil {
+		number = header.Number
+	}
+
+	return &proto.SnapStatus{
+		Number: number,
+	}, nil
+}
+
+// GetPivotBlocks is a gRPC endpoint to return the pivot block preceded by the recent blocks
+func (s *snapSyncService) GetPivotBlocks(
+	ctx context.Context,
+	req *proto.PivotBlocksRequest,
+) (*proto.PivotBlocksResponse, error) {
+	pivot, ok := s.blockchain.GetBlockByNumber(req.Number, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	td, ok := s.blockchain.GetTD(pivot.Hash())
+	if !ok {
+		return nil, fmt.Errorf("total difficulty of block %d not found", req.Number)
+	}
+
+	count := req.Count
+	if count == 0 || count > maxSnapPivotBlocks {
+		count = maxSnapPivotBlocks
+	}
+
+	// the blocks are collected from the pivot one down
+	var (
+		blocks = [][]byte{pivot.MarshalRLP()}
+		size   = len(blocks[0])
+	)
+
+	for number := req.Number; uint64(len(blocks)) < count && number > 0 && size < snapResponseSizeLimit; {
+		number--
+
+		block, ok := s.blockchain.GetBlockByNumber(number, true)
+		if !ok {
+			return nil, ErrBlockNotFound
+		}
+
+		data := block.MarshalRLP()
+		blocks = append(blocks, data)
+		size += len(data)
+	}
+
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+
+	return &proto.PivotBlocksResponse{
+		Blocks:          blocks,
+		TotalDifficulty: td.Bytes(),
+	}, nil
+}
+
+// GetHeaders is a gRPC endpoint to return the headers starting from the given one
+func (s *snapSyncService) GetHeaders(
+	ctx context.Context,
+	req *proto.HeadersRequest,
+) (*proto.HeadersResponse, error) {
+	count := req.Count
+	if count == 0 || count > maxSnapHeaders {
+		count = maxSnapHeaders
+	}
+
+	headers := make([][]byte, 0, count)
+
+	for number := req.From; uint64(len(headers)) < count; number++ {
+		header, ok := s.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			break
+		}
+
+		headers = append(headers, header.MarshalRLP())
+	}
+
+	if len(headers) == 0 {
+		return nil, ErrBlockNotFound
+	}
+
+	return &proto.HeadersResponse{
+		Headers: headers,
+	}, nil
+}
+
+// GetAccountRange is a gRPC endpoint to return the range of the accounts of the state,
+// along with the proofs of the first and the last accounts
+func (s *snapSyncService) GetAccountRange(
+	ctx context.Context,
+	req *proto.AccountRangeRequest,
+) (*proto.AccountRangeResponse, error) {
+	if len(req.Root) != types.HashLength || len(req.Origin) != types.HashLength {
+		return nil, errInvalidSnapHash
+	}
+
+	root := types.BytesToHash(req.Root)
+
+	accounts, _, err := s.getRange(root, req.Origin, limitSnapResults(req.MaxResults))
+	if err != nil {
+		return nil, err
+	}
+
+	var last []byte
+	if len(accounts) > 0 {
+		last = accounts[len(accounts)-1].Hash
+	}
+
+	proof, err := itrie.ProveRange(root, req.Origin, last, s.stateStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.AccountRangeResponse{
+		Accounts: accounts,
+		Proof:    proof,
+	}, nil
+}
+
+// GetStorageRanges is a gRPC endpoint to return the ranges of the storage slots of the accounts.
+// Only the last range may be incomplete, it's returned along with the proofs of its first and last slots
+func (s *snapSyncService) GetStorageRanges(
+	ctx context.Context,
+	req *proto.StorageRangesRequest,
+) (*proto.StorageRangesResponse, error) {
+	if len(req.Root) != types.HashLength || len(req.Accounts) > maxSnapStorageAccounts {
+		return nil, errInvalidSnapHash
+	}
+
+	var (
+		root   = types.BytesToHash(req.Root)
+		origin = req.Origin
+		limit  = limitSnapResults(req.MaxResults)
+		resp   = &proto.StorageRangesResponse{}
+	)
+
+	if len(origin) == 0 {
+		origin = types.ZeroHash.Bytes()
+	}
+
+	for _, accountHash := range req.Accounts {
+		if limit == 0 {
+			break
+		}
+
+		storageRoot, err := s.getStorageRoot(root, accountHash)
+		if err != nil {
+			return nil, err
+		}
+
+		slots, complete, err := s.getRange(storageRoot, origin, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Ranges = append(resp.Ranges, &proto.StorageRange{Slots: slots})
+
+		if !complete {
+			if resp.Proof, err = itrie.ProveRange(
+				storageRoot, origin, slots[len(slots)-1].Hash, s.stateStorage,
+			); err != nil {
+				return nil, err
+			}
+
+			break
+		}
+
+		limit -= uint64(len(slots))
+		origin = types.ZeroHash.Bytes()
+	}
+
+	return resp, nil
+}
+
+// GetTrieNodes is a gRPC endpoint to return the trie nodes by their hashes
+func (s *snapSyncService) GetTrieNodes(
+	ctx context.Context,
+	req *proto.TrieNodesRequest,
+) (*proto.TrieNodesResponse, error) {
+	resp := &proto.TrieNodesResponse{}
+
+	for i, size := 0, 0; i < len(req.Hashes) && i < maxSnapTrieNodes && size < snapResponseSizeLimit; i++ {
+		node, _ := s.stateStorage.Get(req.Hashes[i])
+		resp.Nodes = append(resp.Nodes, node)
+		size += len(node)
+	}
+
+	return resp, nil
+}
+
+// GetByteCodes is a gRPC endpoint to return the contract codes by their hashes
+func (s *snapSyncService) GetByteCodes(
+	ctx context.Context,
+	req *proto.ByteCodesRequest,
+) (*proto.ByteCodesResponse, error) {
+	resp := &proto.ByteCodesResponse{}
+
+	for i, size := 0, 0; i < len(req.Hashes) && i < maxSnapTrieNodes && size < snapResponseSizeLimit; i++ {
+		code, _ := s.stateStorage.GetCode(types.BytesToHash(req.Hashes[i]))
+		resp.Codes = append(resp.Codes, code)
+		size += len(code)
+	}
+
+	return resp, nil
+}
+
+// getRange returns the leaves of the trie starting from the origin, up to the limit,
+// and whether there are no more leaves in the trie
+func (s *snapSyncService) getRange(
+	root types.Hash,
+	origin []byte,
+	limit uint64,
+) ([]*proto.TrieEntry, bool, error) {
+	var (
+		entries  []*proto.TrieEntry
+		size     int
+		complete = true
+	)
+
+	err := itrie.IterateRange(root, origin, s.stateStorage, func(key, value []byte) bool {
+		if uint64(len(entries)) >= limit || size >= snapResponseSizeLimit {
+			complete = false
+
+			return false
+		}
+
+		entries = append(entries, &proto.TrieEntry{Hash: key, Value: value})
+		size += len(key) + len(value)
+
+		return true
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return entries, complete, nil
+}
+
+// getStorageRoot returns the storage root of the account with the given hash
+func (s *snapSyncService) getStorageRoot(root types.Hash, accountHash []byte) (types.Hash, error) {
+	if len(accountHash) != types.HashLength {
+		return types.ZeroHash, errInvalidSnapHash
+	}
+
+	data, err := itrie.GetLeaf(root, accountHash, s.stateStorage)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if data == nil {
+		return types.EmptyRootHash, nil
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return types.ZeroHash, err
+	}
+
+	return account.Root, nil
+}
+
+// limitSnapResults returns the requested number of the results, capped by the maximum
+func limitSnapResults(max uint64) uint64 {
+	if max == 0 || max > maxSnapRangeResults {
+		return maxSnapRangeResults
+	}
+
+	return max
+}
@@ -0,0 +1,877 @@
+package syncer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/state"
+	itrie "github.com/vishnushankarsg/metad/state/immutable-trie"
+	"github.com/vishnushankarsg/metad/syncer/proto"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+const (
+	snapSyncerName = "snap-syncer"
+	snapProto      = "/snap/0.1"
+
+	// snapPivotDistance is the number of the blocks between the pivot block and the head of the best peer,
+	// so the peers keep the state of the pivot block while it's downloaded
+	snapPivotDistance = 64
+
+	// snapMinPivotPeers is the minimum number of the peers agreeing on the pivot block
+	snapMinPivotPeers = 2
+
+	// snapPeersTimeout is the time to wait for the peers to sync the state from
+	snapPeersTimeout = time.Minute
+
+	// snapRequestTimeout is the timeout of a single request to the peer
+	snapRequestTimeout = 30 * time.Second
+
+	// snapLogInterval is the number of the accounts between the progress logs
+	snapLogInterval = 10000
+)
+
+var (
+	emptyCodeHash = types.BytesToHash(crypto.Keccak256(nil))
+
+	errNoSnapPeers          = errors.New("no peers left to snap sync with")
+	errNoPivotAgreement     = errors.New("not enough peers agree on the pivot block")
+	errInvalidSnapResponse  = errors.New("invalid snap sync response")
+	errSnapResponseNoResult = errors.New("peer returned no results")
+)
+
+// snapPeer is the peer which is able to serve the state of the pivot block
+type snapPeer struct {
+	id     peer.ID
+	number uint64
+	client proto.SnapSyncClient
+}
+
+// storageTask is the storage trie of the account to download
+type storageTask struct {
+	accountHash types.Hash
+	root        types.Hash
+	origin      []byte
+	builder     *itrie.TrieBuilder
+}
+
+// snapSyncer downloads the state of the recent block from the peers,
+// so the new node doesn't have to execute the whole history of the chain.
+// The state is downloaded by the ranges of the accounts and the storage slots,
+// then the trie nodes which are still missing or don't match are downloaded by their hashes
+type snapSyncer struct {
+	logger       hclog.Logger
+	network      Network
+	blockchain   SnapBlockchain
+	stateStorage itrie.Storage
+	service      *snapSyncService
+
+	// the headers are synced from the start of the epoch of the first block,
+	// so the consensus can rebuild the validator set
+	epochSize uint64
+
+	peers    []*snapPeer
+	nextPeer int
+
+	// synced storage roots, shared by many accounts
+	syncedRoots map[types.Hash]struct{}
+	// codes to download
+	codes map[types.Hash]struct{}
+}
+
+func NewSnapSyncer(
+	logger hclog.Logger,
+	network Network,
+	blockchain SnapBlockchain,
+	stateStorage itrie.Storage,
+	epochSize uint64,
+) SnapSyncer {
+	return &snapSyncer{
+		logger:       logger.Named(snapSyncerName),
+		network:      network,
+		blockchain:   blockchain,
+		stateStorage: stateStorage,
+		service:      newSnapSyncService(network, blockchain, stateStorage),
+		epochSize:    epochSize,
+	}
+}
+
+// Start starts serving the state to the peers
+func (s *snapSyncer) Start() {
+	s.service.Start()
+}
+
+// Close terminates snap sync processes
+func (s *snapSyncer) Close() error {
+	return s.service.Close()
+}
+
+// Sync downloads the state of the block a bit behind the head of the best peer,
+// and writes the block along with the recent ones to the empty chain,
+// so the block sync continues after it. The pivot block and its total difficulty have to be agreed on
+// by the majority of the peers, and the headers preceding it are verified by the consensus when they are written
+func (s *snapSyncer) Sync() error {
+	if header := s.blockchain.Header(); header != nil && header.Number != 0 {
+		s.logger.Info("chain is not empty, skipping snap sync", "number", header.Number)
+
+		return nil
+	}
+
+	if err := s.connectPeers(); err != nil {
+		return err
+	}
+
+	defer s.closePeers()
+
+	var best uint64
+
+	for _, p := range s.peers {
+		if p.number > best {
+			best = p.number
+		}
+	}
+
+	if best <= snapPivotDistance {
+		s.logger.Info("chain is too short, skipping snap sync", "number", best)
+
+		return nil
+	}
+
+	pivot := best - snapPivotDistance
+
+	// only the peers having the pivot block can serve its state
+	peers := s.peers[:0]
+
+	for _, p := range s.peers {
+		if p.number >= pivot {
+			peers = append(peers, p)
+		}
+	}
+
+	s.peers = peers
+
+	hash, td, err := s.agreePivot(pivot)
+	if err != nil {
+		return err
+	}
+
+	blocks, err := s.fetchPivotBlocks(pivot, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pivot blocks: %w", err)
+	}
+
+	headers, err := s.fetchEpochHeaders(blocks[0])
+	if err != nil {
+		return fmt.Errorf("failed to fetch epoch headers: %w", err)
+	}
+
+	root := blocks[len(blocks)-1].Header.StateRoot
+
+	s.logger.Info("snap syncing state", "number", pivot, "root", root, "peers", len(s.peers))
+
+	if err := s.syncAccounts(root); err != nil {
+		return fmt.Errorf("failed to sync accounts: %w", err)
+	}
+
+	if err := s.healState(root); err != nil {
+		return fmt.Errorf("failed to heal state: %w", err)
+	}
+
+	if err := s.blockchain.WriteSnapshotBlocks(headers, blocks, td); err != nil {
+		return err
+	}
+
+	s.logger.Info("snap sync done", "number", pivot, "hash", blocks[len(blocks)-1].Hash())
+
+	return nil
+}
+
+// connectPeers waits for the connected peers and fetches their statuses
+func (s *snapSyncer) connectPeers() error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	timeout := time.After(snapPeersTimeout)
+
+	for len(s.network.Peers()) == 0 {
+		select {
+		case <-ticker.C:
+		case <-timeout:
+			return errNoSnapPeers
+		}
+	}
+
+	for _, p := range s.network.Peers() {
+		peerID := p.Info.ID
+
+		conn, err := s.network.NewProtoConnection(snapProto, peerID)
+		if err != nil {
+			s.logger.Warn("failed to open a stream, skip", "id", peerID, "err", err)
+
+			continue
+		}
+
+		s.network.SaveProtocolStream(snapProto, conn, peerID)
+
+		client := proto.NewSnapSyncClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), snapRequestTimeout)
+		status, err := client.GetStatus(ctx, &emptypb.Empty{})
+
+		cancel()
+
+		if err != nil {
+			s.logger.Warn("failed to get status from a peer, skip", "id", peerID, "err", err)
+
+			continue
+		}
+
+		s.peers = append(s.peers, &snapPeer{id: peerID, number: status.Number, client: client})
+	}
+
+	if len(s.peers) == 0 {
+		return errNoSnapPeers
+	}
+
+	return nil
+}
+
+// closePeers closes the streams to the peers
+func (s *snapSyncer) closePeers() {
+	for _, p := range s.peers {
+		if err := s.network.CloseProtocolStream(snapProto, p.id); err != nil {
+			s.logger.Debug("failed to close stream", "id", p.id, "err", err)
+		}
+	}
+
+	s.peers = nil
+}
+
+// request sends the request to the peers in turns, until one of them responds properly.
+// The peers failing the request are not used anymore
+func (s *snapSyncer) request(send func(ctx context.Context, client proto.SnapSyncClient) error) error {
+	for len(s.peers) > 0 {
+		idx := s.nextPeer % len(s.peers)
+		p := s.peers[idx]
+
+		ctx, cancel := context.WithTimeout(context.Background(), snapRequestTimeout)
+		err := send(ctx, p.client)
+
+		cancel()
+
+		if err == nil {
+			s.nextPeer = idx + 1
+
+			return nil
+		}
+
+		s.logger.Warn("snap sync request failed, dropping peer", "id", p.id, "err", err)
+
+		if err := s.network.CloseProtocolStream(snapProto, p.id); err != nil {
+			s.logger.Debug("failed to close stream", "id", p.id, "err", err)
+		}
+
+		s.peers = append(s.peers[:idx], s.peers[idx+1:]...)
+	}
+
+	return errNoSnapPeers
+}
+
+// agreePivot asks all the peers for the pivot block and its total difficulty, and returns them
+// if the majority of the peers agree on both. The peers returning another ones are not used anymore
+func (s *snapSyncer) agreePivot(pivot uint64) (types.Hash, *big.Int, error) {
+	type vote struct {
+		hash types.Hash
+		td   string
+	}
+
+	var (
+		votes  = make(map[vote][]*snapPeer)
+		asked  = len(s.peers)
+		agreed vote
+	)
+
+	for _, p := range s.peers {
+		ctx, cancel := context.WithTimeout(context.Background(), snapRequestTimeout)
+		resp, err := p.client.GetPivotBlocks(ctx, &proto.PivotBlocksRequest{Number: pivot, Count: 1})
+
+		cancel()
+
+		if err != nil || len(resp.Blocks) == 0 {
+			s.logger.Warn("failed to get pivot block from a peer, skip", "id", p.id, "err", err)
+
+			continue
+		}
+
+		block := &types.Block{}
+		if err := block.UnmarshalRLP(resp.Blocks[len(resp.Blocks)-1]); err != nil || block.Number() != pivot {
+			s.logger.Warn("invalid pivot block from a peer, skip", "id", p.id, "err", err)
+
+			continue
+		}
+
+		v := vote{hash: block.Hash(), td: new(big.Int).SetBytes(resp.TotalDifficulty).String()}
+		votes[v] = append(votes[v], p)
+
+		if len(votes[v]) > len(votes[agreed]) {
+			agreed = v
+		}
+	}
+
+	if len(votes[agreed]) < snapMinPivotPeers || len(votes[agreed])*2 <= asked {
+		return types.ZeroHash, nil, fmt.Errorf("%w: %d of %d peers", errNoPivotAgreement, len(votes[agreed]), asked)
+	}
+
+	s.peers = votes[agreed]
+
+	td, _ := new(big.Int).SetString(agreed.td, 10)
+
+	return agreed.hash, td, nil
+}
+
+// fetchPivotBlocks fetches the pivot block with the given hash preceded by the recent blocks,
+// so the hashes of the recent blocks are available to the BLOCKHASH opcode
+func (s *snapSyncer) fetchPivotBlocks(pivot uint64, hash types.Hash) ([]*types.Block, error) {
+	var (
+		blocks []*types.Block
+		number = pivot
+	)
+
+	for uint64(len(blocks)) < maxSnapPivotBlocks {
+		var resp *proto.PivotBlocksResponse
+
+		if err := s.request(func(ctx context.Context, client proto.SnapSyncClient) (err error) {
+			resp, err = client.GetPivotBlocks(ctx, &proto.PivotBlocksRequest{
+				Number: number,
+				Count:  maxSnapPivotBlocks - uint64(len(blocks)),
+			})
+			if err == nil && len(resp.Blocks) == 0 {
+				err = errSnapResponseNoResult
+			}
+
+			return err
+		}); err != nil {
+			return nil, err
+		}
+
+		fetched := make([]*types.Block, len(resp.Blocks))
+
+		for i, data := range resp.Blocks {
+			block := &types.Block{}
+			if err := block.UnmarshalRLP(data); err != nil {
+				return nil, err
+			}
+
+			fetched[i] = block
+		}
+
+		blocks = append(fetched, blocks...)
+
+		for i := 1; i < len(blocks); i++ {
+			if blocks[i].Number() != blocks[i-1].Number()+1 || blocks[i].ParentHash() != blocks[i-1].Hash() {
+				return nil, fmt.Errorf("%w: block %d doesn't follow its parent", errInvalidSnapResponse, blocks[i].Number())
+			}
+		}
+
+		if last := blocks[len(blocks)-1]; last.Number() != pivot || last.Hash() != hash {
+			return nil, fmt.Errorf("%w: pivot block %d not found", errInvalidSnapResponse, pivot)
+		}
+
+		if blocks[0].Number() == 0 {
+			break
+		}
+
+		number = blocks[0].Number() - 1
+	}
+
+	return blocks, nil
+}
+
+// fetchEpochHeaders fetches the headers from the start of the epoch of the given block, excluding the genesis,
+// up to the block, so the consensus can rebuild the validator set of the epoch from them
+func (s *snapSyncer) fetchEpochHeaders(first *types.Block) ([]*types.Header, error) {
+	if s.epochSize == 0 || first.Number() == 0 {
+		return nil, nil
+	}
+
+	var (
+		from    = first.Number() / s.epochSize * s.epochSize
+		headers []*types.Header
+	)
+
+	if from == 0 {
+		from = 1
+	}
+
+	if from >= first.Number() {
+		return nil, nil
+	}
+
+	for number := from; number < first.Number(); {
+		var resp *proto.HeadersResponse
+
+		if err := s.request(func(ctx context.Context, client proto.SnapSyncClient) (err error) {
+			resp, err = client.GetHeaders(ctx, &proto.HeadersRequest{
+				From:  number,
+				Count: first.Number() - number,
+			})
+			if err == nil && len(resp.Headers) == 0 {
+				err = errSnapResponseNoResult
+			}
+
+			return err
+		}); err != nil {
+			return nil, err
+		}
+
+		for _, data := range resp.Headers {
+			header := &types.Header{}
+			if err := header.UnmarshalRLP(data); err != nil {
+				return nil, err
+			}
+
+			if header.Number != number {
+				return nil, fmt.Errorf("%w: expected header %d but got %d", errInvalidSnapResponse, number, header.Number)
+			}
+
+			if len(headers) > 0 && header.ParentHash != headers[len(headers)-1].Hash {
+				return nil, fmt.Errorf("%w: header %d doesn't follow its parent", errInvalidSnapResponse, number)
+			}
+
+			headers = append(headers, header)
+			number++
+
+			if number == first.Number() {
+				break
+			}
+		}
+	}
+
+	// the headers are anchored to the agreed pivot block through the recent blocks
+	if headers[len(headers)-1].Hash != first.ParentHash() {
+		return nil, fmt.Errorf("%w: headers don't lead to block %d", errInvalidSnapResponse, first.Number())
+	}
+
+	return headers, nil
+}
+
+// syncAccounts downloads the accounts of the state by their ranges,
+// along with their storage and codes, and writes the state trie
+func (s *snapSyncer) syncAccounts(root types.Hash) error {
+	var (
+		builder  = itrie.NewTrieBuilder(s.stateStorage)
+		origin   = types.ZeroHash.Bytes()
+		accounts uint64
+		nextLog  = uint64(snapLogInterval)
+	)
+
+	s.syncedRoots = map[types.Hash]struct{}{}
+	s.codes = map[types.Hash]struct{}{}
+
+	for {
+		var resp *proto.AccountRangeResponse
+
+		if err := s.request(func(ctx context.Context, client proto.SnapSyncClient) (err error) {
+			if resp, err = client.GetAccountRange(ctx, &proto.AccountRangeRequest{
+				Root:       root.Bytes(),
+				Origin:     origin,
+				MaxResults: maxSnapRangeResults,
+			}); err != nil {
+				return err
+			}
+
+			return verifyRange(root, origin, resp.Accounts, resp.Proof)
+		}); err != nil {
+			return err
+		}
+
+		if len(resp.Accounts) == 0 {
+			break
+		}
+
+		var tasks []*storageTask
+
+		for _, entry := range resp.Accounts {
+			account := &state.Account{}
+			if err := account.UnmarshalRlp(entry.Value); err != nil {
+				return err
+			}
+
+			builder.Insert(entry.Hash, entry.Value)
+
+			if _, ok := s.syncedRoots[account.Root]; !ok && account.Root != types.EmptyRootHash {
+				s.syncedRoots[account.Root] = struct{}{}
+
+				tasks = append(tasks, &storageTask{
+					accountHash: types.BytesToHash(entry.Hash),
+					root:        account.Root,
+					origin:      types.ZeroHash.Bytes(),
+				})
+			}
+
+			if codeHash := types.BytesToHash(account.CodeHash); codeHash != emptyCodeHash {
+				if _, ok := s.stateStorage.GetCode(codeHash); !ok {
+					s.codes[codeHash] = struct{}{}
+				}
+			}
+		}
+
+		if err := s.syncStorage(root, tasks); err != nil {
+			return err
+		}
+
+		if len(s.codes) >= maxSnapTrieNodes {
+			if err := s.syncCodes(); err != nil {
+				return err
+			}
+		}
+
+		if accounts += uint64(len(resp.Accounts)); accounts >= nextLog {
+			s.logger.Info("snap synced accounts", "accounts", accounts)
+
+			nextLog += snapLogInterval
+		}
+
+		var ok bool
+		if origin, ok = incHash(resp.Accounts[len(resp.Accounts)-1].Hash); !ok {
+			break
+		}
+	}
+
+	if err := s.syncCodes(); err != nil {
+		return err
+	}
+
+	accountRoot, err := builder.Commit()
+	if err != nil {
+		return err
+	}
+
+	if accountRoot != root {
+		s.logger.Warn("state root mismatch after downloading ranges, healing", "root", accountRoot, "expected", root)
+	}
+
+	s.logger.Info("snap synced accounts", "accounts", accounts)
+
+	return nil
+}
+
+// syncStorage downloads the storage of the accounts by their ranges, and writes the storage tries
+func (s *snapSyncer) syncStorage(root types.Hash, tasks []*storageTask) error {
+	for len(tasks) > 0 {
+		var (
+			resp   *proto.StorageRangesResponse
+			batch  = tasks
+			hashes = make([][]byte, 0, maxSnapStorageAccounts)
+		)
+
+		if len(batch) > maxSnapStorageAccounts {
+			batch = batch[:maxSnapStorageAccounts]
+		}
+
+		for _, task := range batch {
+			hashes = append(hashes, task.accountHash.Bytes())
+		}
+
+		if err := s.request(func(ctx context.Context, client proto.SnapSyncClient) (err error) {
+			if resp, err = client.GetStorageRanges(ctx, &proto.StorageRangesRequest{
+				Root:       root.Bytes(),
+				Accounts:   hashes,
+				Origin:     batch[0].origin,
+				MaxResults: maxSnapRangeResults,
+			}); err != nil {
+				return err
+			}
+
+			if len(resp.Ranges) == 0 || len(resp.Ranges) > len(batch) {
+				return fmt.Errorf("%w: %d storage ranges for %d accounts", errInvalidSnapResponse, len(resp.Ranges), len(batch))
+			}
+
+			for i, r := range resp.Ranges {
+				origin := types.ZeroHash.Bytes()
+				if i == 0 {
+					origin = batch[0].origin
+				}
+
+				// only the proof of the last range is given, when it's incomplete
+				var proof [][]byte
+				if i == len(resp.Ranges)-1 {
+					if proof = resp.Proof; len(proof) > 0 && len(r.Slots) == 0 {
+						return fmt.Errorf("%w: empty incomplete storage range", errInvalidSnapResponse)
+					}
+				}
+
+				if err := verifyRange(batch[i].root, origin, r.Slots, proof); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		completed := 0
+
+		for i, r := range resp.Ranges {
+			task := batch[i]
+
+			if task.builder == nil {
+				task.builder = itrie.NewTrieBuilder(s.stateStorage)
+			}
+
+			for _, slot := range r.Slots {
+				task.builder.Insert(slot.Hash, slot.Value)
+			}
+
+			if i == len(resp.Ranges)-1 && len(resp.Proof) > 0 {
+				// the range is incomplete, continue after its last slot
+				if origin, ok := incHash(r.Slots[len(r.Slots)-1].Hash); ok {
+					task.origin = origin
+
+					break
+				}
+			}
+
+			storageRoot, err := task.builder.Commit()
+			if err != nil {
+				return err
+			}
+
+			if storageRoot != task.root {
+				s.logger.Debug("storage root mismatch, healing", "account", task.accountHash, "root", storageRoot)
+			}
+
+			task.builder = nil
+			completed++
+		}
+
+		tasks = tasks[completed:]
+	}
+
+	return nil
+}
+
+// syncCodes downloads the codes of the accounts
+func (s *snapSyncer) syncCodes() error {
+	for len(s.codes) > 0 {
+		hashes := make([][]byte, 0, len(s.codes))
+
+		for hash := range s.codes {
+			if len(hashes) == maxSnapTrieNodes {
+				break
+			}
+
+			hashes = append(hashes, hash.Bytes())
+		}
+
+		if err := s.request(func(ctx context.Context, client proto.SnapSyncClient) error {
+			resp, err := client.GetByteCodes(ctx, &proto.ByteCodesRequest{Hashes: hashes})
+			if err != nil {
+				return err
+			}
+
+			var added int
+
+			for i, code := range resp.Codes {
+				if i >= len(hashes) || len(code) == 0 {
+					continue
+				}
+
+				hash := types.BytesToHash(hashes[i])
+				if types.BytesToHash(crypto.Keccak256(code)) != hash {
+					return fmt.Errorf("%w: code hash %s mismatch", errInvalidSnapResponse, hash)
+				}
+
+				s.stateStorage.SetCode(hash, code)
+				delete(s.codes, hash)
+
+				added++
+			}
+
+			if added == 0 {
+				return errSnapResponseNoResult
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// healState downloads the trie nodes and the codes of the state which are still missing
+func (s *snapSyncer) healState(root types.Hash) error {
+	healer := itrie.NewStateHealer(root, s.stateStorage)
+
+	var healed uint64
+
+	for {
+		nodes, codes, err := healer.Missing(maxSnapTrieNodes)
+		if err != nil {
+			return err
+		}
+
+		if len(nodes) == 0 && len(codes) == 0 {
+			break
+		}
+
+		if len(nodes) > 0 {
+			if err := s.request(func(ctx context.Context, client proto.SnapSyncClient) error {
+				return healNodes(ctx, client, healer, nodes)
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(codes) > 0 {
+			if err := s.request(func(ctx context.Context, client proto.SnapSyncClient) error {
+				return healCodes(ctx, client, healer, codes)
+			}); err != nil {
+				return err
+			}
+		}
+
+		healed += uint64(len(nodes) + len(codes))
+	}
+
+	if healed > 0 {
+		s.logger.Info("snap healed state", "items", healed)
+	}
+
+	return nil
+}
+
+// healNodes fetches the missing trie nodes from the peer
+func healNodes(ctx context.Context, client proto.SnapSyncClient, healer *itrie.StateHealer, nodes []types.Hash) error {
+	hashes := make([][]byte, len(nodes))
+	for i, hash := range nodes {
+		hashes[i] = hash.Bytes()
+	}
+
+	resp, err := client.GetTrieNodes(ctx, &proto.TrieNodesRequest{Hashes: hashes})
+	if err != nil {
+		return err
+	}
+
+	var added int
+
+	for i, node := range resp.Nodes {
+		if i >= len(nodes) || len(node) == 0 {
+			continue
+		}
+
+		if err := healer.AddNode(nodes[i], node); err != nil {
+			return err
+		}
+
+		added++
+	}
+
+	if added == 0 {
+		return errSnapResponseNoResult
+	}
+
+	return nil
+}
+
+// healCodes fetches the missing codes from the peer
+func healCodes(ctx context.Context, client proto.SnapSyncClient, healer *itrie.StateHealer, codes []types.Hash) error {
+	hashes := make([][]byte, len(codes))
+	for i, hash := range codes {
+		hashes[i] = hash.Bytes()
+	}
+
+	resp, err := client.GetByteCodes(ctx, &proto.ByteCodesRequest{Hashes: hashes})
+	if err != nil {
+		return err
+	}
+
+	var added int
+
+	for i, code := range resp.Codes {
+		if i >= len(codes) || len(code) == 0 {
+			continue
+		}
+
+		if err := healer.AddCode(codes[i], code); err != nil {
+			return err
+		}
+
+		added++
+	}
+
+	if added == 0 {
+		return errSnapResponseNoResult
+	}
+
+	return nil
+}
+
+// verifyRange checks the leaves of the trie range are ordered, start from the origin,
+// and the first and the last of them are proven, if the proof is given
+func verifyRange(root types.Hash, origin []byte, entries []*proto.TrieEntry, proof [][]byte) error {
+	prev := origin
+
+	for i, entry := range entries {
+		if len(entry.Hash) != types.HashLength {
+			return fmt.Errorf("%w: invalid key length", errInvalidSnapResponse)
+		}
+
+		if cmp := bytes.Compare(entry.Hash, prev); cmp < 0 || (cmp == 0 && i > 0) {
+			return fmt.Errorf("%w: keys are not ordered", errInvalidSnapResponse)
+		}
+
+		prev = entry.Hash
+	}
+
+	if len(proof) == 0 {
+		return nil
+	}
+
+	value, err := itrie.VerifyProof(root, origin, proof)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidSnapResponse, err)
+	}
+
+	if value != nil && (len(entries) == 0 || !bytes.Equal(entries[0].Hash, origin) || !bytes.Equal(entries[0].Value, value)) {
+		return fmt.Errorf("%w: first key doesn't match the proof", errInvalidSnapResponse)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	last := entries[len(entries)-1]
+
+	if value, err = itrie.VerifyProof(root, last.Hash, proof); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidSnapResponse, err)
+	}
+
+	if !bytes.Equal(value, last.Value) {
+		return fmt.Errorf("%w: last key doesn't match the proof", errInvalidSnapResponse)
+	}
+
+	return nil
+}
+
+// incHash returns the hash following the given one, false if it's the last hash
+func incHash(hash []byte) ([]byte, bool) {
+	next := new(big.Int).Add(new(big.Int).SetBytes(hash), big.NewInt(1))
+	if next.BitLen() > types.HashLength*8 {
+		return nil, false
+	}
+
+	return types.BytesToHash(next.Bytes()).Bytes(), true
+}
@@ -0,0 +1,393 @@
+package syncer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/network"
+	"github.com/vishnushankarsg/metad/state"
+	itrie "github.com/vishnushankarsg/metad/state/immutable-trie"
+	"github.com/vishnushankarsg/metad/syncer/proto"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+type mockSnapBlockchain struct {
+	blocks []*types.Block
+	// extraTD is added to the total difficulties the chain reports
+	extraTD uint64
+
+	writtenHeaders []*types.Header
+	writtenBlocks  []*types.Block
+	writtenTD      *big.Int
+}
+
+func (m *mockSnapBlockchain) Header() *types.Header {
+	if len(m.blocks) == 0 {
+		return &types.Header{Number: 0}
+	}
+
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockSnapBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Block, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number], true
+}
+
+func (m *mockSnapBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	block, ok := m.GetBlockByNumber(number, false)
+	if !ok {
+		return nil, false
+	}
+
+	return block.Header, true
+}
+
+func (m *mockSnapBlockchain) GetTD(hash types.Hash) (*big.Int, bool) {
+	for _, b := range m.blocks {
+		if b.Hash() == hash {
+			return new(big.Int).SetUint64(b.Number() + 1 + m.extraTD), true
+		}
+	}
+
+	return nil, false
+}
+
+func (m *mockSnapBlockchain) WriteSnapshotBlocks(headers []*types.Header, blocks []*types.Block, td *big.Int) error {
+	m.writtenHeaders = headers
+	m.writtenBlocks = blocks
+	m.writtenTD = td
+
+	return nil
+}
+
+// newTestSnapState writes the state with the contracts sharing the code,
+// one of them having more storage slots than fit into a single range
+func newTestSnapState(t *testing.T, accounts int) (types.Hash, itrie.Storage) {
+	t.Helper()
+
+	var (
+		storage = itrie.NewMemoryStorage()
+		code    = []byte{0x60, 0x01, 0x60, 0x02}
+		objs    = make([]*state.Object, 0, accounts)
+	)
+
+	for i := 0; i < accounts; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i + 1)),
+			Root:     types.EmptyRootHash,
+			CodeHash: emptyCodeHash,
+		}
+
+		if i%100 == 0 {
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.Code = code
+			obj.DirtyCode = true
+
+			slots := 3
+			if i == 0 {
+				slots = maxSnapRangeResults + 100
+			}
+
+			for j := 0; j < slots; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash(big.NewInt(int64(j)).Bytes()).Bytes(),
+					Val: types.BytesToHash(big.NewInt(int64(i + j + 1)).Bytes()).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root := itrie.NewState(storage).NewSnapshot().Commit(objs)
+
+	return types.BytesToHash(root), storage
+}
+
+// newTestSnapBlocks creates the chain of the given length with the given state root
+func newTestSnapBlocks(length int, root types.Hash) []*types.Block {
+	blocks := make([]*types.Block, length)
+	parent := types.ZeroHash
+
+	for i := range blocks {
+		header := &types.Header{
+			Number:       uint64(i),
+			ParentHash:   parent,
+			StateRoot:    root,
+			Difficulty:   1,
+			TxRoot:       types.EmptyRootHash,
+			ReceiptsRoot: types.EmptyRootHash,
+			Sha3Uncles:   types.EmptyUncleHash,
+		}
+		header.ComputeHash()
+
+		blocks[i] = &types.Block{Header: header}
+		parent = header.Hash
+	}
+
+	return blocks
+}
+
+// newTestSnapPeers starts the peers serving the given chain and state, and connects them to the client
+func newTestSnapPeers(t *testing.T, client *network.Server, chain *mockSnapBlockchain, storage itrie.Storage, count int) {
+	t.Helper()
+
+	for i := 0; i < count; i++ {
+		peerSrv := newTestNetwork(t)
+		newSnapSyncService(peerSrv, chain, storage).Start()
+
+		require.NoError(t, network.JoinAndWait(
+			client,
+			peerSrv,
+			network.DefaultBufferTimeout,
+			network.DefaultJoinTimeout,
+		))
+	}
+}
+
+func TestSnapSyncer_Sync(t *testing.T) {
+	t.Parallel()
+
+	root, peerStorage := newTestSnapState(t, maxSnapRangeResults+500)
+	peerChain := &mockSnapBlockchain{blocks: newTestSnapBlocks(snapPivotDistance+300, root)}
+
+	clientSrv := newTestNetwork(t)
+	clientChain := &mockSnapBlockchain{}
+	clientStorage := itrie.NewMemoryStorage()
+
+	// the peer serving another chain is outvoted
+	newTestSnapPeers(t, clientSrv, peerChain, peerStorage, 2)
+	newTestSnapPeers(t, clientSrv, &mockSnapBlockchain{
+		blocks: newTestSnapBlocks(snapPivotDistance+300, types.EmptyRootHash),
+	}, itrie.NewMemoryStorage(), 1)
+
+	// the protocol is registered on both sides, as every node serves the state
+	syncer := NewSnapSyncer(hclog.NewNullLogger(), clientSrv, clientChain, clientStorage, 100)
+	syncer.Start()
+
+	t.Cleanup(func() {
+		_ = syncer.Close()
+	})
+
+	require.NoError(t, syncer.Sync())
+
+	pivot := peerChain.Header().Number - snapPivotDistance
+	first := pivot - maxSnapPivotBlocks + 1
+
+	require.Len(t, clientChain.writtenBlocks, maxSnapPivotBlocks)
+	assert.Equal(t, pivot, clientChain.writtenBlocks[maxSnapPivotBlocks-1].Number())
+	assert.Equal(t, peerChain.blocks[pivot].Hash(), clientChain.writtenBlocks[maxSnapPivotBlocks-1].Hash())
+	assert.Equal(t, new(big.Int).SetUint64(pivot+1), clientChain.writtenTD)
+
+	// the headers from the start of the epoch, excluding the genesis
+	require.Len(t, clientChain.writtenHeaders, int(first-1))
+	assert.Equal(t, uint64(1), clientChain.writtenHeaders[0].Number)
+	assert.Equal(t, peerChain.blocks[first-1].Hash(), clientChain.writtenHeaders[first-2].Hash)
+
+	// the synced state matches the state of the peer
+	var accounts int
+
+	require.NoError(t, itrie.DumpState(root, clientStorage, func(account *itrie.DumpAccount) error {
+		accounts++
+
+		return nil
+	}))
+	assert.Equal(t, maxSnapRangeResults+500, accounts)
+
+	snap, err := itrie.NewState(clientStorage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(types.BytesToAddress(big.NewInt(1).Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t,
+		types.BytesToHash(big.NewInt(maxSnapRangeResults+100).Bytes()),
+		snap.GetStorage(types.BytesToAddress(big.NewInt(1).Bytes()), account.Root,
+			types.BytesToHash(big.NewInt(maxSnapRangeResults+99).Bytes())),
+	)
+}
+
+func TestSnapSyncer_OutvotedTotalDifficulty(t *testing.T) {
+	t.Parallel()
+
+	root, peerStorage := newTestSnapState(t, 10)
+	peerChain := &mockSnapBlockchain{blocks: newTestSnapBlocks(snapPivotDistance+300, root)}
+
+	clientSrv := newTestNetwork(t)
+	clientChain := &mockSnapBlockchain{}
+
+	// the peer serving the same chain with another total difficulty is outvoted
+	newTestSnapPeers(t, clientSrv, peerChain, peerStorage, 2)
+	newTestSnapPeers(t, clientSrv, &mockSnapBlockchain{
+		blocks:  newTestSnapBlocks(snapPivotDistance+300, root),
+		extraTD: 1000,
+	}, peerStorage, 1)
+
+	syncer := NewSnapSyncer(hclog.NewNullLogger(), clientSrv, clientChain, itrie.NewMemoryStorage(), 0)
+	syncer.Start()
+
+	t.Cleanup(func() {
+		_ = syncer.Close()
+	})
+
+	require.NoError(t, syncer.Sync())
+
+	pivot := peerChain.Header().Number - snapPivotDistance
+
+	assert.Equal(t, new(big.Int).SetUint64(pivot+1), clientChain.writtenTD)
+}
+
+func TestSnapSyncer_SkipNotEmptyChain(t *testing.T) {
+	t.Parallel()
+
+	chain := &mockSnapBlockchain{blocks: newTestSnapBlocks(2, types.EmptyRootHash)}
+	syncer := NewSnapSyncer(hclog.NewNullLogger(), nil, chain, itrie.NewMemoryStorage(), 0)
+
+	require.NoError(t, syncer.Sync())
+	assert.Nil(t, chain.writtenBlocks)
+}
+
+func TestSnapSyncer_NoPivotAgreement(t *testing.T) {
+	t.Parallel()
+
+	root, peerStorage := newTestSnapState(t, 10)
+
+	testTable := []struct {
+		name   string
+		honest int
+		forked int
+		lying  int
+	}{
+		{"single peer", 1, 0, 0},
+		{"peers split", 1, 1, 0},
+		{"total difficulty split", 1, 0, 1},
+	}
+
+	for _, test := range testTable {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientSrv := newTestNetwork(t)
+			clientChain := &mockSnapBlockchain{}
+
+			newTestSnapPeers(t, clientSrv, &mockSnapBlockchain{
+				blocks: newTestSnapBlocks(snapPivotDistance+300, root),
+			}, peerStorage, test.honest)
+			newTestSnapPeers(t, clientSrv, &mockSnapBlockchain{
+				blocks: newTestSnapBlocks(snapPivotDistance+300, types.EmptyRootHash),
+			}, itrie.NewMemoryStorage(), test.forked)
+			newTestSnapPeers(t, clientSrv, &mockSnapBlockchain{
+				blocks:  newTestSnapBlocks(snapPivotDistance+300, root),
+				extraTD: 1000,
+			}, peerStorage, test.lying)
+
+			syncer := NewSnapSyncer(hclog.NewNullLogger(), clientSrv, clientChain, itrie.NewMemoryStorage(), 0)
+			syncer.Start()
+
+			t.Cleanup(func() {
+				_ = syncer.Close()
+			})
+
+			assert.ErrorIs(t, syncer.Sync(), errNoPivotAgreement)
+			assert.Nil(t, clientChain.writtenBlocks)
+		})
+	}
+}
+
+func TestSnapSyncService_GetHeaders(t *testing.T) {
+	t.Parallel()
+
+	chain := &mockSnapBlockchain{blocks: newTestSnapBlocks(10, types.EmptyRootHash)}
+	service := newSnapSyncService(nil, chain, itrie.NewMemoryStorage())
+
+	resp, err := service.GetHeaders(context.Background(), &proto.HeadersRequest{From: 3, Count: 4})
+	require.NoError(t, err)
+	require.Len(t, resp.Headers, 4)
+
+	for i, data := range resp.Headers {
+		header := &types.Header{}
+		require.NoError(t, header.UnmarshalRLP(data))
+		assert.Equal(t, chain.blocks[3+i].Hash(), header.Hash)
+	}
+
+	// the headers up to the head are returned
+	resp, err = service.GetHeaders(context.Background(), &proto.HeadersRequest{From: 8, Count: 4})
+	require.NoError(t, err)
+	assert.Len(t, resp.Headers, 2)
+
+	_, err = service.GetHeaders(context.Background(), &proto.HeadersRequest{From: 10})
+	assert.ErrorIs(t, err, ErrBlockNotFound)
+}
+
+func TestSnapSyncService_GetStorageRanges(t *testing.T) {
+	t.Parallel()
+
+	root, storage := newTestSnapState(t, 201)
+	service := newSnapSyncService(nil, &mockSnapBlockchain{}, storage)
+
+	// the accounts with the storage, the first one has more slots than fit into the response
+	hashes := make([][]byte, 0, 3)
+	for _, i := range []int64{1, 101, 201} {
+		hashes = append(hashes, crypto.Keccak256(types.BytesToAddress(big.NewInt(i).Bytes()).Bytes()))
+	}
+
+	resp, err := service.GetStorageRanges(context.Background(), &proto.StorageRangesRequest{
+		Root:     root.Bytes(),
+		Accounts: hashes,
+	})
+	require.NoError(t, err)
+
+	// only the incomplete range of the first account is returned, along with its proof
+	require.Len(t, resp.Ranges, 1)
+	assert.Len(t, resp.Ranges[0].Slots, maxSnapRangeResults)
+	assert.NotEmpty(t, resp.Proof)
+
+	resp, err = service.GetStorageRanges(context.Background(), &proto.StorageRangesRequest{
+		Root:     root.Bytes(),
+		Accounts: hashes[1:],
+	})
+	require.NoError(t, err)
+
+	// the complete ranges are returned without the proof
+	require.Len(t, resp.Ranges, 2)
+	assert.Len(t, resp.Ranges[0].Slots, 3)
+	assert.Len(t, resp.Ranges[1].Slots, 3)
+	assert.Empty(t, resp.Proof)
+}
+
+func Test_verifyRange(t *testing.T) {
+	t.Parallel()
+
+	root, storage := newTestSnapState(t, 20)
+	service := newSnapSyncService(nil, &mockSnapBlockchain{}, storage)
+
+	resp, err := service.GetAccountRange(context.Background(), &proto.AccountRangeRequest{
+		Root:       root.Bytes(),
+		Origin:     types.ZeroHash.Bytes(),
+		MaxResults: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Accounts, 10)
+
+	require.NoError(t, verifyRange(root, types.ZeroHash.Bytes(), resp.Accounts, resp.Proof))
+
+	// the value of the last account doesn't match the proof
+	resp.Accounts[9].Value = resp.Accounts[8].Value
+	assert.ErrorIs(t, verifyRange(root, types.ZeroHash.Bytes(), resp.Accounts, resp.Proof), errInvalidSnapResponse)
+
+	// the accounts are not ordered
+	resp.Accounts[0], resp.Accounts[1] = resp.Accounts[1], resp.Accounts[0]
+	assert.ErrorIs(t, verifyRange(root, types.ZeroHash.Bytes(), resp.Accounts, nil), errInvalidSnapResponse)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/vishnushankarsg/metad/blockchain"
 	"github.com/vishnushankarsg/metad/helper/progress"
 	"github.com/vishnushankarsg/metad/network/event"
 	"github.com/vishnushankarsg/metad/types"
@@ -19,6 +20,16 @@ const (
 
 var (
 	errTimeout = errors.New("timeout awaiting block from peer")
+
+	// invalidBlockErrors are the verification errors proving the peer served an invalid block,
+	// the other errors may be caused by the local node or by the order of the blocks
+	invalidBlockErrors = []error{
+		blockchain.ErrInvalidHeader,
+		blockchain.ErrInvalidGasLimit,
+		blockchain.ErrInvalidBaseFee,
+		blockchain.ErrInvalidSha3Uncles,
+		blockchain.ErrInvalidTxRoot,
+	}
 )
 
 // XXX: Don't use this syncer for the consensus that may cause fork.
@@ -240,6 +251,10 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.F
 
 			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
 			if err != nil {
+				if isInvalidBlockError(err) {
+					s.syncPeerClient.ReportInvalidBlock(peerID, err)
+				}
+
 				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
 			}
 
@@ -255,3 +270,14 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.F
 		}
 	}
 }
+
+// isInvalidBlockError checks if the block verification error proves the block is invalid
+func isInvalidBlockError(err error) bool {
+	for _, invalidBlockErr := range invalidBlockErrors {
+		if errors.Is(err, invalidBlockErr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -92,6 +92,7 @@ type mockSyncPeerClient struct {
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
+	reportInvalidBlockHandler             func(peer.ID, error)
 }
 
 func (m *mockSyncPeerClient) DisablePublishingPeerStatus() {}
@@ -132,6 +133,12 @@ func (m *mockSyncPeerClient) CloseStream(peerID peer.ID) error {
 	return nil
 }
 
+func (m *mockSyncPeerClient) ReportInvalidBlock(peerID peer.ID, err error) {
+	if m.reportInvalidBlockHandler != nil {
+		m.reportInvalidBlockHandler(peerID, err)
+	}
+}
+
 func GetAllElementsFromPeerMap(t *testing.T, p *PeerMap) []*NoForkPeer {
 	t.Helper()
 
@@ -701,6 +708,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		blocks                []*types.Block
 		lastSyncedBlockNumber uint64
 		shouldTerminate       bool
+		invalidBlockReported  bool
 		err                   error
 	}{
 		{
@@ -746,7 +754,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			err:                   errPeerNoResponse,
 		},
 		{
-			name:            "should return error if verification is failed",
+			name:            "should return error if verification is failed without reporting the peer",
 			beginningHeight: 0,
 			blockTimeout:    time.Second,
 			blockCallback: func(b *types.FullBlock) bool {
@@ -768,8 +776,61 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			blocks:                blocks[:5],
 			lastSyncedBlockNumber: 5,
 			shouldTerminate:       false,
+			invalidBlockReported:  false,
 			err:                   errInvalidBlock,
 		},
+		{
+			name:            "should not report the peer if the parent is not found",
+			beginningHeight: 0,
+			blockTimeout:    time.Second,
+			blockCallback: func(b *types.FullBlock) bool {
+				return false
+			},
+			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+				return blocksToCh(blocks[:10], 0), nil
+			},
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				if b.Number() > 5 {
+					return nil, blockchain.ErrParentNotFound
+				}
+
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				return nil
+			},
+			blocks:                blocks[:5],
+			lastSyncedBlockNumber: 5,
+			shouldTerminate:       false,
+			invalidBlockReported:  false,
+			err:                   blockchain.ErrParentNotFound,
+		},
+		{
+			name:            "should report the peer serving the block with the invalid header",
+			beginningHeight: 0,
+			blockTimeout:    time.Second,
+			blockCallback: func(b *types.FullBlock) bool {
+				return false
+			},
+			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+				return blocksToCh(blocks[:10], 0), nil
+			},
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				if b.Number() > 5 {
+					return nil, fmt.Errorf("%w: invalid committed seals", blockchain.ErrInvalidHeader)
+				}
+
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				return nil
+			},
+			blocks:                blocks[:5],
+			lastSyncedBlockNumber: 5,
+			shouldTerminate:       false,
+			invalidBlockReported:  true,
+			err:                   blockchain.ErrInvalidHeader,
+		},
 		{
 			name:            "should return error if block insertion is failed",
 			beginningHeight: 0,
@@ -826,6 +887,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 
 			var (
 				syncedBlocks = make([]*types.Block, 0, len(test.blocks))
+				reported     = false
 
 				syncer = NewTestSyncer(
 					nil,
@@ -845,6 +907,12 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 					test.blockTimeout,
 					&mockSyncPeerClient{
 						getBlocksHandler: test.getBlocksHandler,
+						reportInvalidBlockHandler: func(id peer.ID, err error) {
+							assert.Equal(t, peer.ID("X"), id)
+							assert.ErrorIs(t, err, test.err)
+
+							reported = true
+						},
 					},
 					&mockProgression{},
 				)
@@ -856,6 +924,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			assert.Equal(t, test.shouldTerminate, shouldTerminate)
 			assert.ErrorIs(t, err, test.err)
 			assert.Equal(t, test.blocks, syncedBlocks)
+			assert.Equal(t, test.invalidBlockReported, reported)
 		})
 	}
 }
@@ -31,6 +31,19 @@ type Blockchain interface {
 	WriteFullBlock(*types.FullBlock, string) error
 }
 
+type SnapBlockchain interface {
+	// Header returns get latest header
+	Header() *types.Header
+	// GetBlockByNumber returns block by number
+	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+	// GetHeaderByNumber returns header by number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	// GetTD returns total difficulty of the block
+	GetTD(types.Hash) (*big.Int, bool)
+	// WriteSnapshotBlocks writes the headers and the blocks of the synced state to the empty chain
+	WriteSnapshotBlocks([]*types.Header, []*types.Block, *big.Int) error
+}
+
 type Network interface {
 	// AddrInfo returns Network Info
 	AddrInfo() *peer.AddrInfo
@@ -53,6 +66,8 @@ type Network interface {
 	SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID)
 	// CloseProtocolStream closes stream
 	CloseProtocolStream(protocol string, peerID peer.ID) error
+	// ReportPeer adds the penalty of the misbehavior to the peer score
+	ReportPeer(peerID peer.ID, penalty network.PeerPenalty, reason string)
 }
 
 type Syncer interface {
@@ -68,6 +83,15 @@ type Syncer interface {
 	Sync(func(*types.FullBlock) bool) error
 }
 
+type SnapSyncer interface {
+	// Start starts serving the state to the peers
+	Start()
+	// Close terminates snap sync processes
+	Close() error
+	// Sync downloads the state of the recent block from the peers to the empty chain
+	Sync() error
+}
+
 type Progression interface {
 	// StartProgression starts progression
 	StartProgression(startingBlock uint64, subscription blockchain.Subscription)
@@ -103,6 +127,8 @@ type SyncPeerClient interface {
 	GetPeerConnectionUpdateEventCh() <-chan *event.PeerEvent
 	// CloseStream close a stream
 	CloseStream(peerID peer.ID) error
+	// ReportInvalidBlock penalizes the peer for serving a block that fails verification
+	ReportInvalidBlock(peerID peer.ID, err error)
 	// DisablePublishingPeerStatus disables publishing status in syncer topic
 	DisablePublishingPeerStatus()
 	// EnablePublishingPeerStatus enables publishing status in syncer topic
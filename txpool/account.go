@@ -1,8 +1,10 @@
 package txpool
 
 import (
+	"math/big"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/vishnushankarsg/metad/types"
 )
@@ -24,6 +26,7 @@ func (m *accountsMap) initOnce(addr types.Address, nonce uint64) *account {
 		promoted:    newAccountQueue(),
 		maxEnqueued: m.maxEnqueuedLimit,
 		nextNonce:   nonce,
+		lastActive:  time.Now().UnixNano(),
 	})
 	newAccount := a.(*account) //nolint:forcetypeassert
 
@@ -136,6 +139,37 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 	return
 }
 
+// Thread safe set of the senders of the local transactions
+type localAccounts struct {
+	sync.RWMutex
+
+	accounts map[types.Address]struct{}
+}
+
+func newLocalAccounts() *localAccounts {
+	return &localAccounts{
+		accounts: make(map[types.Address]struct{}),
+	}
+}
+
+// add marks the address as local
+func (l *localAccounts) add(addr types.Address) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.accounts[addr] = struct{}{}
+}
+
+// contains checks if the address is local
+func (l *localAccounts) contains(addr types.Address) bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	_, ok := l.accounts[addr]
+
+	return ok
+}
+
 // An account is the core structure for processing
 // transactions from a specific address. The nextNonce
 // field is what separates the enqueued from promoted transactions:
@@ -156,6 +190,9 @@ type account struct {
 
 	//	maximum number of enqueued transactions
 	maxEnqueued uint64
+
+	// the time (unix nano) of the latest enqueued or promoted transaction
+	lastActive int64
 }
 
 // getNonce returns the next expected nonce for this account.
@@ -168,6 +205,16 @@ func (a *account) setNonce(nonce uint64) {
 	atomic.StoreUint64(&a.nextNonce, nonce)
 }
 
+// lastActivity returns the time of the latest enqueued or promoted transaction.
+func (a *account) lastActivity() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&a.lastActive))
+}
+
+// touch updates the time of the latest activity to now.
+func (a *account) touch() {
+	atomic.StoreInt64(&a.lastActive, time.Now().UnixNano())
+}
+
 // Demotions returns the current value of demotions
 func (a *account) Demotions() uint64 {
 	return a.demotions
@@ -223,25 +270,114 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 }
 
 // enqueue attempts tp push the transaction onto the enqueued queue.
-func (a *account) enqueue(tx *types.Transaction) error {
+// A transaction with the same nonce as an enqueued or promoted one
+// replaces it if its gas price is higher by at least priceBump percent.
+// The replaced transaction is returned, along with the flag
+// indicating it was replaced in the promoted queue.
+func (a *account) enqueue(tx *types.Transaction, priceBump uint64) (
+	replaced *types.Transaction,
+	promoted bool,
+	err error,
+) {
+	a.promoted.lock(true)
 	a.enqueued.lock(true)
-	defer a.enqueued.unlock()
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	// replace the tx with the same nonce
+	for _, queue := range []*accountQueue{a.promoted, a.enqueued} {
+		if old := queue.get(tx.Nonce); old != nil {
+			if !canReplace(old, tx, priceBump) {
+				return nil, false, ErrReplacementUnderpriced
+			}
+
+			return queue.replace(tx), queue == a.promoted, nil
+		}
+	}
 
 	if a.enqueued.length() == a.maxEnqueued {
-		return ErrMaxEnqueuedLimitReached
+		return nil, false, ErrMaxEnqueuedLimitReached
 	}
 
 	// reject low nonce tx
 	if tx.Nonce < a.getNonce() {
-		return ErrNonceTooLow
+		return nil, false, ErrNonceTooLow
 	}
 
 	// enqueue tx
 	a.enqueued.push(tx)
+	a.touch()
+
+	return nil, false, nil
+}
+
+// contains checks whether the given transaction is in one of the queues of the account.
+// The queues have to be locked by the caller
+func (a *account) contains(tx *types.Transaction) bool {
+	for _, queue := range []*accountQueue{a.promoted, a.enqueued} {
+		if queued := queue.get(tx.Nonce); queued != nil && queued.Hash == tx.Hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkEnqueue returns the error enqueue would fail with for the given transaction,
+// without changing the account. It's used to reject the transaction before
+// any room is made for it in the pool.
+func (a *account) checkEnqueue(tx *types.Transaction, priceBump uint64) error {
+	a.promoted.lock(false)
+	a.enqueued.lock(false)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	for _, queue := range []*accountQueue{a.promoted, a.enqueued} {
+		if old := queue.get(tx.Nonce); old != nil {
+			if !canReplace(old, tx, priceBump) {
+				return ErrReplacementUnderpriced
+			}
+
+			return nil
+		}
+	}
+
+	if a.enqueued.length() == a.maxEnqueued {
+		return ErrMaxEnqueuedLimitReached
+	}
+
+	if tx.Nonce < a.getNonce() {
+		return ErrNonceTooLow
+	}
 
 	return nil
 }
 
+// canReplace checks if both the gas fee cap and the gas tip cap of the new transaction
+// are higher than the ones of the old transaction by at least priceBump percent.
+func canReplace(old, tx *types.Transaction, priceBump uint64) bool {
+	bumped := func(oldPrice, newPrice *big.Int) bool {
+		if newPrice.Cmp(oldPrice) <= 0 {
+			return false
+		}
+
+		// newPrice >= oldPrice * (100 + priceBump) / 100
+		threshold := new(big.Int).Mul(oldPrice, new(big.Int).SetUint64(100+priceBump))
+		threshold.Div(threshold, big.NewInt(100))
+
+		return newPrice.Cmp(threshold) >= 0
+	}
+
+	return bumped(old.GetGasFeeCap(), tx.GetGasFeeCap()) &&
+		bumped(old.GetGasTipCap(), tx.GetGasTipCap())
+}
+
 // Promote moves eligible transactions from enqueued to promoted.
 //
 // Eligible transactions are all sequential in order of nonce
@@ -295,6 +431,10 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 		a.setNonce(nextNonce)
 	}
 
+	if len(promoted) > 0 {
+		a.touch()
+	}
+
 	return
 }
 
@@ -0,0 +1,197 @@
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/vishnushankarsg/metad/crypto"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+const (
+	// maxBundleTxs is the maximum number of transactions in a single bundle
+	maxBundleTxs = 32
+
+	// maxBundles is the maximum number of the bundles kept by the pool
+	maxBundles = 1024
+
+	// maxBundleBlocksAhead is the maximum distance of the target block from the head
+	maxBundleBlocksAhead = 25
+)
+
+var (
+	ErrEmptyBundle        = errors.New("bundle has no transactions")
+	ErrBundleTooLarge     = fmt.Errorf("bundle has more than %d transactions", maxBundleTxs)
+	ErrBundleExpired      = errors.New("bundle target block is already sealed")
+	ErrBundleTooFar       = fmt.Errorf("bundle target block is more than %d blocks ahead", maxBundleBlocksAhead)
+	ErrBundlePoolFull     = errors.New("bundle pool is full")
+	ErrBundleAlreadyKnown = errors.New("bundle already known")
+)
+
+// Bundle is the ordered list of transactions which are executed
+// back-to-back in the target block, or not at all
+type Bundle struct {
+	// Hash is the hash of the concatenated transaction hashes
+	Hash types.Hash
+
+	// BlockNumber is the number of the block the bundle is meant for
+	BlockNumber uint64
+
+	// Txs are the transactions of the bundle, in the execution order
+	Txs []*types.Transaction
+}
+
+// bundleHash returns the hash of the concatenated transaction hashes
+func bundleHash(txs []*types.Transaction) types.Hash {
+	hashes := make([]byte, 0, len(txs)*types.HashLength)
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash.Bytes()...)
+	}
+
+	return types.BytesToHash(crypto.Keccak256(hashes))
+}
+
+// bundlePool keeps the submitted bundles in the submission order.
+// The bundles are local to the node and never gossiped
+type bundlePool struct {
+	sync.Mutex
+
+	bundles []*Bundle
+}
+
+// add adds the bundle to the end of the pool
+func (p *bundlePool) add(bundle *Bundle) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if len(p.bundles) >= maxBundles {
+		return ErrBundlePoolFull
+	}
+
+	for _, b := range p.bundles {
+		if b.Hash == bundle.Hash {
+			return ErrBundleAlreadyKnown
+		}
+	}
+
+	p.bundles = append(p.bundles, bundle)
+
+	return nil
+}
+
+// get returns the bundles targeting the given block
+// and removes the ones targeting the previous blocks
+func (p *bundlePool) get(blockNumber uint64) []*Bundle {
+	p.Lock()
+	defer p.Unlock()
+
+	var (
+		kept   = p.bundles[:0]
+		result []*Bundle
+	)
+
+	for _, b := range p.bundles {
+		if b.BlockNumber < blockNumber {
+			continue
+		}
+
+		if b.BlockNumber == blockNumber {
+			result = append(result, b)
+		}
+
+		kept = append(kept, b)
+	}
+
+	// release the references to the removed bundles
+	for i := len(kept); i < len(p.bundles); i++ {
+		p.bundles[i] = nil
+	}
+
+	p.bundles = kept
+
+	return result
+}
+
+// remove removes the bundle of the given hash
+func (p *bundlePool) remove(hash types.Hash) {
+	p.Lock()
+	defer p.Unlock()
+
+	for i, b := range p.bundles {
+		if b.Hash == hash {
+			p.bundles = append(p.bundles[:i], p.bundles[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// length returns the number of the bundles in the pool
+func (p *bundlePool) length() int {
+	p.Lock()
+	defer p.Unlock()
+
+	return len(p.bundles)
+}
+
+// AddBundle validates the transactions and adds them as the bundle targeting the given block.
+// The bundle is kept by this node only and tried first when it proposes the target block.
+//
+// The bundle transactions don't take the slots of the pool nor of the sender's account,
+// the bundles are bounded by maxBundles and maxBundleTxs instead. The sender may have a pool
+// transaction with the same nonce as a bundle transaction: the bundle is executed first,
+// so the pool transaction fails with the too low nonce once the bundle is included,
+// and the bundle is dropped if the pool transaction was included before
+func (p *TxPool) AddBundle(txs []*types.Transaction, blockNumber uint64) (types.Hash, error) {
+	if len(txs) == 0 {
+		return types.ZeroHash, ErrEmptyBundle
+	}
+
+	if len(txs) > maxBundleTxs {
+		return types.ZeroHash, ErrBundleTooLarge
+	}
+
+	head := p.store.Header().Number
+
+	if blockNumber <= head {
+		return types.ZeroHash, ErrBundleExpired
+	}
+
+	if blockNumber > head+maxBundleBlocksAhead {
+		return types.ZeroHash, ErrBundleTooFar
+	}
+
+	for i, tx := range txs {
+		if err := p.validateTx(tx); err != nil {
+			return types.ZeroHash, fmt.Errorf("invalid bundle transaction %d, %w", i, err)
+		}
+	}
+
+	bundle := &Bundle{
+		Hash:        bundleHash(txs),
+		BlockNumber: blockNumber,
+		Txs:         txs,
+	}
+
+	if err := p.bundles.add(bundle); err != nil {
+		return types.ZeroHash, err
+	}
+
+	p.logger.Debug("bundle added", "hash", bundle.Hash, "block", blockNumber, "txs", len(txs))
+
+	return bundle.Hash, nil
+}
+
+// Bundles returns the bundles targeting the given block, in the submission order.
+// The bundles targeting the previous blocks are removed
+func (p *TxPool) Bundles(blockNumber uint64) []*Bundle {
+	return p.bundles.get(blockNumber)
+}
+
+// DropBundle removes the bundle, which failed to execute, from the pool
+func (p *TxPool) DropBundle(bundle *Bundle) {
+	p.bundles.remove(bundle.Hash)
+
+	p.logger.Debug("bundle dropped", "hash", bundle.Hash)
+}
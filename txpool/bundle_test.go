@@ -0,0 +1,90 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/types"
+)
+
+func TestAddBundle(t *testing.T) {
+	t.Parallel()
+
+	newBundleTxs := func(nonces ...uint64) []*types.Transaction {
+		txs := make([]*types.Transaction, len(nonces))
+		for i, nonce := range nonces {
+			txs[i] = newTx(addr1, nonce, 1).ComputeHash()
+		}
+
+		return txs
+	}
+
+	t.Run("invalid bundles", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		_, err = pool.AddBundle(nil, 1)
+		assert.ErrorIs(t, err, ErrEmptyBundle)
+
+		_, err = pool.AddBundle(make([]*types.Transaction, maxBundleTxs+1), 1)
+		assert.ErrorIs(t, err, ErrBundleTooLarge)
+
+		_, err = pool.AddBundle(newBundleTxs(0), 0)
+		assert.ErrorIs(t, err, ErrBundleExpired)
+
+		_, err = pool.AddBundle(newBundleTxs(0), maxBundleBlocksAhead+1)
+		assert.ErrorIs(t, err, ErrBundleTooFar)
+
+		_, err = pool.AddBundle(newBundleTxs(0), maxBundleBlocksAhead)
+		assert.NoError(t, err)
+
+		underpricedTxs := newBundleTxs(0, 1)
+		underpricedTxs[1].GasPrice = big.NewInt(0)
+
+		_, err = pool.AddBundle(underpricedTxs, 1)
+		assert.ErrorIs(t, err, ErrUnderpriced)
+	})
+
+	t.Run("bundles of the target block", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		first, err := pool.AddBundle(newBundleTxs(0, 1), 2)
+		require.NoError(t, err)
+
+		second, err := pool.AddBundle(newBundleTxs(0), 2)
+		require.NoError(t, err)
+
+		later, err := pool.AddBundle(newBundleTxs(2), 3)
+		require.NoError(t, err)
+
+		_, err = pool.AddBundle(pool.bundles.bundles[0].Txs, 2)
+		assert.ErrorIs(t, err, ErrBundleAlreadyKnown)
+
+		assert.Empty(t, pool.Bundles(1))
+
+		bundles := pool.Bundles(2)
+		require.Len(t, bundles, 2)
+		assert.Equal(t, first, bundles[0].Hash)
+		assert.Equal(t, second, bundles[1].Hash)
+		assert.Len(t, bundles[0].Txs, 2)
+
+		pool.DropBundle(bundles[0])
+		require.Len(t, pool.Bundles(2), 1)
+
+		// the bundles of the sealed blocks are removed
+		bundles = pool.Bundles(3)
+		require.Len(t, bundles, 1)
+		assert.Equal(t, later, bundles[0].Hash)
+		assert.Equal(t, 1, pool.bundles.length())
+	})
+}
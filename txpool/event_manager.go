@@ -82,6 +82,9 @@ func (em *eventManager) Close() {
 		subscription.close()
 	}
 
+	// drop the closed subscriptions, so cancelling them later is a no-op
+	em.subscriptions = make(map[subscriptionID]*eventSubscription)
+
 	atomic.StoreInt64(&em.numSubscriptions, 0)
 }
 
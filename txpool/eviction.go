@@ -0,0 +1,209 @@
+package txpool
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/armon/go-metrics"
+
+	"github.com/vishnushankarsg/metad/txpool/proto"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+const (
+	// DefaultEnqueuedLifetime is the maximum time the enqueued transactions
+	// of an account are kept without any activity of the account
+	DefaultEnqueuedLifetime = 3 * time.Hour
+
+	// evictionInterval is the interval of checking for the expired enqueued transactions
+	evictionInterval = time.Minute
+)
+
+// eviction reasons reported by the txpool metrics
+const (
+	evictedNonceHoles  = "nonce_holes"
+	evictedLifetime    = "lifetime"
+	evictedUnderpriced = "underpriced"
+)
+
+// countEvicted increments the counter of the transactions evicted for the given reason
+func countEvicted(reason string, count int) {
+	metrics.IncrCounter([]string{txPoolMetrics, "evicted", reason}, float32(count))
+}
+
+// evictExpired removes the enqueued transactions of the evictable accounts
+// which have not been active for longer than the enqueued lifetime
+func (p *TxPool) evictExpired(now time.Time) {
+	p.accounts.Range(
+		func(key, value interface{}) bool {
+			addr, _ := key.(types.Address)
+			account, _ := value.(*account)
+
+			if !p.isEvictable(addr) || now.Sub(account.lastActivity()) < p.enqueuedLifetime {
+				return true
+			}
+
+			account.enqueued.lock(true)
+			defer account.enqueued.unlock()
+
+			removed := account.enqueued.clear()
+			if len(removed) == 0 {
+				return true
+			}
+
+			p.index.remove(removed...)
+			p.gauge.decrease(slotsRequired(removed...))
+
+			p.eventManager.signalEvent(proto.EventType_PRUNED_ENQUEUED, toHash(removed...)...)
+			countEvicted(evictedLifetime, len(removed))
+
+			p.logger.Debug("evicted expired enqueued txs",
+				"num", len(removed),
+				"address", addr.String(),
+			)
+
+			return true
+		},
+	)
+}
+
+// evictUnderpriced makes room for the given transaction in the full pool
+// by evicting the cheaper transactions of the evictable accounts.
+// Nothing is evicted if there's not enough cheaper transactions
+func (p *TxPool) evictUnderpriced(tx *types.Transaction) bool {
+	p.evictionLock.Lock()
+	defer p.evictionLock.Unlock()
+
+	required := slotsRequired(tx)
+
+	available := uint64(0)
+	if height := p.gauge.read(); height < p.gauge.max {
+		available = p.gauge.max - height
+	}
+
+	if required <= available {
+		return true
+	}
+
+	victims := p.findEvictionVictims(tx, required-available)
+	if victims == nil {
+		return false
+	}
+
+	for _, victim := range victims {
+		p.evict(victim)
+	}
+
+	countEvicted(evictedUnderpriced, len(victims))
+
+	return true
+}
+
+// findEvictionVictims returns the transactions, paying a lower effective tip at the
+// current base fee than the given one, which free the given number of slots once evicted. The transactions are taken from the end
+// of the accounts (highest nonce first), so no nonce gaps are created.
+// Nil is returned if there are not enough of such transactions
+func (p *TxPool) findEvictionVictims(tx *types.Transaction, slots uint64) []*types.Transaction {
+	// nonce ordered transactions of each evictable account
+	candidates := make(map[types.Address][]*types.Transaction)
+
+	p.accounts.Range(
+		func(key, value interface{}) bool {
+			addr, _ := key.(types.Address)
+			account, _ := value.(*account)
+
+			if addr == tx.From || !p.isEvictable(addr) {
+				return true
+			}
+
+			account.promoted.lock(false)
+			account.enqueued.lock(false)
+
+			defer func() {
+				account.enqueued.unlock()
+				account.promoted.unlock()
+			}()
+
+			txs := make([]*types.Transaction, 0, account.promoted.length()+account.enqueued.length())
+			txs = append(txs, account.promoted.queue...)
+			txs = append(txs, account.enqueued.queue...)
+
+			if len(txs) > 0 {
+				sortByNonce(txs)
+				candidates[addr] = txs
+			}
+
+			return true
+		},
+	)
+
+	var (
+		baseFee = new(big.Int).SetUint64(p.store.CalculateBaseFee(p.store.Header()))
+		victims []*types.Transaction
+		freed   uint64
+	)
+
+	for freed < slots {
+		// the cheapest of the last transactions of the accounts
+		var (
+			cheapest types.Address
+			victim   *types.Transaction
+		)
+
+		for addr, txs := range candidates {
+			last := txs[len(txs)-1]
+			if victim == nil || last.EffectiveGasTip(baseFee).Cmp(victim.EffectiveGasTip(baseFee)) < 0 {
+				cheapest, victim = addr, last
+			}
+		}
+
+		if victim == nil || victim.EffectiveGasTip(baseFee).Cmp(tx.EffectiveGasTip(baseFee)) >= 0 {
+			return nil
+		}
+
+		txs := candidates[cheapest]
+
+		victims = append(victims, victim)
+		freed += slotsRequired(victim)
+
+		if len(txs) == 1 {
+			delete(candidates, cheapest)
+		} else {
+			candidates[cheapest] = txs[:len(txs)-1]
+		}
+	}
+
+	return victims
+}
+
+// evict removes the transaction from the end of its account.
+// If the transaction was promoted, the account's nonce is reverted to its nonce
+func (p *TxPool) evict(tx *types.Transaction) {
+	account := p.accounts.get(tx.From)
+
+	account.promoted.lock(true)
+	account.enqueued.lock(true)
+
+	defer func() {
+		account.enqueued.unlock()
+		account.promoted.unlock()
+	}()
+
+	switch {
+	case account.enqueued.remove(tx):
+	case account.promoted.remove(tx):
+		if tx.Nonce < account.getNonce() {
+			account.setNonce(tx.Nonce)
+		}
+
+		p.updatePending(-1)
+	default:
+		// the transaction left the pool in the meantime
+		return
+	}
+
+	p.index.remove(tx)
+	p.gauge.decrease(slotsRequired(tx))
+
+	p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
+}
@@ -0,0 +1,224 @@
+package txpool
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// enqueueTx adds the transaction of the given origin and handles its enqueue request
+func enqueueTx(t *testing.T, pool *TxPool, origin txOrigin, tx *types.Transaction) {
+	t.Helper()
+
+	go func() {
+		assert.NoError(t, pool.addTx(origin, tx))
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+}
+
+func TestEvictExpired(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.enqueuedLifetime = time.Hour
+
+	enqueueTx(t, pool, gossip, newTx(addr1, 10, 1))
+	enqueueTx(t, pool, local, newTx(addr2, 10, 1))
+	enqueueTx(t, pool, gossip, newTx(addr3, 10, 1))
+
+	// the first two accounts are inactive for longer than the lifetime
+	for _, addr := range []types.Address{addr1, addr2} {
+		atomic.StoreInt64(&pool.accounts.get(addr).lastActive, time.Now().Add(-2*time.Hour).UnixNano())
+	}
+
+	pool.evictExpired(time.Now())
+
+	// the local account is kept
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr2).enqueued.length())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr3).enqueued.length())
+	assert.Equal(t, uint64(2), pool.gauge.read())
+}
+
+func TestEvictUnderpriced(t *testing.T) {
+	t.Parallel()
+
+	newPricedTx := func(addr types.Address, nonce, gasPrice, slots uint64) *types.Transaction {
+		tx := newTx(addr, nonce, slots)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx
+	}
+
+	// returns the pool filled with the transactions of the first two accounts
+	setupPool := func(t *testing.T, origin txOrigin) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPoolWithSlots(3)
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		enqueueTx(t, pool, origin, newPricedTx(addr1, 10, 1, 1))
+		enqueueTx(t, pool, origin, newPricedTx(addr1, 11, 1, 1))
+		enqueueTx(t, pool, origin, newPricedTx(addr2, 10, 2, 1))
+
+		require.Equal(t, uint64(3), pool.gauge.read())
+
+		return pool
+	}
+
+	t.Run("evict cheaper transactions", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, gossip)
+
+		// both transactions of the cheapest account make room for the new one
+		enqueueTx(t, pool, gossip, newPricedTx(addr3, 10, 3, 2))
+
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr2).enqueued.length())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr3).enqueued.length())
+		assert.Equal(t, uint64(3), pool.gauge.read())
+
+		// no cheaper transactions are left
+		assert.ErrorIs(t,
+			pool.addTx(gossip, newPricedTx(addr4, 10, 1, 1)),
+			ErrTxPoolOverflow,
+		)
+	})
+
+	t.Run("keep local transactions", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, local)
+
+		assert.ErrorIs(t,
+			pool.addTx(gossip, newPricedTx(addr3, 10, 3, 1)),
+			ErrTxPoolOverflow,
+		)
+		assert.Equal(t, uint64(3), pool.gauge.read())
+	})
+
+	t.Run("evict by effective tip", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(2, defaultMockStore{
+			DefaultHeader: mockHeader,
+			BaseFee:       1,
+		})
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		// the high fee cap doesn't make up for the low tip
+		dynamicTx := newTx(addr1, 10, 1)
+		dynamicTx.Type = types.DynamicFeeTx
+		dynamicTx.GasPrice = big.NewInt(0)
+		dynamicTx.GasTipCap = big.NewInt(1)
+		dynamicTx.GasFeeCap = big.NewInt(100)
+
+		enqueueTx(t, pool, gossip, dynamicTx)
+		enqueueTx(t, pool, gossip, newPricedTx(addr2, 10, 3, 1))
+
+		enqueueTx(t, pool, gossip, newPricedTx(addr3, 10, 4, 1))
+
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr2).enqueued.length())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr3).enqueued.length())
+	})
+
+	t.Run("keep transactions for rejected transaction", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, gossip)
+
+		// the full pool only accepts the transactions with the expected nonce
+		pool.accounts.get(addr2).setNonce(10)
+
+		// the known transaction
+		assert.ErrorIs(t,
+			pool.addTx(gossip, pool.accounts.get(addr2).enqueued.peek()),
+			ErrAlreadyKnown,
+		)
+
+		// the transaction which can't replace the one with the same nonce
+		assert.ErrorIs(t,
+			pool.addTx(gossip, newPricedTx(addr2, 10, 2, 2)),
+			ErrReplacementUnderpriced,
+		)
+
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, uint64(3), pool.gauge.read())
+	})
+
+	t.Run("revert nonce of evicted promoted transaction", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(1)
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		go func() {
+			assert.NoError(t, pool.addTx(gossip, newPricedTx(addr1, 0, 1, 1)))
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		require.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
+
+		enqueueTx(t, pool, gossip, newPricedTx(addr2, 10, 2, 1))
+
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(1), pool.gauge.read())
+	})
+}
+
+func TestPrioritySenders(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPoolWithSlots(2)
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.prioritySenders = map[types.Address]struct{}{addr1: {}}
+	pool.priceLimit = 10
+	pool.enqueuedLifetime = time.Hour
+
+	// the priority sender is exempt from the price limit
+	priorityTx := newTx(addr1, 10, 1)
+	priorityTx.GasPrice = big.NewInt(1)
+
+	enqueueTx(t, pool, gossip, priorityTx)
+
+	underpricedTx := newTx(addr2, 10, 1)
+	underpricedTx.GasPrice = big.NewInt(1)
+
+	assert.ErrorIs(t, pool.addTx(gossip, underpricedTx), ErrUnderpriced)
+
+	// the priority sender is never evicted
+	cheaperTx := newTx(addr2, 10, 1)
+	cheaperTx.GasPrice = big.NewInt(10)
+
+	enqueueTx(t, pool, gossip, cheaperTx)
+
+	atomic.StoreInt64(&pool.accounts.get(addr1).lastActive, time.Now().Add(-2*time.Hour).UnixNano())
+	pool.evictExpired(time.Now())
+
+	pricierTx := newTx(addr3, 10, 1)
+	pricierTx.GasPrice = big.NewInt(100)
+
+	enqueueTx(t, pool, gossip, pricierTx)
+
+	assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr2).enqueued.length())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr3).enqueued.length())
+}
@@ -0,0 +1,635 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p/core/peer"
+	rawGrpc "google.golang.org/grpc"
+
+	"github.com/vishnushankarsg/metad/network"
+	"github.com/vishnushankarsg/metad/network/grpc"
+	"github.com/vishnushankarsg/metad/txpool/proto"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+const (
+	// txGossipProto is the libp2p protocol announcing and exchanging the transactions
+	txGossipProto = "/txpool/0.2"
+
+	// txGossipInterval is the interval of propagating the new transactions to the peers
+	txGossipInterval = 100 * time.Millisecond
+
+	// txGossipTimeout is the timeout of a single request to a peer
+	txGossipTimeout = 5 * time.Second
+
+	// maxGossipTxs is the maximum number of the transactions or the hashes in a single message
+	maxGossipTxs = 256
+
+	// maxGossipQueue is the maximum number of the transactions waiting for the propagation
+	maxGossipQueue = 4096
+
+	// maxKnownTxs is the maximum number of the hashes remembered as known to a single peer
+	maxKnownTxs = 32768
+)
+
+var (
+	errTooManyGossipTxs     = errors.New("too many transactions in the gossip message")
+	errInvalidGossipContext = errors.New("invalid gossip request context")
+)
+
+// gossipNetwork is the networking stack used by the transaction gossip
+type gossipNetwork interface {
+	// Peers returns the currently connected peers
+	Peers() []*network.PeerConnInfo
+	// GetProtocols returns the protocols supported by the peer
+	GetProtocols(peerID peer.ID) ([]string, error)
+	// NewProtoConnection opens up a new stream on the set protocol to the peer
+	NewProtoConnection(protocol string, peerID peer.ID) (*rawGrpc.ClientConn, error)
+	// RegisterProtocol registers the protocol handler
+	RegisterProtocol(id string, p network.Protocol)
+	// ReportPeer adds the penalty of the misbehavior to the peer score
+	ReportPeer(peerID peer.ID, penalty network.PeerPenalty, reason string)
+}
+
+// invalidTxErrors are the errors proving the transaction is invalid regardless of the pool state,
+// the peers sending such transactions are penalized
+var invalidTxErrors = []error{
+	ErrExtractSignature,
+	ErrInvalidSender,
+	ErrNegativeValue,
+	ErrOversizedData,
+	ErrIntrinsicGas,
+	ErrInvalidTxType,
+	ErrTipAboveFeeCap,
+}
+
+// isInvalidTxError checks if the error proves the transaction is invalid
+func isInvalidTxError(err error) bool {
+	for _, invalidErr := range invalidTxErrors {
+		if errors.Is(err, invalidErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// txGossip propagates the new transactions to the peers. The full transactions
+// are pushed to the square root of the peers, the rest of them receives the hashes only
+// and fetches the unknown transactions from the announcing peer (the same way as eth/68).
+// The peers not supporting the protocol are served through the legacy gossip topic
+type txGossip struct {
+	proto.UnimplementedTxnGossipServer
+
+	logger  hclog.Logger
+	pool    *TxPool
+	network gossipNetwork
+	stream  *grpc.GrpcStream
+
+	// transactions waiting for the propagation
+	queueLock sync.Mutex
+	queue     []*types.Transaction
+
+	// hashes known to each of the peers, which are never sent to them
+	knownLock sync.Mutex
+	known     map[peer.ID]*lru.Cache
+
+	// hashes requested from the peers and not received yet
+	fetchingLock sync.Mutex
+	fetching     map[types.Hash]struct{}
+}
+
+func newTxGossip(logger hclog.Logger, pool *TxPool, network gossipNetwork) *txGossip {
+	return &txGossip{
+		logger:   logger.Named("gossip"),
+		pool:     pool,
+		network:  network,
+		known:    make(map[peer.ID]*lru.Cache),
+		fetching: make(map[types.Hash]struct{}),
+	}
+}
+
+// register registers the gossip protocol handler
+func (g *txGossip) register() {
+	g.stream = grpc.NewGrpcStream()
+
+	proto.RegisterTxnGossipServer(g.stream.GrpcServer(), g)
+	g.stream.Serve()
+	g.network.RegisterProtocol(txGossipProto, g.stream)
+}
+
+// close closes the gossip protocol handler
+func (g *txGossip) close() error {
+	return g.stream.Close()
+}
+
+// run propagates the queued transactions periodically, until the shutdown
+func (g *txGossip) run(shutdownCh <-chan struct{}) {
+	ticker := time.NewTicker(txGossipInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-shutdownCh:
+			return
+		case <-ticker.C:
+			g.flush()
+		}
+	}
+}
+
+// enqueue queues the transaction for the propagation
+func (g *txGossip) enqueue(tx *types.Transaction) {
+	g.queueLock.Lock()
+	defer g.queueLock.Unlock()
+
+	if len(g.queue) >= maxGossipQueue {
+		g.logger.Debug("gossip queue is full, dropping tx", "hash", tx.Hash)
+
+		return
+	}
+
+	g.queue = append(g.queue, tx)
+}
+
+// flush propagates the queued transactions to the peers not knowing them yet
+func (g *txGossip) flush() {
+	g.queueLock.Lock()
+	queued := g.queue
+	g.queue = nil
+	g.queueLock.Unlock()
+
+	// the private transactions are neither pushed nor announced
+	txs := make([]*types.Transaction, 0, len(queued))
+
+	for _, tx := range queued {
+		if !g.pool.index.isPrivate(tx.Hash) {
+			txs = append(txs, tx)
+		}
+	}
+
+	if len(txs) == 0 {
+		return
+	}
+
+	var (
+		peers  = g.network.Peers()
+		ids    = make([]peer.ID, 0, len(peers))
+		legacy = false
+	)
+
+	for _, p := range peers {
+		if g.supportsGossip(p.Info.ID) {
+			ids = append(ids, p.Info.ID)
+		} else {
+			legacy = true
+		}
+	}
+
+	g.pruneKnown(ids)
+
+	if legacy {
+		g.publishLegacy(txs)
+	}
+
+	rand.Shuffle(len(ids), func(i, j int) {
+		ids[i], ids[j] = ids[j], ids[i]
+	})
+
+	pushPeers := int(math.Sqrt(float64(len(ids))))
+
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		unknown := g.markKnown(id, txs)
+		if len(unknown) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(i int, id peer.ID) {
+			defer wg.Done()
+
+			if i < pushPeers {
+				g.push(id, unknown)
+			} else {
+				g.announce(id, unknown)
+			}
+		}(i, id)
+	}
+
+	wg.Wait()
+}
+
+// supportsGossip checks if the peer supports the gossip protocol
+func (g *txGossip) supportsGossip(id peer.ID) bool {
+	protocols, err := g.network.GetProtocols(id)
+	if err != nil {
+		return false
+	}
+
+	for _, p := range protocols {
+		if p == txGossipProto {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publishLegacy publishes the transactions to the legacy gossip topic
+func (g *txGossip) publishLegacy(txs []*types.Transaction) {
+	if g.pool.topic == nil {
+		return
+	}
+
+	for _, tx := range txs {
+		msg := &proto.Txn{
+			Raw: &any.Any{
+				Value: tx.MarshalRLP(),
+			},
+		}
+
+		if err := g.pool.topic.Publish(msg); err != nil {
+			g.logger.Error("failed to topic tx", "err", err)
+		}
+	}
+}
+
+// markKnown marks the transactions as known to the peer
+// and returns the ones the peer didn't know before
+func (g *txGossip) markKnown(id peer.ID, txs []*types.Transaction) []*types.Transaction {
+	g.knownLock.Lock()
+	defer g.knownLock.Unlock()
+
+	known, ok := g.known[id]
+	if !ok {
+		known, _ = lru.New(maxKnownTxs)
+		g.known[id] = known
+	}
+
+	unknown := make([]*types.Transaction, 0, len(txs))
+
+	for _, tx := range txs {
+		if contains, _ := known.ContainsOrAdd(tx.Hash, struct{}{}); !contains {
+			unknown = append(unknown, tx)
+		}
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "gossip", "skipped_known"}, float32(len(txs)-len(unknown)))
+
+	return unknown
+}
+
+// markKnownHashes marks the hashes as known to the peer
+func (g *txGossip) markKnownHashes(id peer.ID, hashes []types.Hash) {
+	g.knownLock.Lock()
+	defer g.knownLock.Unlock()
+
+	known, ok := g.known[id]
+	if !ok {
+		known, _ = lru.New(maxKnownTxs)
+		g.known[id] = known
+	}
+
+	for _, hash := range hashes {
+		known.Add(hash, struct{}{})
+	}
+}
+
+// pruneKnown removes the known hashes of the disconnected peers
+func (g *txGossip) pruneKnown(connected []peer.ID) {
+	g.knownLock.Lock()
+	defer g.knownLock.Unlock()
+
+	ids := make(map[peer.ID]struct{}, len(connected))
+	for _, id := range connected {
+		ids[id] = struct{}{}
+	}
+
+	for id := range g.known {
+		if _, ok := ids[id]; !ok {
+			delete(g.known, id)
+		}
+	}
+}
+
+// newClient opens up a new connection to the gossip protocol of the peer
+func (g *txGossip) newClient(id peer.ID) (proto.TxnGossipClient, func(), error) {
+	conn, err := g.network.NewProtoConnection(txGossipProto, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return proto.NewTxnGossipClient(conn), func() { _ = conn.Close() }, nil
+}
+
+// push sends the full transactions to the peer
+func (g *txGossip) push(id peer.ID, txs []*types.Transaction) {
+	client, closeFn, err := g.newClient(id)
+	if err != nil {
+		g.logger.Debug("failed to open a stream", "id", id, "err", err)
+
+		return
+	}
+
+	defer closeFn()
+
+	for start := 0; start < len(txs); start += maxGossipTxs {
+		batch := txs[start:minInt(start+maxGossipTxs, len(txs))]
+
+		ctx, cancel := context.WithTimeout(context.Background(), txGossipTimeout)
+		_, err := client.PushTxns(ctx, toTxnBodies(batch))
+
+		cancel()
+
+		if err != nil {
+			g.logger.Debug("failed to push txs", "id", id, "err", err)
+
+			return
+		}
+
+		metrics.IncrCounter([]string{txPoolMetrics, "gossip", "pushed"}, float32(len(batch)))
+	}
+}
+
+// announce sends the hashes of the transactions to the peer
+func (g *txGossip) announce(id peer.ID, txs []*types.Transaction) {
+	client, closeFn, err := g.newClient(id)
+	if err != nil {
+		g.logger.Debug("failed to open a stream", "id", id, "err", err)
+
+		return
+	}
+
+	defer closeFn()
+
+	for start := 0; start < len(txs); start += maxGossipTxs {
+		batch := txs[start:minInt(start+maxGossipTxs, len(txs))]
+
+		hashes := &proto.TxnHashes{Hashes: make([][]byte, len(batch))}
+		for i, tx := range batch {
+			hashes.Hashes[i] = tx.Hash.Bytes()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), txGossipTimeout)
+		_, err := client.AnnounceTxns(ctx, hashes)
+
+		cancel()
+
+		if err != nil {
+			g.logger.Debug("failed to announce txs", "id", id, "err", err)
+
+			return
+		}
+
+		metrics.IncrCounter([]string{txPoolMetrics, "gossip", "announced"}, float32(len(batch)))
+	}
+}
+
+// fetch requests the announced transactions from the peer and adds them to the pool
+func (g *txGossip) fetch(id peer.ID, hashes []types.Hash) {
+	defer func() {
+		g.fetchingLock.Lock()
+		defer g.fetchingLock.Unlock()
+
+		for _, hash := range hashes {
+			delete(g.fetching, hash)
+		}
+	}()
+
+	client, closeFn, err := g.newClient(id)
+	if err != nil {
+		g.logger.Debug("failed to open a stream", "id", id, "err", err)
+
+		return
+	}
+
+	defer closeFn()
+
+	req := &proto.TxnHashes{Hashes: make([][]byte, len(hashes))}
+	for i, hash := range hashes {
+		req.Hashes[i] = hash.Bytes()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), txGossipTimeout)
+	defer cancel()
+
+	resp, err := client.GetTxns(ctx, req)
+	if err != nil {
+		g.logger.Debug("failed to fetch txs", "id", id, "err", err)
+
+		return
+	}
+
+	requested := make(map[types.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		requested[hash] = struct{}{}
+	}
+
+	txs := make([]*types.Transaction, 0, len(resp.Txns))
+
+	for _, raw := range resp.Txns {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			g.logger.Debug("failed to decode fetched tx", "id", id, "err", err)
+			g.reportInvalidTx(id, err)
+
+			continue
+		}
+
+		// ignore the transactions which were not requested
+		if _, ok := requested[tx.Hash]; !ok {
+			continue
+		}
+
+		delete(requested, tx.Hash)
+
+		txs = append(txs, tx)
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "gossip", "fetched"}, float32(len(txs)))
+
+	g.addTxs(id, txs)
+}
+
+// addTxs adds the transactions received from the peer to the pool
+// and queues the accepted ones for the further propagation
+func (g *txGossip) addTxs(id peer.ID, txs []*types.Transaction) {
+	for _, tx := range txs {
+		if err := g.pool.addTx(gossip, tx); err != nil {
+			if errors.Is(err, ErrAlreadyKnown) {
+				metrics.IncrCounter([]string{txPoolMetrics, "gossip", "duplicate"}, 1)
+
+				continue
+			}
+
+			g.logger.Debug("failed to add gossiped tx", "id", id, "err", err, "hash", tx.Hash)
+
+			if isInvalidTxError(err) {
+				g.reportInvalidTx(id, err)
+			}
+
+			continue
+		}
+
+		g.enqueue(tx)
+	}
+}
+
+// reportInvalidTx penalizes the peer for sending the invalid transaction
+func (g *txGossip) reportInvalidTx(id peer.ID, err error) {
+	metrics.IncrCounter([]string{txPoolMetrics, "gossip", "invalid"}, 1)
+
+	g.network.ReportPeer(id, network.PenaltyInvalidTx, fmt.Sprintf("invalid tx: %v", err))
+}
+
+// PushTxns is a gRPC endpoint receiving the full transactions from the peer
+func (g *txGossip) PushTxns(ctx context.Context, req *proto.TxnBodies) (*empty.Empty, error) {
+	id, err := peerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Txns) > maxGossipTxs {
+		return nil, errTooManyGossipTxs
+	}
+
+	txs := make([]*types.Transaction, 0, len(req.Txns))
+	hashes := make([]types.Hash, 0, len(req.Txns))
+
+	for _, raw := range req.Txns {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			g.logger.Debug("failed to decode pushed tx", "id", id, "err", err)
+			g.reportInvalidTx(id, err)
+
+			continue
+		}
+
+		txs = append(txs, tx)
+		hashes = append(hashes, tx.Hash)
+	}
+
+	g.markKnownHashes(id, hashes)
+
+	if g.pool.getSealing() {
+		g.addTxs(id, txs)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// AnnounceTxns is a gRPC endpoint receiving the hashes of the new transactions from the peer.
+// The unknown transactions are fetched from the peer in the background
+func (g *txGossip) AnnounceTxns(ctx context.Context, req *proto.TxnHashes) (*empty.Empty, error) {
+	id, err := peerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Hashes) > maxGossipTxs {
+		return nil, errTooManyGossipTxs
+	}
+
+	hashes := make([]types.Hash, len(req.Hashes))
+	for i, hash := range req.Hashes {
+		hashes[i] = types.BytesToHash(hash)
+	}
+
+	g.markKnownHashes(id, hashes)
+
+	if !g.pool.getSealing() {
+		return &empty.Empty{}, nil
+	}
+
+	if unknown := g.startFetching(hashes); len(unknown) > 0 {
+		go g.fetch(id, unknown)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// startFetching returns the hashes which are neither in the pool nor being fetched,
+// and marks them as being fetched
+func (g *txGossip) startFetching(hashes []types.Hash) []types.Hash {
+	g.fetchingLock.Lock()
+	defer g.fetchingLock.Unlock()
+
+	unknown := make([]types.Hash, 0, len(hashes))
+
+	for _, hash := range hashes {
+		if _, ok := g.pool.index.get(hash); ok {
+			continue
+		}
+
+		if _, ok := g.fetching[hash]; ok {
+			continue
+		}
+
+		g.fetching[hash] = struct{}{}
+
+		unknown = append(unknown, hash)
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "gossip", "suppressed"}, float32(len(hashes)-len(unknown)))
+
+	return unknown
+}
+
+// GetTxns is a gRPC endpoint returning the requested transactions known to the pool, except the private ones
+func (g *txGossip) GetTxns(_ context.Context, req *proto.TxnHashes) (*proto.TxnBodies, error) {
+	if len(req.Hashes) > maxGossipTxs {
+		return nil, errTooManyGossipTxs
+	}
+
+	txs := make([]*types.Transaction, 0, len(req.Hashes))
+
+	for _, hash := range req.Hashes {
+		if tx, ok := g.pool.index.getPublic(types.BytesToHash(hash)); ok {
+			txs = append(txs, tx)
+		}
+	}
+
+	return toTxnBodies(txs), nil
+}
+
+// peerFromContext returns the ID of the peer sending the gossip request
+func peerFromContext(ctx context.Context) (peer.ID, error) {
+	grpcCtx, ok := ctx.(*grpc.Context)
+	if !ok {
+		return "", errInvalidGossipContext
+	}
+
+	return grpcCtx.PeerID, nil
+}
+
+// toTxnBodies returns the RLP encoded transactions
+func toTxnBodies(txs []*types.Transaction) *proto.TxnBodies {
+	bodies := &proto.TxnBodies{Txns: make([][]byte, len(txs))}
+	for i, tx := range txs {
+		bodies.Txns[i] = tx.MarshalRLP()
+	}
+
+	return bodies
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,288 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rawGrpc "google.golang.org/grpc"
+
+	"github.com/vishnushankarsg/metad/network"
+	"github.com/vishnushankarsg/metad/network/grpc"
+	"github.com/vishnushankarsg/metad/txpool/proto"
+	"github.com/vishnushankarsg/metad/types"
+)
+
+// mockGossipNetwork is the network without any connected peers
+type mockGossipNetwork struct {
+	connections []peer.ID
+
+	reportsLock sync.Mutex
+	reports     []peer.ID
+}
+
+func (m *mockGossipNetwork) Peers() []*network.PeerConnInfo {
+	return nil
+}
+
+func (m *mockGossipNetwork) GetProtocols(peer.ID) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockGossipNetwork) NewProtoConnection(_ string, peerID peer.ID) (*rawGrpc.ClientConn, error) {
+	m.connections = append(m.connections, peerID)
+
+	return nil, errors.New("not connected")
+}
+
+func (m *mockGossipNetwork) RegisterProtocol(string, network.Protocol) {}
+
+func (m *mockGossipNetwork) ReportPeer(peerID peer.ID, _ network.PeerPenalty, _ string) {
+	m.reportsLock.Lock()
+	defer m.reportsLock.Unlock()
+
+	m.reports = append(m.reports, peerID)
+}
+
+func (m *mockGossipNetwork) getReports() []peer.ID {
+	m.reportsLock.Lock()
+	defer m.reportsLock.Unlock()
+
+	return m.reports
+}
+
+func newGossipContext(id peer.ID) context.Context {
+	return &grpc.Context{
+		Context: context.Background(),
+		PeerID:  id,
+	}
+}
+
+func TestTxGossip_Handlers(t *testing.T) {
+	t.Parallel()
+
+	const remotePeer = peer.ID("remote")
+
+	setupGossip := func(t *testing.T) (*TxPool, *txGossip, *mockGossipNetwork) {
+		t.Helper()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.SetSealing(true)
+
+		network := &mockGossipNetwork{}
+
+		return pool, newTxGossip(hclog.NewNullLogger(), pool, network), network
+	}
+
+	t.Run("pushed transactions are added and propagated", func(t *testing.T) {
+		t.Parallel()
+
+		pool, g, _ := setupGossip(t)
+
+		tx := newTx(addr1, 10, 1).ComputeHash()
+
+		go func() {
+			_, err := g.PushTxns(newGossipContext(remotePeer), toTxnBodies([]*types.Transaction{tx}))
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		require.Eventually(t, func() bool {
+			g.queueLock.Lock()
+			defer g.queueLock.Unlock()
+
+			return len(g.queue) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		// the transaction is never sent back to the pushing peer
+		assert.Empty(t, g.markKnown(remotePeer, []*types.Transaction{tx}))
+	})
+
+	t.Run("peers pushing invalid transactions are reported", func(t *testing.T) {
+		t.Parallel()
+
+		pool, g, network := setupGossip(t)
+
+		invalidTx := newTx(addr1, 0, 1)
+		invalidTx.Type = types.StateTx
+		invalidTx.ComputeHash()
+
+		bodies := toTxnBodies([]*types.Transaction{invalidTx})
+		bodies.Txns = append(bodies.Txns, []byte{0x1})
+
+		_, err := g.PushTxns(newGossipContext(remotePeer), bodies)
+		require.NoError(t, err)
+
+		assert.Equal(t, []peer.ID{remotePeer, remotePeer}, network.getReports())
+		assert.Nil(t, pool.accounts.get(addr1))
+	})
+
+	t.Run("only unknown announced transactions are fetched", func(t *testing.T) {
+		t.Parallel()
+
+		pool, g, network := setupGossip(t)
+
+		knownTx := newTx(addr1, 10, 1).ComputeHash()
+		enqueueTx(t, pool, gossip, knownTx)
+
+		unknownTx := newTx(addr2, 11, 1).ComputeHash()
+
+		// the transaction being fetched is not requested again
+		fetchingTx := newTx(addr3, 12, 1).ComputeHash()
+		g.fetching[fetchingTx.Hash] = struct{}{}
+
+		assert.Equal(t,
+			[]types.Hash{unknownTx.Hash},
+			g.startFetching([]types.Hash{knownTx.Hash, unknownTx.Hash, fetchingTx.Hash}),
+		)
+
+		delete(g.fetching, unknownTx.Hash)
+
+		_, err := g.AnnounceTxns(newGossipContext(remotePeer), &proto.TxnHashes{
+			Hashes: [][]byte{knownTx.Hash.Bytes(), unknownTx.Hash.Bytes()},
+		})
+		require.NoError(t, err)
+
+		// the failed fetch releases the hash
+		require.Eventually(t, func() bool {
+			g.fetchingLock.Lock()
+			defer g.fetchingLock.Unlock()
+
+			_, ok := g.fetching[unknownTx.Hash]
+
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, []peer.ID{remotePeer}, network.connections)
+	})
+
+	t.Run("requested transactions are returned if known", func(t *testing.T) {
+		t.Parallel()
+
+		pool, g, _ := setupGossip(t)
+
+		knownTx := newTx(addr1, 10, 1).ComputeHash()
+		enqueueTx(t, pool, gossip, knownTx)
+
+		resp, err := g.GetTxns(context.Background(), &proto.TxnHashes{
+			Hashes: [][]byte{knownTx.Hash.Bytes(), types.StringToHash("0x1").Bytes()},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{knownTx.MarshalRLP()}, resp.Txns)
+
+		_, err = g.GetTxns(context.Background(), &proto.TxnHashes{
+			Hashes: make([][]byte, maxGossipTxs+1),
+		})
+		assert.ErrorIs(t, err, errTooManyGossipTxs)
+	})
+
+	t.Run("private transactions are not returned", func(t *testing.T) {
+		t.Parallel()
+
+		pool, g, _ := setupGossip(t)
+
+		publicTx := newTx(addr1, 10, 1).ComputeHash()
+		enqueueTx(t, pool, local, publicTx)
+
+		privateTx := newTx(addr2, 10, 1).ComputeHash()
+		enqueueTx(t, pool, private, privateTx)
+
+		resp, err := g.GetTxns(context.Background(), &proto.TxnHashes{
+			Hashes: [][]byte{publicTx.Hash.Bytes(), privateTx.Hash.Bytes()},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{publicTx.MarshalRLP()}, resp.Txns)
+
+		// the private transaction stays private until it leaves the pool
+		pool.index.remove(privateTx)
+		assert.False(t, pool.index.isPrivate(privateTx.Hash))
+	})
+}
+
+func TestTxGossip_Propagation(t *testing.T) {
+	t.Parallel()
+
+	newNetworkedPool := func() (*TxPool, *network.Server) {
+		srv, err := network.CreateServer(&network.CreateServerParams{
+			ConfigCallback: func(c *network.Config) {
+				c.NoDiscover = true
+			},
+		})
+		require.NoError(t, err)
+
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			srv,
+			&Config{
+				PriceLimit:         defaultPriceLimit,
+				MaxSlots:           defaultMaxSlots,
+				MaxAccountEnqueued: defaultMaxAccountEnqueued,
+				PriceBump:          DefaultPriceBump,
+			},
+		)
+		require.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+		pool.SetSealing(true)
+		pool.Start()
+
+		t.Cleanup(func() {
+			pool.Close()
+			_ = srv.Close()
+		})
+
+		return pool, srv
+	}
+
+	sender, senderSrv := newNetworkedPool()
+	receivers := make([]*TxPool, 2)
+
+	for i := range receivers {
+		receiver, receiverSrv := newNetworkedPool()
+
+		require.NoError(t, network.JoinAndWait(
+			senderSrv,
+			receiverSrv,
+			network.DefaultBufferTimeout,
+			network.DefaultJoinTimeout,
+		))
+
+		receivers[i] = receiver
+	}
+
+	// the private transaction is never propagated, even if it ends up in the gossip queue
+	privateTx := new(eoa).create(t).signTx(t, newTx(types.ZeroAddress, 0, 1), signerEIP155).ComputeHash()
+	require.NoError(t, sender.AddPrivateTx(privateTx))
+	sender.gossip.enqueue(privateTx)
+
+	// one of the receivers gets the full transaction,
+	// the other one fetches it after the announcement
+	tx := new(eoa).create(t).signTx(t, newTx(types.ZeroAddress, 0, 1), signerEIP155).ComputeHash()
+	require.NoError(t, sender.AddTx(tx))
+
+	for _, receiver := range receivers {
+		require.Eventually(t, func() bool {
+			_, ok := receiver.index.get(tx.Hash)
+
+			return ok
+		}, 10*time.Second, 10*time.Millisecond)
+
+		_, ok := receiver.index.get(privateTx.Hash)
+		assert.False(t, ok)
+	}
+
+	for _, p := range senderSrv.Peers() {
+		assert.True(t, sender.gossip.supportsGossip(p.Info.ID))
+	}
+}
@@ -0,0 +1,202 @@
+package txpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/vishnushankarsg/metad/types"
+)
+
+const (
+	// journalRotateInterval is the interval of rewriting the journal
+	// with the local transactions still present in the pool
+	journalRotateInterval = time.Hour
+)
+
+// txJournal is the append-only file of the local transactions,
+// kept across the node restarts. Each record is the RLP encoded transaction
+// prefixed with its length
+type txJournal struct {
+	sync.Mutex
+
+	path   string
+	writer *os.File
+
+	// hashes of the journaled transactions
+	hashes map[types.Hash]struct{}
+}
+
+func newTxJournal(path string) *txJournal {
+	return &txJournal{
+		path:   path,
+		hashes: make(map[types.Hash]struct{}),
+	}
+}
+
+// load reads the transactions from the journal and passes them to the add callback.
+// Returns the number of the loaded transactions and the ones the callback rejected
+func (j *txJournal) load(add func(tx *types.Transaction) error) (int, int, error) {
+	fs, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		// nothing journaled yet
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	defer fs.Close()
+
+	var (
+		reader          = bufio.NewReader(fs)
+		loaded, dropped int
+	)
+
+	for {
+		size, err := binary.ReadUvarint(reader)
+		if errors.Is(err, io.EOF) {
+			return loaded, dropped, nil
+		} else if err != nil {
+			return loaded, dropped, err
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return loaded, dropped, err
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(data); err != nil {
+			return loaded, dropped, err
+		}
+
+		loaded++
+
+		if err := add(tx); err != nil {
+			dropped++
+		}
+	}
+}
+
+// insert appends the transaction to the journal
+func (j *txJournal) insert(tx *types.Transaction) error {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.writer == nil {
+		return errors.New("journal is not open")
+	}
+
+	if _, err := j.writer.Write(encodeJournalRecord(nil, tx)); err != nil {
+		return err
+	}
+
+	j.hashes[tx.Hash] = struct{}{}
+
+	return nil
+}
+
+// contains returns true if the transaction has been journaled
+func (j *txJournal) contains(hash types.Hash) bool {
+	j.Lock()
+	defer j.Unlock()
+
+	_, ok := j.hashes[hash]
+
+	return ok
+}
+
+// rotate rewrites the journal with the given transactions
+// and reopens it for appending the new ones
+func (j *txJournal) rotate(txs map[types.Address][]*types.Transaction) error {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return err
+		}
+
+		j.writer = nil
+	}
+
+	tmpPath := j.path + ".new"
+
+	replacement, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	var (
+		writer = bufio.NewWriter(replacement)
+		hashes = make(map[types.Hash]struct{})
+	)
+
+	for _, accountTxs := range txs {
+		sorted := make([]*types.Transaction, len(accountTxs))
+		copy(sorted, accountTxs)
+		sortByNonce(sorted)
+
+		for _, tx := range sorted {
+			if _, err := writer.Write(encodeJournalRecord(nil, tx)); err != nil {
+				replacement.Close()
+
+				return err
+			}
+
+			hashes[tx.Hash] = struct{}{}
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		replacement.Close()
+
+		return err
+	}
+
+	if err := replacement.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return fmt.Errorf("unable to replace the journal, %w", err)
+	}
+
+	j.hashes = hashes
+
+	j.writer, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+
+	return err
+}
+
+// close closes the journal
+func (j *txJournal) close() error {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.writer == nil {
+		return nil
+	}
+
+	err := j.writer.Close()
+	j.writer = nil
+
+	return err
+}
+
+// encodeJournalRecord appends the length prefixed RLP encoding of the transaction to dst
+func encodeJournalRecord(dst []byte, tx *types.Transaction) []byte {
+	var (
+		data   = tx.MarshalRLP()
+		prefix = make([]byte, binary.MaxVarintLen64)
+	)
+
+	dst = append(dst, prefix[:binary.PutUvarint(prefix, uint64(len(data)))]...)
+
+	return append(dst, data...)
+}
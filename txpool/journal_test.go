@@ -0,0 +1,149 @@
+package txpool
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vishnushankarsg/metad/types"
+)
+
+func TestTxJournal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+	journal := newTxJournal(path)
+
+	// the missing journal is empty
+	loaded, dropped, err := journal.load(func(*types.Transaction) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, loaded)
+	assert.Equal(t, 0, dropped)
+
+	txs := []*types.Transaction{
+		newTx(addr1, 0, 1),
+		newTx(addr1, 1, 1),
+		newTx(addr2, 0, 2),
+	}
+
+	for _, tx := range txs {
+		tx.ComputeHash()
+	}
+
+	// the rotation keeps the given transactions only
+	require.NoError(t, journal.rotate(map[types.Address][]*types.Transaction{
+		addr1: {txs[1], txs[0]},
+	}))
+	require.NoError(t, journal.insert(txs[2]))
+	require.NoError(t, journal.close())
+
+	var journaled []*types.Transaction
+
+	loaded, dropped, err = journal.load(func(tx *types.Transaction) error {
+		journaled = append(journaled, tx.ComputeHash())
+
+		if tx.Nonce == 1 {
+			return ErrNonceTooLow
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, loaded)
+	assert.Equal(t, 1, dropped)
+
+	require.Len(t, journaled, 3)
+
+	for i, tx := range journaled {
+		assert.Equal(t, txs[i].Hash, tx.Hash)
+	}
+}
+
+func TestTxPool_Journal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	newJournaledPool := func() *TxPool {
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			nil,
+			&Config{
+				PriceLimit:         defaultPriceLimit,
+				MaxSlots:           defaultMaxSlots,
+				MaxAccountEnqueued: defaultMaxAccountEnqueued,
+				PriceBump:          DefaultPriceBump,
+				Journal:            path,
+			},
+		)
+		require.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+
+		return pool
+	}
+
+	// readJournal reads the nonces of the journaled transactions from the journal file
+	readJournal := func() []uint64 {
+		nonces := []uint64{}
+
+		_, _, err := newTxJournal(path).load(func(tx *types.Transaction) error {
+			nonces = append(nonces, tx.Nonce)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		return nonces
+	}
+
+	localAccount, remoteAccount := new(eoa).create(t), new(eoa).create(t)
+
+	pool := newJournaledPool()
+	pool.Start()
+
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		require.NoError(t, pool.AddTx(localAccount.signTx(t, newTx(types.ZeroAddress, nonce, 1), signerEIP155)))
+	}
+
+	// the transactions received from the network are not journaled, even if their sender is local
+	require.NoError(t, pool.addTx(gossip, remoteAccount.signTx(t, newTx(types.ZeroAddress, 0, 1), signerEIP155)))
+	require.NoError(t, pool.addTx(gossip, localAccount.signTx(t, newTx(types.ZeroAddress, 3, 1), signerEIP155)))
+
+	require.Eventually(t, func() bool {
+		return pool.Length() == 5
+	}, 5*time.Second, 10*time.Millisecond)
+
+	pool.Close()
+
+	assert.Equal(t, []uint64{0, 1, 2}, readJournal())
+
+	// the journal is rewritten on start, before the re-injected transactions are enqueued
+	pool = newJournaledPool()
+	pool.Start()
+	pool.Close()
+
+	assert.Equal(t, []uint64{0, 1, 2}, readJournal())
+
+	// the local transactions are re-injected on start
+	pool = newJournaledPool()
+	pool.Start()
+
+	t.Cleanup(pool.Close)
+
+	require.Eventually(t, func() bool {
+		return pool.Length() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, uint64(3), pool.accounts.get(localAccount.Address).getNonce())
+	assert.False(t, pool.accounts.exists(remoteAccount.Address))
+}
@@ -10,11 +10,13 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+	// private are the transactions never shared with the peers
+	private map[types.Hash]struct{}
 }
 
-// add inserts the given transaction into the map. Returns false
-// if it already exists. [thread-safe]
-func (m *lookupMap) add(tx *types.Transaction) bool {
+// add inserts the given transaction into the map, marking it as private if requested.
+// Returns false if it already exists. [thread-safe]
+func (m *lookupMap) add(tx *types.Transaction, private bool) bool {
 	m.Lock()
 	defer m.Unlock()
 
@@ -24,6 +26,10 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 
 	m.all[tx.Hash] = tx
 
+	if private {
+		m.private[tx.Hash] = struct{}{}
+	}
+
 	return true
 }
 
@@ -34,6 +40,7 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.private, tx.Hash)
 	}
 }
 
@@ -49,3 +56,28 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// getPublic returns the transaction associated with the given hash,
+// unless the transaction is private. [thread-safe]
+func (m *lookupMap) getPublic(hash types.Hash) (*types.Transaction, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if _, private := m.private[hash]; private {
+		return nil, false
+	}
+
+	tx, ok := m.all[hash]
+
+	return tx, ok
+}
+
+// isPrivate checks if the transaction with the given hash is private. [thread-safe]
+func (m *lookupMap) isPrivate(hash types.Hash) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	_, ok := m.private[hash]
+
+	return ok
+}
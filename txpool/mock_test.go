@@ -15,11 +15,12 @@ var mockHeader = &types.Header{
 
 type defaultMockStore struct {
 	DefaultHeader *types.Header
+	BaseFee       uint64
 }
 
 func NewDefaultMockStore(header *types.Header) defaultMockStore {
 	return defaultMockStore{
-		header,
+		DefaultHeader: header,
 	}
 }
 
@@ -41,6 +42,10 @@ func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error
 	return balance, nil
 }
 
+func (m defaultMockStore) CalculateBaseFee(*types.Header) uint64 {
+	return m.BaseFee
+}
+
 type faultyMockStore struct {
 }
 
@@ -60,6 +65,10 @@ func (fms faultyMockStore) GetBalance(root types.Hash, addr types.Address) (*big
 	return nil, fmt.Errorf("unable to fetch account state")
 }
 
+func (fms faultyMockStore) CalculateBaseFee(*types.Header) uint64 {
+	return 0
+}
+
 type mockSigner struct {
 }
 
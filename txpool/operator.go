@@ -38,7 +38,12 @@ func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTx
 		txn.From = from
 	}
 
-	if err := p.AddTx(txn); err != nil {
+	addTx := p.AddTx
+	if raw.Private {
+		addTx = p.AddPrivateTx
+	}
+
+	if err := addTx(txn); err != nil {
 		return nil, err
 	}
 
@@ -82,3 +87,39 @@ func (p *TxPool) Subscribe(
 		}
 	}
 }
+
+// ContentFrom implements the operator endpoint. Returns the page of the transactions of the sender
+func (p *TxPool) ContentFrom(ctx context.Context, req *proto.ContentFromReq) (*proto.ContentFromResp, error) {
+	from := types.Address{}
+	if err := from.UnmarshalText([]byte(req.From)); err != nil {
+		return nil, fmt.Errorf("invalid sender address, %w", err)
+	}
+
+	pending, queued, total := p.GetTxsFrom(from, req.Offset, req.Limit)
+
+	return &proto.ContentFromResp{
+		Pending: toProtoTransactions(pending),
+		Queued:  toProtoTransactions(queued),
+		Total:   total,
+	}, nil
+}
+
+func toProtoTransactions(txs []*types.Transaction) []*proto.TxnPoolTransaction {
+	result := make([]*proto.TxnPoolTransaction, len(txs))
+
+	for i, tx := range txs {
+		result[i] = &proto.TxnPoolTransaction{
+			Hash:     tx.Hash.String(),
+			Nonce:    tx.Nonce,
+			Gas:      tx.Gas,
+			GasPrice: tx.GetGasFeeCap().String(),
+			Value:    tx.Value.String(),
+		}
+
+		if tx.To != nil {
+			result[i].To = tx.To.String()
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,234 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: txpool/proto/gossip.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on TxnHashes with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TxnHashes) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TxnHashes with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TxnHashesMultiError, or nil
+// if none found.
+func (m *TxnHashes) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TxnHashes) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return TxnHashesMultiError(errors)
+	}
+
+	return nil
+}
+
+// TxnHashesMultiError is an error wrapping multiple validation errors returned
+// by TxnHashes.ValidateAll() if the designated constraints aren't met.
+type TxnHashesMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TxnHashesMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TxnHashesMultiError) AllErrors() []error { return m }
+
+// TxnHashesValidationError is the validation error returned by
+// TxnHashes.Validate if the designated constraints aren't met.
+type TxnHashesValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TxnHashesValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TxnHashesValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TxnHashesValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TxnHashesValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TxnHashesValidationError) ErrorName() string { return "TxnHashesValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TxnHashesValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTxnHashes.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TxnHashesValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TxnHashesValidationError{}
+
+// Validate checks the field values on TxnBodies with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TxnBodies) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TxnBodies with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TxnBodiesMultiError, or nil
+// if none found.
+func (m *TxnBodies) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TxnBodies) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return TxnBodiesMultiError(errors)
+	}
+
+	return nil
+}
+
+// TxnBodiesMultiError is an error wrapping multiple validation errors returned
+// by TxnBodies.ValidateAll() if the designated constraints aren't met.
+type TxnBodiesMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TxnBodiesMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TxnBodiesMultiError) AllErrors() []error { return m }
+
+// TxnBodiesValidationError is the validation error returned by
+// TxnBodies.Validate if the designated constraints aren't met.
+type TxnBodiesValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TxnBodiesValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TxnBodiesValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TxnBodiesValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TxnBodiesValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TxnBodiesValidationError) ErrorName() string { return "TxnBodiesValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TxnBodiesValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTxnBodies.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TxnBodiesValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TxnBodiesValidationError{}
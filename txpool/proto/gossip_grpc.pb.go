@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.7
+// source: txpool/proto/gossip.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// TxnGossipClient is the client API for TxnGossip service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TxnGossipClient interface {
+	// Pushes the full transactions to the peer
+	PushTxns(ctx context.Context, in *TxnBodies, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Announces the hashes of the new transactions to the peer
+	AnnounceTxns(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns the transactions of the given hashes known to the peer
+	GetTxns(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*TxnBodies, error)
+}
+
+type txnGossipClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTxnGossipClient(cc grpc.ClientConnInterface) TxnGossipClient {
+	return &txnGossipClient{cc}
+}
+
+func (c *txnGossipClient) PushTxns(ctx context.Context, in *TxnBodies, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.TxnGossip/PushTxns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnGossipClient) AnnounceTxns(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.TxnGossip/AnnounceTxns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnGossipClient) GetTxns(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*TxnBodies, error) {
+	out := new(TxnBodies)
+	err := c.cc.Invoke(ctx, "/v1.TxnGossip/GetTxns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TxnGossipServer is the server API for TxnGossip service.
+// All implementations must embed UnimplementedTxnGossipServer
+// for forward compatibility
+type TxnGossipServer interface {
+	// Pushes the full transactions to the peer
+	PushTxns(context.Context, *TxnBodies) (*emptypb.Empty, error)
+	// Announces the hashes of the new transactions to the peer
+	AnnounceTxns(context.Context, *TxnHashes) (*emptypb.Empty, error)
+	// Returns the transactions of the given hashes known to the peer
+	GetTxns(context.Context, *TxnHashes) (*TxnBodies, error)
+	mustEmbedUnimplementedTxnGossipServer()
+}
+
+// UnimplementedTxnGossipServer must be embedded to have forward compatible implementations.
+type UnimplementedTxnGossipServer struct {
+}
+
+func (UnimplementedTxnGossipServer) PushTxns(context.Context, *TxnBodies) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushTxns not implemented")
+}
+func (UnimplementedTxnGossipServer) AnnounceTxns(context.Context, *TxnHashes) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnounceTxns not implemented")
+}
+func (UnimplementedTxnGossipServer) GetTxns(context.Context, *TxnHashes) (*TxnBodies, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxns not implemented")
+}
+func (UnimplementedTxnGossipServer) mustEmbedUnimplementedTxnGossipServer() {}
+
+// UnsafeTxnGossipServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TxnGossipServer will
+// result in compilation errors.
+type UnsafeTxnGossipServer interface {
+	mustEmbedUnimplementedTxnGossipServer()
+}
+
+func RegisterTxnGossipServer(s grpc.ServiceRegistrar, srv TxnGossipServer) {
+	s.RegisterService(&TxnGossip_ServiceDesc, srv)
+}
+
+func _TxnGossip_PushTxns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnBodies)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnGossipServer).PushTxns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnGossip/PushTxns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnGossipServer).PushTxns(ctx, req.(*TxnBodies))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnGossip_AnnounceTxns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnHashes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnGossipServer).AnnounceTxns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnGossip/AnnounceTxns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnGossipServer).AnnounceTxns(ctx, req.(*TxnHashes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnGossip_GetTxns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnHashes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnGossipServer).GetTxns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnGossip/GetTxns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnGossipServer).GetTxns(ctx, req.(*TxnHashes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TxnGossip_ServiceDesc is the grpc.ServiceDesc for TxnGossip service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TxnGossip_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.TxnGossip",
+	HandlerType: (*TxnGossipServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PushTxns",
+			Handler:    _TxnGossip_PushTxns_Handler,
+		},
+		{
+			MethodName: "AnnounceTxns",
+			Handler:    _TxnGossip_AnnounceTxns_Handler,
+		},
+		{
+			MethodName: "GetTxns",
+			Handler:    _TxnGossip_GetTxns_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "txpool/proto/gossip.proto",
+}
@@ -87,6 +87,8 @@ func (m *AddTxnReq) validate(all bool) error {
 
 	}
 
+	// no validation rules for Private
+
 	if len(errors) > 0 {
 		return AddTxnReqMultiError(errors)
 	}
@@ -371,6 +373,396 @@ var _ interface {
 	ErrorName() string
 } = TxnPoolStatusRespValidationError{}
 
+// Validate checks the field values on ContentFromReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ContentFromReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ContentFromReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ContentFromReqMultiError,
+// or nil if none found.
+func (m *ContentFromReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ContentFromReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for From
+
+	// no validation rules for Offset
+
+	// no validation rules for Limit
+
+	if len(errors) > 0 {
+		return ContentFromReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ContentFromReqMultiError is an error wrapping multiple validation errors
+// returned by ContentFromReq.ValidateAll() if the designated constraints
+// aren't met.
+type ContentFromReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ContentFromReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ContentFromReqMultiError) AllErrors() []error { return m }
+
+// ContentFromReqValidationError is the validation error returned by
+// ContentFromReq.Validate if the designated constraints aren't met.
+type ContentFromReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ContentFromReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ContentFromReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ContentFromReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ContentFromReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ContentFromReqValidationError) ErrorName() string { return "ContentFromReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ContentFromReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sContentFromReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ContentFromReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ContentFromReqValidationError{}
+
+// Validate checks the field values on ContentFromResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ContentFromResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ContentFromResp with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ContentFromRespMultiError, or nil if none found.
+func (m *ContentFromResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ContentFromResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetPending() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Pending[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Pending[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ContentFromRespValidationError{
+					field:  fmt.Sprintf("Pending[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetQueued() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Queued[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Queued[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ContentFromRespValidationError{
+					field:  fmt.Sprintf("Queued[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ContentFromRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// ContentFromRespMultiError is an error wrapping multiple validation errors
+// returned by ContentFromResp.ValidateAll() if the designated constraints
+// aren't met.
+type ContentFromRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ContentFromRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ContentFromRespMultiError) AllErrors() []error { return m }
+
+// ContentFromRespValidationError is the validation error returned by
+// ContentFromResp.Validate if the designated constraints aren't met.
+type ContentFromRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ContentFromRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ContentFromRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ContentFromRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ContentFromRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ContentFromRespValidationError) ErrorName() string { return "ContentFromRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ContentFromRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sContentFromResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ContentFromRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ContentFromRespValidationError{}
+
+// Validate checks the field values on TxnPoolTransaction with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *TxnPoolTransaction) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TxnPoolTransaction with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TxnPoolTransactionMultiError, or nil if none found.
+func (m *TxnPoolTransaction) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TxnPoolTransaction) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Hash
+
+	// no validation rules for Nonce
+
+	// no validation rules for Gas
+
+	// no validation rules for GasPrice
+
+	// no validation rules for To
+
+	// no validation rules for Value
+
+	if len(errors) > 0 {
+		return TxnPoolTransactionMultiError(errors)
+	}
+
+	return nil
+}
+
+// TxnPoolTransactionMultiError is an error wrapping multiple validation errors
+// returned by TxnPoolTransaction.ValidateAll() if the designated constraints
+// aren't met.
+type TxnPoolTransactionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TxnPoolTransactionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TxnPoolTransactionMultiError) AllErrors() []error { return m }
+
+// TxnPoolTransactionValidationError is the validation error returned by
+// TxnPoolTransaction.Validate if the designated constraints aren't met.
+type TxnPoolTransactionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TxnPoolTransactionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TxnPoolTransactionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TxnPoolTransactionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TxnPoolTransactionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TxnPoolTransactionValidationError) ErrorName() string {
+	return "TxnPoolTransactionValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TxnPoolTransactionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTxnPoolTransaction.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TxnPoolTransactionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TxnPoolTransactionValidationError{}
+
 // Validate checks the field values on SubscribeRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
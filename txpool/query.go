@@ -46,3 +46,59 @@ func (p *TxPool) GetTxs(inclQueued bool) (
 
 	return
 }
+
+// MaxTxsPageSize is the maximum number of transactions returned by GetTxsFrom
+const MaxTxsPageSize uint64 = 1000
+
+// GetTxsFrom gets the page of pending and queued transactions of the sender, ordered by nonce,
+// along with the total number of the sender's transactions. The page size is capped by MaxTxsPageSize,
+// which is also the size of the page if the limit is zero
+func (p *TxPool) GetTxsFrom(addr types.Address, offset, limit uint64) (
+	pending, queued []*types.Transaction,
+	total uint64,
+) {
+	account := p.accounts.get(addr)
+	if account == nil {
+		return nil, nil, 0
+	}
+
+	account.promoted.lock(false)
+	account.enqueued.lock(false)
+
+	txs := make([]*types.Transaction, 0, account.promoted.length()+account.enqueued.length())
+	txs = append(txs, account.promoted.queue...)
+	txs = append(txs, account.enqueued.queue...)
+	promoted := len(account.promoted.queue)
+
+	account.enqueued.unlock()
+	account.promoted.unlock()
+
+	// the promoted nonces are always lower than the enqueued ones
+	sortByNonce(txs[:promoted])
+	sortByNonce(txs[promoted:])
+
+	total = uint64(len(txs))
+
+	if limit == 0 || limit > MaxTxsPageSize {
+		limit = MaxTxsPageSize
+	}
+
+	if offset >= total {
+		return nil, nil, total
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	for i := offset; i < end; i++ {
+		if i < uint64(promoted) {
+			pending = append(pending, txs[i])
+		} else {
+			queued = append(queued, txs[i])
+		}
+	}
+
+	return pending, queued, total
+}
@@ -2,6 +2,8 @@ package txpool
 
 import (
 	"container/heap"
+	"math/big"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -77,6 +79,46 @@ func (q *accountQueue) push(tx *types.Transaction) {
 	heap.Push(&q.queue, tx)
 }
 
+// get returns the transaction with the given nonce, nil if there's none.
+func (q *accountQueue) get(nonce uint64) *types.Transaction {
+	for _, tx := range q.queue {
+		if tx.Nonce == nonce {
+			return tx
+		}
+	}
+
+	return nil
+}
+
+// replace swaps the transaction having the same nonce as the given one
+// for the given transaction and returns the replaced one (if any).
+func (q *accountQueue) replace(tx *types.Transaction) *types.Transaction {
+	for i, old := range q.queue {
+		if old.Nonce == tx.Nonce {
+			q.queue[i] = tx
+			heap.Fix(&q.queue, i)
+
+			return old
+		}
+	}
+
+	return nil
+}
+
+// remove removes the given transaction from the queue
+// and reports whether it was present.
+func (q *accountQueue) remove(tx *types.Transaction) bool {
+	for i, queued := range q.queue {
+		if queued == tx {
+			heap.Remove(&q.queue, i)
+
+			return true
+		}
+	}
+
+	return false
+}
+
 // peek returns the first transaction from the queue without removing it.
 func (q *accountQueue) peek() *types.Transaction {
 	if q.length() == 0 {
@@ -105,6 +147,13 @@ func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
 }
 
+// sortByNonce sorts the given transactions by nonce (ascending).
+func sortByNonce(txs []*types.Transaction) {
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Nonce < txs[j].Nonce
+	})
+}
+
 // transactions sorted by nonce (ascending)
 type minNonceQueue []*types.Transaction
 
@@ -129,7 +178,7 @@ func (q *minNonceQueue) Swap(i, j int) {
 func (q *minNonceQueue) Less(i, j int) bool {
 	// The higher gas price Tx comes first if the nonces are same
 	if (*q)[i].Nonce == (*q)[j].Nonce {
-		return (*q)[i].GasPrice.Cmp((*q)[j].GasPrice) > 0
+		return (*q)[i].GetGasFeeCap().Cmp((*q)[j].GetGasFeeCap()) > 0
 	}
 
 	return (*q)[i].Nonce < (*q)[j].Nonce
@@ -153,13 +202,18 @@ func (q *minNonceQueue) Pop() interface{} {
 	return x
 }
 
+// A thread-safe wrapper of a maxPriceQueue.
 type pricedQueue struct {
+	sync.Mutex
+
 	queue maxPriceQueue
 }
 
 func newPricedQueue() *pricedQueue {
 	q := pricedQueue{
-		queue: make(maxPriceQueue, 0),
+		queue: maxPriceQueue{
+			txs: make([]*types.Transaction, 0),
+		},
 	}
 
 	heap.Init(&q.queue)
@@ -169,18 +223,45 @@ func newPricedQueue() *pricedQueue {
 
 // clear empties the underlying queue.
 func (q *pricedQueue) clear() {
-	q.queue = q.queue[:0]
+	q.Lock()
+	defer q.Unlock()
+
+	q.queue.txs = q.queue.txs[:0]
+}
+
+// setBaseFee sets the base fee the effective tips are calculated against.
+// The queue has to be empty as the ordering of the present transactions is not updated
+func (q *pricedQueue) setBaseFee(baseFee uint64) {
+	q.Lock()
+	defer q.Unlock()
+
+	q.queue.baseFee = new(big.Int).SetUint64(baseFee)
+}
+
+// setPriority sets the senders whose transactions come first.
+// The queue has to be empty as the ordering of the present transactions is not updated
+func (q *pricedQueue) setPriority(senders map[types.Address]struct{}) {
+	q.Lock()
+	defer q.Unlock()
+
+	q.queue.priority = senders
 }
 
 // Pushes the given transactions onto the queue.
 func (q *pricedQueue) push(tx *types.Transaction) {
+	q.Lock()
+	defer q.Unlock()
+
 	heap.Push(&q.queue, tx)
 }
 
 // Pop removes the first transaction from the queue
 // or nil if the queue is empty.
 func (q *pricedQueue) pop() *types.Transaction {
-	if q.length() == 0 {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.queue.Len() == 0 {
 		return nil
 	}
 
@@ -192,13 +273,40 @@ func (q *pricedQueue) pop() *types.Transaction {
 	return transaction
 }
 
+// replace swaps the old transaction for the given one
+// and reports whether the old transaction was in the queue.
+func (q *pricedQueue) replace(old, tx *types.Transaction) bool {
+	q.Lock()
+	defer q.Unlock()
+
+	for i, queued := range q.queue.txs {
+		if queued == old {
+			q.queue.txs[i] = tx
+			heap.Fix(&q.queue, i)
+
+			return true
+		}
+	}
+
+	return false
+}
+
 // length returns the number of transactions in the queue.
 func (q *pricedQueue) length() uint64 {
+	q.Lock()
+	defer q.Unlock()
+
 	return uint64(q.queue.Len())
 }
 
-// transactions sorted by gas price (descending)
-type maxPriceQueue []*types.Transaction
+// transactions sorted by effective gas tip (descending)
+type maxPriceQueue struct {
+	baseFee *big.Int
+	txs     []*types.Transaction
+
+	// senders whose transactions come first, regardless of the price
+	priority map[types.Address]struct{}
+}
 
 /* Queue methods required by the heap interface */
 
@@ -207,19 +315,19 @@ func (q *maxPriceQueue) Peek() *types.Transaction {
 		return nil
 	}
 
-	return (*q)[0]
+	return q.txs[0]
 }
 
 func (q *maxPriceQueue) Len() int {
-	return len(*q)
+	return len(q.txs)
 }
 
 func (q *maxPriceQueue) Swap(i, j int) {
-	(*q)[i], (*q)[j] = (*q)[j], (*q)[i]
+	q.txs[i], q.txs[j] = q.txs[j], q.txs[i]
 }
 
 func (q *maxPriceQueue) Less(i, j int) bool {
-	return (*q)[i].GasPrice.Uint64() > (*q)[j].GasPrice.Uint64()
+	return q.cmp(q.txs[i], q.txs[j]) > 0
 }
 
 func (q *maxPriceQueue) Push(x interface{}) {
@@ -228,14 +336,35 @@ func (q *maxPriceQueue) Push(x interface{}) {
 		return
 	}
 
-	*q = append(*q, transaction)
+	q.txs = append(q.txs, transaction)
 }
 
 func (q *maxPriceQueue) Pop() interface{} {
-	old := q
-	n := len(*old)
-	x := (*old)[n-1]
-	*q = (*old)[0 : n-1]
+	n := len(q.txs)
+	x := q.txs[n-1]
+	q.txs = q.txs[0 : n-1]
 
 	return x
 }
+
+// cmp compares the transactions by the priority of their senders first,
+// then by the effective gas tip, and then by the gas fee cap and the gas tip cap
+func (q *maxPriceQueue) cmp(a, b *types.Transaction) int {
+	if _, aPriority := q.priority[a.From]; aPriority {
+		if _, bPriority := q.priority[b.From]; !bPriority {
+			return 1
+		}
+	} else if _, bPriority := q.priority[b.From]; bPriority {
+		return -1
+	}
+
+	if c := a.EffectiveGasTip(q.baseFee).Cmp(b.EffectiveGasTip(q.baseFee)); c != 0 {
+		return c
+	}
+
+	if c := a.GetGasFeeCap().Cmp(b.GetGasFeeCap()); c != 0 {
+		return c
+	}
+
+	return a.GetGasTipCap().Cmp(b.GetGasTipCap())
+}
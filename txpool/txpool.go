@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -15,7 +16,6 @@ import (
 	"github.com/vishnushankarsg/metad/txpool/proto"
 	"github.com/vishnushankarsg/metad/types"
 	"github.com/armon/go-metrics"
-	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/grpc"
@@ -37,6 +37,10 @@ const (
 
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
+
+	// DefaultPriceBump is the minimum gas price increase (in percent)
+	// required to replace a transaction with the same nonce
+	DefaultPriceBump uint64 = 10
 )
 
 // errors
@@ -57,14 +61,18 @@ var (
 	ErrRejectFutureTx          = errors.New("rejected future tx due to low slots")
 	ErrSmartContractRestricted = errors.New("smart contract deployment restricted")
 	ErrInvalidTxType           = errors.New("invalid tx type")
+	ErrTxTypeNotSupported      = errors.New("transaction type not supported")
+	ErrTipAboveFeeCap          = errors.New("max priority fee per gas higher than max fee per gas")
+	ErrReplacementUnderpriced  = errors.New("replacement transaction underpriced")
 )
 
 // indicates origin of a transaction
 type txOrigin int
 
 const (
-	local  txOrigin = iota // json-RPC/gRPC endpoints
-	gossip                 // gossip protocol
+	local   txOrigin = iota // json-RPC/gRPC endpoints
+	gossip                  // gossip protocol
+	private                 // json-RPC/gRPC endpoints, never shared with the peers
 )
 
 func (o txOrigin) String() (s string) {
@@ -73,6 +81,8 @@ func (o txOrigin) String() (s string) {
 		s = "local"
 	case gossip:
 		s = "gossip"
+	case private:
+		s = "private"
 	}
 
 	return
@@ -84,6 +94,7 @@ type store interface {
 	GetNonce(root types.Hash, addr types.Address) uint64
 	GetBalance(root types.Hash, addr types.Address) (*big.Int, error)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
+	CalculateBaseFee(parent *types.Header) uint64
 }
 
 type signer interface {
@@ -94,7 +105,20 @@ type Config struct {
 	PriceLimit          uint64
 	MaxSlots            uint64
 	MaxAccountEnqueued  uint64
+	PriceBump           uint64
 	DeploymentWhitelist []types.Address
+
+	// Journal is the path of the journal of the local transactions,
+	// kept across the restarts. The journal is disabled if empty
+	Journal string
+
+	// EnqueuedLifetime is the maximum time the enqueued transactions of a non-local
+	// account are kept without any activity of the account. Disabled if zero
+	EnqueuedLifetime time.Duration
+
+	// PrioritySenders are exempt from the price limit, never evicted,
+	// and their transactions are executed first
+	PrioritySenders []types.Address
 }
 
 /* All requests are passed to the main loop
@@ -151,7 +175,8 @@ type TxPool struct {
 	index lookupMap
 
 	// networking stack
-	topic *network.Topic
+	topic  *network.Topic
+	gossip *txGossip
 
 	// gauge for measuring pool capacity
 	gauge slotGauge
@@ -159,6 +184,17 @@ type TxPool struct {
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// priceBump is the minimum gas price increase (in percent)
+	// of a transaction replacing the one with the same nonce
+	priceBump uint64
+
+	// enqueuedLifetime is the maximum time of keeping
+	// the enqueued transactions of an inactive account
+	enqueuedLifetime time.Duration
+
+	// evictionLock serializes evictions of the underpriced transactions
+	evictionLock sync.Mutex
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	enqueueReqCh chan enqueueRequest
@@ -178,6 +214,18 @@ type TxPool struct {
 	// deploymentWhitelist map
 	deploymentWhitelist deploymentWhitelist
 
+	// senders of the local transactions
+	locals *localAccounts
+
+	// senders exempt from the price limit and eviction, executed first
+	prioritySenders map[types.Address]struct{}
+
+	// journal of the local transactions (nil if disabled)
+	journal *txJournal
+
+	// bundles submitted for the upcoming blocks
+	bundles *bundlePool
+
 	// indicates which txpool operator commands should be implemented
 	proto.UnimplementedTxnPoolOperatorServer
 
@@ -236,9 +284,14 @@ func NewTxPool(
 		store:       store,
 		executables: newPricedQueue(),
 		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
+		index:       lookupMap{all: make(map[types.Hash]*types.Transaction), private: make(map[types.Hash]struct{})},
 		gauge:       slotGauge{height: 0, max: config.MaxSlots},
 		priceLimit:  config.PriceLimit,
+		priceBump:   config.PriceBump,
+		locals:      newLocalAccounts(),
+		bundles:     &bundlePool{},
+
+		enqueuedLifetime: config.EnqueuedLifetime,
 
 		//	main loop channels
 		enqueueReqCh: make(chan enqueueRequest),
@@ -262,11 +315,26 @@ func NewTxPool(
 		}
 
 		pool.topic = topic
+
+		// announce the transactions by their hashes to the upgraded peers
+		pool.gossip = newTxGossip(pool.logger, pool, network)
+		pool.gossip.register()
 	}
 
 	// initialize deployment whitelist
 	pool.deploymentWhitelist = newDeploymentWhitelist(config.DeploymentWhitelist)
 
+	pool.prioritySenders = make(map[types.Address]struct{}, len(config.PrioritySenders))
+	for _, addr := range config.PrioritySenders {
+		pool.prioritySenders[addr] = struct{}{}
+	}
+
+	pool.executables.setPriority(pool.prioritySenders)
+
+	if config.Journal != "" {
+		pool.journal = newTxJournal(config.Journal)
+	}
+
 	if grpcServer != nil {
 		proto.RegisterTxnPoolOperatorServer(grpcServer, pool)
 	}
@@ -315,12 +383,127 @@ func (p *TxPool) Start() {
 			}
 		}
 	}()
+
+	if p.enqueuedLifetime > 0 {
+		//	run the handler for the expired enqueued transactions
+		go func() {
+			ticker := time.NewTicker(evictionInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-p.shutdownCh:
+					return
+				case now := <-ticker.C:
+					p.evictExpired(now)
+				}
+			}
+		}()
+	}
+
+	if p.gossip != nil {
+		//	run the handler for the transaction propagation
+		go p.gossip.run(p.shutdownCh)
+	}
+
+	if p.journal != nil {
+		p.loadJournal()
+
+		//	run the handler for the journal rotation
+		go func() {
+			ticker := time.NewTicker(journalRotateInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-p.shutdownCh:
+					return
+				case <-ticker.C:
+					p.rotateJournal()
+				}
+			}
+		}()
+	}
 }
 
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.eventManager.Close()
-	p.shutdownCh <- struct{}{}
+	close(p.shutdownCh)
+
+	if p.gossip != nil {
+		if err := p.gossip.close(); err != nil {
+			p.logger.Error("failed to close the gossip", "err", err)
+		}
+	}
+
+	if p.journal != nil {
+		p.rotateJournal()
+
+		if err := p.journal.close(); err != nil {
+			p.logger.Error("failed to close the journal", "err", err)
+		}
+	}
+}
+
+// loadJournal re-injects the journaled local transactions
+// and rewrites the journal with the ones accepted by the pool.
+// The accepted transactions are enqueued asynchronously,
+// so the journal is rewritten from them rather than from the accounts
+func (p *TxPool) loadJournal() {
+	accepted := make(map[types.Address][]*types.Transaction)
+
+	loaded, dropped, err := p.journal.load(func(tx *types.Transaction) error {
+		if err := p.addTx(local, tx); err != nil {
+			return err
+		}
+
+		accepted[tx.From] = append(accepted[tx.From], tx)
+
+		return nil
+	})
+	if err != nil {
+		p.logger.Error("failed to load the journal", "err", err)
+	}
+
+	p.logger.Info("loaded the journal", "transactions", loaded, "dropped", dropped)
+
+	if err := p.journal.rotate(accepted); err != nil {
+		p.logger.Error("failed to rotate the journal", "err", err)
+	}
+}
+
+// rotateJournal rewrites the journal with the journaled transactions still present in the pool,
+// the transactions received from the network are not journaled even if their senders are local
+func (p *TxPool) rotateJournal() {
+	promoted, enqueued := p.accounts.allTxs(true)
+	txs := make(map[types.Address][]*types.Transaction)
+
+	for _, all := range []map[types.Address][]*types.Transaction{promoted, enqueued} {
+		for addr, accountTxs := range all {
+			for _, tx := range accountTxs {
+				if p.journal.contains(tx.Hash) {
+					txs[addr] = append(txs[addr], tx)
+				}
+			}
+		}
+	}
+
+	if err := p.journal.rotate(txs); err != nil {
+		p.logger.Error("failed to rotate the journal", "err", err)
+	}
+}
+
+// SubscribeTxEvents subscribes for the pool events of the given types.
+// The returned channel is closed once the subscription is cancelled or the pool is closed
+func (p *TxPool) SubscribeTxEvents(eventTypes []proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	subscription := p.eventManager.subscribe(eventTypes)
+
+	cancel := func() {
+		p.eventManager.cancelSubscription(subscription.subscriptionID)
+	}
+
+	return subscription.subscriptionChannel, cancel
 }
 
 // SetSigner sets the signer the pool will use
@@ -351,23 +534,44 @@ func (p *TxPool) getSealing() bool {
 // AddTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
 func (p *TxPool) AddTx(tx *types.Transaction) error {
+	if err := p.addLocalTx(tx); err != nil {
+		return err
+	}
+
+	// broadcast the transaction only if the
+	// networking stack is present
+	if p.gossip != nil {
+		p.gossip.enqueue(tx)
+	}
+
+	return nil
+}
+
+// AddPrivateTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
+// without broadcasting it to the network. The transaction is only included
+// in the blocks proposed by this node, it's neither served to the peers nor journaled,
+// as the journaled transactions are reloaded as the public ones
+func (p *TxPool) AddPrivateTx(tx *types.Transaction) error {
+	if err := p.addTx(private, tx); err != nil {
+		p.logger.Error("failed to add private tx", "err", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// addLocalTx adds the local transaction to the pool and to the journal (if enabled)
+func (p *TxPool) addLocalTx(tx *types.Transaction) error {
 	if err := p.addTx(local, tx); err != nil {
 		p.logger.Error("failed to add tx", "err", err)
 
 		return err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
-
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
+	if p.journal != nil {
+		if err := p.journal.insert(tx); err != nil {
+			p.logger.Error("failed to journal tx", "err", err)
 		}
 	}
 
@@ -382,6 +586,9 @@ func (p *TxPool) Prepare() {
 		p.executables.clear()
 	}
 
+	// order the transactions by the effective tip in regard to the base fee of the next block
+	p.executables.setBaseFee(p.store.CalculateBaseFee(p.store.Header()))
+
 	// fetch primary from each account
 	primaries := p.accounts.getPrimaries()
 
@@ -414,6 +621,13 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 	account.promoted.lock(true)
 	defer account.promoted.unlock()
 
+	// the tx may have been replaced since it was peeked, the replacement is kept
+	if head := account.promoted.peek(); head == nil || head.Hash != tx.Hash {
+		p.logger.Debug("pop of replaced tx skipped", "hash", tx.Hash.String())
+
+		return
+	}
+
 	// pop the top most promoted tx
 	account.promoted.pop()
 
@@ -458,6 +672,13 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 		account.promoted.unlock()
 	}()
 
+	// the tx may have been replaced in the meantime, the account is kept then
+	if !account.contains(tx) {
+		p.logger.Debug("drop of replaced tx skipped", "hash", tx.Hash.String())
+
+		return
+	}
+
 	// rollback nonce
 	nextNonce := tx.Nonce
 	account.setNonce(nextNonce)
@@ -486,6 +707,18 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 // it is Dropped instead.
 func (p *TxPool) Demote(tx *types.Transaction) {
 	account := p.accounts.get(tx.From)
+
+	// the tx may have been replaced since it was peeked, the replacement isn't demoted
+	account.promoted.lock(false)
+	head := account.promoted.peek()
+	account.promoted.unlock()
+
+	if head == nil || head.Hash != tx.Hash {
+		p.logger.Debug("demotion of replaced tx skipped", "hash", tx.Hash.String())
+
+		return
+	}
+
 	if account.Demotions() >= maxAccountDemotions {
 		p.logger.Debug(
 			"Demote: threshold reached - dropping account",
@@ -580,6 +813,27 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrInvalidTxType
 	}
 
+	// Check the access list transaction type (EIP-2930)
+	if tx.Type == types.AccessListTx && !p.forks.Berlin {
+		return ErrTxTypeNotSupported
+	}
+
+	// Grab the latest block header and the base fee of the next block.
+	// The base fee is only set once the EIP-1559 fork is active for the next block
+	header := p.store.Header()
+	baseFee := p.store.CalculateBaseFee(header)
+
+	// Check the dynamic fee transaction fields (EIP-1559)
+	if tx.Type == types.DynamicFeeTx {
+		if baseFee == 0 {
+			return ErrTxTypeNotSupported
+		}
+
+		if tx.GetGasTipCap().Cmp(tx.GetGasFeeCap()) > 0 {
+			return ErrTipAboveFeeCap
+		}
+	}
+
 	// Check the transaction size to overcome DOS Attacks
 	if uint64(len(tx.MarshalRLP())) > txMaxSize {
 		return ErrOversizedData
@@ -621,13 +875,18 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		}
 	}
 
-	// Reject underpriced transactions
-	if tx.IsUnderpriced(p.priceLimit) {
+	// Reject underpriced transactions, unless sent by the priority sender
+	if tx.IsUnderpriced(p.priceLimit) && !p.isPrioritySender(tx.From) {
+		return ErrUnderpriced
+	}
+
+	// Reject transactions which can't cover the base fee of the next block
+	if baseFee != 0 && tx.GetGasFeeCap().Cmp(new(big.Int).SetUint64(baseFee)) < 0 {
 		return ErrUnderpriced
 	}
 
 	// Grab the state root for the latest block
-	stateRoot := p.store.Header().StateRoot
+	stateRoot := header.StateRoot
 
 	// Check nonce ordering
 	if p.store.GetNonce(stateRoot, tx.From) > tx.Nonce {
@@ -673,9 +932,13 @@ func (p *TxPool) signalPruning() {
 
 func (p *TxPool) pruneAccountsWithNonceHoles() {
 	p.accounts.Range(
-		func(_, value interface{}) bool {
+		func(key, value interface{}) bool {
 			account, _ := value.(*account)
 
+			if addr, _ := key.(types.Address); p.isPrioritySender(addr) {
+				return true
+			}
+
 			account.enqueued.lock(true)
 			defer account.enqueued.unlock()
 
@@ -694,6 +957,8 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 			p.index.remove(removed...)
 			p.gauge.decrease(slotsRequired(removed...))
 
+			countEvicted(evictedNonceHoles, len(removed))
+
 			return true
 		},
 	)
@@ -724,18 +989,33 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		}
 	}
 
-	// check for overflow
-	if slotsRequired(tx) > p.gauge.max-p.gauge.read() {
-		return ErrTxPoolOverflow
-	}
-
 	tx.ComputeHash()
 
 	// add to index
-	if ok := p.index.add(tx); !ok {
+	if ok := p.index.add(tx, origin == private); !ok {
 		return ErrAlreadyKnown
 	}
 
+	// reject the tx which the account won't enqueue
+	if account := p.accounts.get(tx.From); account != nil {
+		if err := account.checkEnqueue(tx, p.priceBump); err != nil {
+			p.index.remove(tx)
+
+			return err
+		}
+	}
+
+	// check for overflow, making room by evicting the cheaper transactions
+	if slotsRequired(tx) > p.gauge.max-p.gauge.read() && !p.evictUnderpriced(tx) {
+		p.index.remove(tx)
+
+		return ErrTxPoolOverflow
+	}
+
+	if origin == local || origin == private {
+		p.locals.add(tx.From)
+	}
+
 	// initialize account for this address once
 	p.createAccountOnce(tx.From)
 
@@ -758,7 +1038,8 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 	account := p.accounts.get(addr)
 
 	// enqueue tx
-	if err := account.enqueue(tx); err != nil {
+	replaced, promoted, err := account.enqueue(tx, p.priceBump)
+	if err != nil {
 		p.logger.Error("enqueue request", "err", err)
 
 		p.index.remove(tx)
@@ -766,10 +1047,28 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 		return
 	}
 
-	p.logger.Debug("enqueue request", "hash", tx.Hash.String())
-
 	p.gauge.increase(slotsRequired(tx))
 
+	if replaced != nil {
+		p.logger.Debug("replace request", "hash", tx.Hash.String(), "replaced", replaced.Hash.String())
+
+		p.index.remove(replaced)
+		p.gauge.decrease(slotsRequired(replaced))
+
+		p.eventManager.signalEvent(proto.EventType_REPLACED, replaced.Hash)
+
+		if promoted {
+			// the tx took the place of the promoted one, no promotion is needed.
+			// If the replaced tx is offered to the block being built, the tx is offered instead
+			p.executables.replace(replaced, tx)
+			p.eventManager.signalEvent(proto.EventType_PROMOTED, tx.Hash)
+
+			return
+		}
+	}
+
+	p.logger.Debug("enqueue request", "hash", tx.Hash.String())
+
 	p.eventManager.signalEvent(proto.EventType_ENQUEUED, tx.Hash)
 
 	if tx.Nonce > account.getNonce() {
@@ -803,7 +1102,7 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 // addGossipTx handles receiving transactions
 // gossiped by the network.
-func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
+func (p *TxPool) addGossipTx(obj interface{}, from peer.ID) {
 	if !p.getSealing() {
 		return
 	}
@@ -818,6 +1117,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// Verify that the gossiped transaction message is not empty
 	if raw == nil || raw.Raw == nil {
 		p.logger.Error("malformed gossip transaction message received")
+		p.reportInvalidGossipTx(from, errors.New("malformed gossip transaction message"))
 
 		return
 	}
@@ -827,6 +1127,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// decode tx
 	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
+		p.reportInvalidGossipTx(from, err)
 
 		return
 	}
@@ -840,9 +1141,22 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 		}
 
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash.String())
+
+		if isInvalidTxError(err) {
+			p.reportInvalidGossipTx(from, err)
+		}
 	}
 }
 
+// reportInvalidGossipTx penalizes the peer for gossiping the invalid transaction
+func (p *TxPool) reportInvalidGossipTx(from peer.ID, err error) {
+	if p.gossip == nil {
+		return
+	}
+
+	p.gossip.reportInvalidTx(from, err)
+}
+
 // resetAccounts updates existing accounts with the new nonce and prunes stale transactions.
 func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	if len(stateNonces) == 0 {
@@ -953,6 +1267,19 @@ func (p *TxPool) createAccountOnce(newAddr types.Address) *account {
 	return p.accounts.initOnce(newAddr, stateNonce)
 }
 
+// isPrioritySender checks if the address is one of the priority senders.
+func (p *TxPool) isPrioritySender(addr types.Address) bool {
+	_, ok := p.prioritySenders[addr]
+
+	return ok
+}
+
+// isEvictable checks if the transactions of the address may be evicted,
+// which the local and the priority senders are exempt from.
+func (p *TxPool) isEvictable(addr types.Address) bool {
+	return !p.isPrioritySender(addr) && !p.locals.contains(addr)
+}
+
 // Length returns the total number of all promoted transactions.
 func (p *TxPool) Length() uint64 {
 	return p.accounts.promoted()
@@ -13,6 +13,7 @@ import (
 	"github.com/vishnushankarsg/metad/chain"
 	"github.com/vishnushankarsg/metad/crypto"
 	"github.com/vishnushankarsg/metad/helper/tests"
+	"github.com/vishnushankarsg/metad/network"
 	"github.com/vishnushankarsg/metad/state"
 	"github.com/vishnushankarsg/metad/state/runtime"
 	"github.com/vishnushankarsg/metad/txpool/proto"
@@ -94,6 +95,7 @@ func newTestPoolWithSlots(maxSlots uint64, mockStore ...store) (*TxPool, error)
 			PriceLimit:          defaultPriceLimit,
 			MaxSlots:            maxSlots,
 			MaxAccountEnqueued:  defaultMaxAccountEnqueued,
+			PriceBump:           DefaultPriceBump,
 			DeploymentWhitelist: []types.Address{},
 		},
 	)
@@ -457,6 +459,87 @@ func TestPruneAccountsWithNonceHoles(t *testing.T) {
 	)
 }
 
+func TestAddPrivateTx(t *testing.T) {
+	t.Parallel()
+
+	newNetworkedPool := func() (*TxPool, *network.Server) {
+		srv, err := network.CreateServer(&network.CreateServerParams{
+			ConfigCallback: func(c *network.Config) {
+				c.NoDiscover = true
+			},
+		})
+		require.NoError(t, err)
+
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			srv,
+			&Config{
+				PriceLimit:         defaultPriceLimit,
+				MaxSlots:           defaultMaxSlots,
+				MaxAccountEnqueued: defaultMaxAccountEnqueued,
+				PriceBump:          DefaultPriceBump,
+			},
+		)
+		require.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+		pool.SetSealing(true)
+		pool.Start()
+
+		t.Cleanup(func() {
+			pool.Close()
+			_ = srv.Close()
+		})
+
+		return pool, srv
+	}
+
+	sender, senderSrv := newNetworkedPool()
+	receiver, receiverSrv := newNetworkedPool()
+
+	require.NoError(t, network.JoinAndWait(
+		senderSrv,
+		receiverSrv,
+		network.DefaultBufferTimeout,
+		network.DefaultJoinTimeout,
+	))
+
+	// wait for the gossip between the pools to be set up
+	warmupTx := new(eoa).create(t).signTx(t, newTx(types.ZeroAddress, 0, 1), signerEIP155).ComputeHash()
+	msg := &proto.Txn{Raw: &any.Any{Value: warmupTx.MarshalRLP()}}
+
+	require.Eventually(t, func() bool {
+		require.NoError(t, sender.topic.Publish(msg))
+
+		_, ok := receiver.index.get(warmupTx.Hash)
+
+		return ok
+	}, 10*time.Second, 100*time.Millisecond)
+
+	account := new(eoa).create(t)
+	privateTx := account.signTx(t, newTx(types.ZeroAddress, 0, 1), signerEIP155).ComputeHash()
+	publicTx := account.signTx(t, newTx(types.ZeroAddress, 1, 1), signerEIP155).ComputeHash()
+
+	require.NoError(t, sender.AddPrivateTx(privateTx))
+	require.NoError(t, sender.AddTx(publicTx))
+
+	// the public transaction is gossiped, the private one is kept by the sender only
+	require.Eventually(t, func() bool {
+		_, ok := receiver.index.get(publicTx.Hash)
+
+		return ok
+	}, 10*time.Second, 10*time.Millisecond)
+
+	_, ok := receiver.index.get(privateTx.Hash)
+	assert.False(t, ok)
+
+	_, ok = sender.index.get(privateTx.Hash)
+	assert.True(t, ok)
+}
+
 func TestAddTxHighPressure(t *testing.T) {
 	t.Parallel()
 
@@ -668,11 +751,11 @@ func TestEnqueueHandler(t *testing.T) {
 			acc.setNonce(20)
 
 			// send tx
-			go func() {
-				err := pool.addTx(local, newTx(addr1, 10, 1)) // 10 < 20
-				assert.NoError(t, err)
-			}()
-			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			tx := newTx(addr1, 10, 1) // 10 < 20
+
+			assert.ErrorIs(t, pool.addTx(local, tx), ErrNonceTooLow)
+
+			pool.handleEnqueueRequest(enqueueRequest{tx: tx})
 
 			assert.Equal(t, uint64(0), pool.gauge.read())
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
@@ -745,13 +828,11 @@ func TestEnqueueHandler(t *testing.T) {
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
 
 			//	send next expected tx
-			go func() {
-				assert.NoError(t,
-					pool.addTx(local, newTx(addr1, 1, 1)),
-				)
-			}()
+			tx := newTx(addr1, 1, 1)
 
-			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			assert.ErrorIs(t, pool.addTx(local, tx), ErrMaxEnqueuedLimitReached)
+
+			pool.handleEnqueueRequest(enqueueRequest{tx: tx})
 
 			//	assert the transaction was rejected
 			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
@@ -964,16 +1045,18 @@ func TestPromoteHandler(t *testing.T) {
 			promReq1 := handleEnqueueRequest(enqTx1)
 			promReq2 := handleEnqueueRequest(enqTx2)
 
+			// the second Tx replaced the cheaper first one
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
-			assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+			assertTxExists(t, tx1, false)
 			assert.Equal(
 				t,
-				slotsRequired(tx1)+slotsRequired(tx2),
+				slotsRequired(tx2),
 				pool.gauge.read(),
 			)
 
-			// promote the second Tx and remove the first Tx
+			// promote the second Tx
 			pool.handlePromoteRequest(promReq1)
 
 			assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
@@ -1546,6 +1629,189 @@ func TestDemote(t *testing.T) {
 	})
 }
 
+func TestReplaceTx(t *testing.T) {
+	t.Parallel()
+
+	// returns a new tx with the given nonce and gas price
+	newPricedTx := func(nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr1, nonce, 1)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx
+	}
+
+	t.Run("replace enqueued tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_REPLACED})
+
+		oldTx := newPricedTx(10, 100)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, oldTx))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		newTx := newPricedTx(10, 110)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, newTx))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancelFn()
+
+		events := waitForEvents(ctx, subscription, 1)
+		require.Len(t, events, 1)
+		assert.Equal(t, oldTx.Hash.String(), events[0].TxHash)
+
+		assert.Equal(t, uint64(1), pool.gauge.read())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, newTx, pool.accounts.get(addr1).enqueued.peek())
+
+		_, exists := pool.index.get(oldTx.Hash)
+		assert.False(t, exists)
+
+		_, exists = pool.index.get(newTx.Hash)
+		assert.True(t, exists)
+	})
+
+	t.Run("replace promoted tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, newPricedTx(0, 100)))
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		newTx := newPricedTx(0, 200)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, newTx))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		assert.Equal(t, uint64(1), pool.gauge.read())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, newTx, pool.accounts.get(addr1).promoted.peek())
+	})
+
+	t.Run("replace promoted tx during block building", func(t *testing.T) {
+		t.Parallel()
+
+		setupPool := func(t *testing.T) (*TxPool, *types.Transaction) {
+			t.Helper()
+
+			pool, err := newTestPool()
+			require.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+
+			oldTx := newPricedTx(0, 100)
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, oldTx))
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+			pool.Prepare()
+
+			return pool, oldTx
+		}
+
+		replace := func(t *testing.T, pool *TxPool) *types.Transaction {
+			t.Helper()
+
+			newTx := newPricedTx(0, 200)
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, newTx))
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			return newTx
+		}
+
+		t.Run("replacement is offered instead", func(t *testing.T) {
+			t.Parallel()
+
+			pool, _ := setupPool(t)
+			newTx := replace(t, pool)
+
+			tx := pool.Peek()
+			require.Equal(t, newTx, tx)
+
+			pool.Pop(tx)
+
+			assert.Nil(t, pool.Peek())
+			assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+			assert.Equal(t, uint64(0), pool.gauge.read())
+		})
+
+		t.Run("replaced tx already peeked", func(t *testing.T) {
+			t.Parallel()
+
+			pool, oldTx := setupPool(t)
+
+			tx := pool.Peek()
+			require.Equal(t, oldTx, tx)
+
+			newTx := replace(t, pool)
+
+			// the replacement stays in the pool
+			pool.Pop(tx)
+			pool.Demote(tx)
+			pool.Drop(tx)
+
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+			assert.Equal(t, newTx, pool.accounts.get(addr1).promoted.peek())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
+			assert.Equal(t, uint64(0), pool.accounts.get(addr1).Demotions())
+			assert.Equal(t, uint64(1), pool.gauge.read())
+
+			_, exists := pool.index.get(newTx.Hash)
+			assert.True(t, exists)
+		})
+	})
+
+	t.Run("reject underpriced replacement", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		oldTx := newPricedTx(10, 100)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, oldTx))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		// the gas price is higher, but not by the price bump
+		assert.ErrorIs(t,
+			pool.addTx(local, newPricedTx(10, 109)),
+			ErrReplacementUnderpriced,
+		)
+
+		assert.Equal(t, uint64(1), pool.gauge.read())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, oldTx, pool.accounts.get(addr1).enqueued.peek())
+	})
+}
+
 func Test_updateAccountSkipsCounts(t *testing.T) {
 	t.Parallel()
 
@@ -2165,6 +2431,222 @@ func TestResetAccounts_Enqueued(t *testing.T) {
 	})
 }
 
+func TestAddTxDynamicFee(t *testing.T) {
+	t.Parallel()
+
+	newDynamicFeeTx := func(gasTipCap, gasFeeCap uint64) *types.Transaction {
+		tx := newTx(addr1, 0, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.GasPrice = big.NewInt(0)
+		tx.GasTipCap = new(big.Int).SetUint64(gasTipCap)
+		tx.GasFeeCap = new(big.Int).SetUint64(gasFeeCap)
+
+		return tx
+	}
+
+	// the base fee of the next block is only set once the EIP-1559 fork is active
+	setupPool := func(baseFee uint64) *TxPool {
+		pool, err := newTestPool(defaultMockStore{
+			DefaultHeader: mockHeader,
+			BaseFee:       baseFee,
+		})
+		require.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+
+		return pool
+	}
+
+	t.Run("ErrTxTypeNotSupported", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(0)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newDynamicFeeTx(1, 2)),
+			ErrTxTypeNotSupported,
+		)
+	})
+
+	t.Run("ErrTipAboveFeeCap", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(1)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newDynamicFeeTx(3, 2)),
+			ErrTipAboveFeeCap,
+		)
+	})
+
+	t.Run("ErrUnderpriced fee cap below base fee", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(10)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newDynamicFeeTx(1, 9)),
+			ErrUnderpriced,
+		)
+	})
+
+	t.Run("ErrUnderpriced legacy gas price below base fee", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(10)
+
+		tx := newTx(addr1, 0, 1)
+		tx.GasPrice.SetUint64(9)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, tx),
+			ErrUnderpriced,
+		)
+	})
+
+	t.Run("valid dynamic fee transaction", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(10)
+
+		go func() {
+			assert.NoError(t,
+				pool.addTx(local, newDynamicFeeTx(1, 10)),
+			)
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		<-pool.promoteReqCh
+	})
+}
+
+func TestAddTxAccessList(t *testing.T) {
+	t.Parallel()
+
+	newAccessListTx := func(gas uint64) *types.Transaction {
+		tx := newTx(addr1, 0, 1)
+		tx.Type = types.AccessListTx
+		tx.Gas = gas
+		tx.AccessList = types.TxAccessList{
+			{Address: addr2, StorageKeys: []types.Hash{types.StringToHash("1")}},
+		}
+
+		return tx
+	}
+
+	setupPool := func(berlin bool) *TxPool {
+		pool, err := newTestPool()
+		require.NoError(t, err)
+
+		pool.forks.Berlin = berlin
+		pool.SetSigner(&mockSigner{})
+
+		return pool
+	}
+
+	t.Run("ErrTxTypeNotSupported", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(false)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newAccessListTx(validGasLimit)),
+			ErrTxTypeNotSupported,
+		)
+	})
+
+	t.Run("ErrIntrinsicGas access list not covered", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(true)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newAccessListTx(state.TxGas+state.TxAccessListAddressGas)),
+			ErrIntrinsicGas,
+		)
+	})
+
+	t.Run("valid access list transaction", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(true)
+
+		go func() {
+			assert.NoError(t,
+				pool.addTx(local, newAccessListTx(validGasLimit)),
+			)
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		<-pool.promoteReqCh
+	})
+}
+
+func TestPricedQueue_EffectiveTipOrder(t *testing.T) {
+	t.Parallel()
+
+	newLegacyTx := func(gasPrice uint64) *types.Transaction {
+		return &types.Transaction{
+			Type:     types.LegacyTx,
+			GasPrice: new(big.Int).SetUint64(gasPrice),
+		}
+	}
+
+	newDynamicFeeTx := func(gasTipCap, gasFeeCap uint64) *types.Transaction {
+		return &types.Transaction{
+			Type:      types.DynamicFeeTx,
+			GasPrice:  big.NewInt(0),
+			GasTipCap: new(big.Int).SetUint64(gasTipCap),
+			GasFeeCap: new(big.Int).SetUint64(gasFeeCap),
+		}
+	}
+
+	txs := []*types.Transaction{
+		newLegacyTx(12),         // tip 2
+		newDynamicFeeTx(5, 13),  // tip 3
+		newDynamicFeeTx(5, 100), // tip 5
+		newDynamicFeeTx(1, 100), // tip 1
+		newDynamicFeeTx(3, 13),  // tip 3, lower tip cap
+	}
+
+	q := newPricedQueue()
+	q.setBaseFee(10)
+
+	for _, tx := range txs {
+		q.push(tx)
+	}
+
+	expectedOrder := []*types.Transaction{txs[2], txs[1], txs[4], txs[0], txs[3]}
+	for _, expected := range expectedOrder {
+		assert.Same(t, expected, q.pop())
+	}
+
+	assert.Nil(t, q.pop())
+}
+
+func TestPricedQueue_PriorityOrder(t *testing.T) {
+	t.Parallel()
+
+	newPricedTx := func(addr types.Address, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, 0, 1)
+		tx.GasPrice.SetUint64(gasPrice)
+
+		return tx
+	}
+
+	txs := []*types.Transaction{
+		newPricedTx(addr1, 30),
+		newPricedTx(addr2, 1),
+		newPricedTx(addr3, 20),
+		newPricedTx(addr2, 5),
+	}
+
+	q := newPricedQueue()
+	q.setPriority(map[types.Address]struct{}{addr2: {}})
+
+	for _, tx := range txs {
+		q.push(tx)
+	}
+
+	// the priority sender comes first, regardless of the price
+	expectedOrder := []*types.Transaction{txs[3], txs[1], txs[0], txs[2]}
+	for _, expected := range expectedOrder {
+		assert.Same(t, expected, q.pop())
+	}
+
+	assert.Nil(t, q.pop())
+}
+
 func TestExecutablesOrder(t *testing.T) {
 	t.Parallel()
 
@@ -2875,3 +3357,71 @@ func TestBatchTx_SingleAccount(t *testing.T) {
 		}
 	}
 }
+
+func TestGetTxsFrom(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// promoted transactions
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		go func(nonce uint64) {
+			assert.NoError(t, pool.addTx(local, newTx(addr1, nonce, 1)))
+		}(nonce)
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	// enqueued transactions
+	enqueueTx(t, pool, local, newTx(addr1, 6, 1))
+	enqueueTx(t, pool, local, newTx(addr1, 5, 1))
+	enqueueTx(t, pool, local, newTx(addr2, 5, 1))
+
+	nonces := func(txs []*types.Transaction) []uint64 {
+		result := make([]uint64, len(txs))
+		for i, tx := range txs {
+			result[i] = tx.Nonce
+		}
+
+		return result
+	}
+
+	testCases := []struct {
+		name            string
+		offset, limit   uint64
+		expectedPending []uint64
+		expectedQueued  []uint64
+	}{
+		{"all", 0, 0, []uint64{0, 1}, []uint64{5, 6}},
+		{"first page", 0, 3, []uint64{0, 1}, []uint64{5}},
+		{"second page", 3, 3, []uint64{}, []uint64{6}},
+		{"pending only", 1, 1, []uint64{1}, []uint64{}},
+		{"out of range", 4, 3, []uint64{}, []uint64{}},
+	}
+
+	for _, tc := range testCases {
+		pending, queued, total := pool.GetTxsFrom(addr1, tc.offset, tc.limit)
+
+		assert.Equal(t, uint64(4), total, tc.name)
+		assert.Equal(t, tc.expectedPending, nonces(pending), tc.name)
+		assert.Equal(t, tc.expectedQueued, nonces(queued), tc.name)
+	}
+
+	// unknown sender
+	pending, queued, total := pool.GetTxsFrom(addr3, 0, 0)
+	assert.Empty(t, pending)
+	assert.Empty(t, queued)
+	assert.Zero(t, total)
+
+	// operator endpoint
+	resp, err := pool.ContentFrom(context.Background(), &proto.ContentFromReq{From: addr2.String()})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), resp.Total)
+	require.Len(t, resp.Queued, 1)
+	assert.Equal(t, uint64(5), resp.Queued[0].Nonce)
+
+	_, err = pool.ContentFrom(context.Background(), &proto.ContentFromReq{From: "0x1"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+)
+
+// AccessTuple is an EIP-2930 access list entry,
+// an address together with the storage keys accessed under it
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+// TxAccessList is the EIP-2930 list of addresses and storage keys
+// that the transaction plans to access
+type TxAccessList []AccessTuple
+
+// StorageKeys returns the total number of storage keys in the access list
+func (al TxAccessList) StorageKeys() int {
+	count := 0
+
+	for _, tuple := range al {
+		count += len(tuple.StorageKeys)
+	}
+
+	return count
+}
+
+// Copy returns a deep copy of the access list
+func (al TxAccessList) Copy() TxAccessList {
+	if al == nil {
+		return nil
+	}
+
+	cpy := make(TxAccessList, len(al))
+
+	for i, tuple := range al {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]Hash{}, tuple.StorageKeys...),
+		}
+	}
+
+	return cpy
+}
+
+// MarshalRLPWith marshals the access list to RLP with a specific fastrlp.Arena
+func (al TxAccessList) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	if len(al) == 0 {
+		return arena.NewNullArray()
+	}
+
+	vv := arena.NewArray()
+
+	for _, tuple := range al {
+		av := arena.NewArray()
+		av.Set(arena.NewCopyBytes(tuple.Address.Bytes()))
+
+		if len(tuple.StorageKeys) == 0 {
+			av.Set(arena.NewNullArray())
+		} else {
+			sv := arena.NewArray()
+			for _, key := range tuple.StorageKeys {
+				sv.Set(arena.NewCopyBytes(key.Bytes()))
+			}
+
+			av.Set(sv)
+		}
+
+		vv.Set(av)
+	}
+
+	return vv
+}
+
+// unmarshalRLPFrom unmarshals an access list in RLP format
+func (al *TxAccessList) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) == 0 {
+		*al = nil
+
+		return nil
+	}
+
+	list := make(TxAccessList, len(elems))
+
+	for i, elem := range elems {
+		tuple, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(tuple) != 2 {
+			return fmt.Errorf("incorrect number of elements to decode access tuple, expected 2 but found %d", len(tuple))
+		}
+
+		if err = tuple[0].GetAddr(list[i].Address[:]); err != nil {
+			return err
+		}
+
+		keys, err := tuple[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		list[i].StorageKeys = make([]Hash, len(keys))
+
+		for j, key := range keys {
+			if err = key.GetHash(list[i].StorageKeys[j][:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	*al = list
+
+	return nil
+}
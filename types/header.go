@@ -26,6 +26,9 @@ type Header struct {
 	MixHash      Hash
 	Nonce        Nonce
 	Hash         Hash
+
+	// BaseFee was added by EIP-1559 and is omitted from the RLP encoding when zero (pre-EIP-1559 headers)
+	BaseFee uint64
 }
 
 func (h *Header) Equal(hh *Header) bool {
@@ -75,6 +78,7 @@ func (h *Header) Copy() *Header {
 		GasLimit:     h.GasLimit,
 		GasUsed:      h.GasUsed,
 		Timestamp:    h.Timestamp,
+		BaseFee:      h.BaseFee,
 	}
 
 	newHeader.Miner = make([]byte, len(h.Miner))
@@ -166,6 +166,111 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 	}
 }
 
+func TestRLPMarshall_And_Unmarshall_DynamicFeeTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+	originalTx := &Transaction{
+		Type:      DynamicFeeTx,
+		ChainID:   big.NewInt(100),
+		Nonce:     1,
+		GasPrice:  big.NewInt(0),
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21000,
+		To:        &addrTo,
+		Value:     big.NewInt(1),
+		Input:     []byte{1, 2},
+		V:         big.NewInt(1),
+		S:         big.NewInt(26),
+		R:         big.NewInt(27),
+	}
+	originalTx.ComputeHash()
+
+	txRLP := originalTx.MarshalRLP()
+	assert.Equal(t, byte(DynamicFeeTx), txRLP[0])
+
+	unmarshalledTx := new(Transaction)
+	assert.NoError(t, unmarshalledTx.UnmarshalRLP(txRLP))
+	assert.Equal(t, originalTx, unmarshalledTx)
+
+	// the typed transaction hash covers the type prefix
+	legacyTx := originalTx.Copy()
+	legacyTx.Type = LegacyTx
+	legacyTx.ComputeHash()
+	assert.NotEqual(t, legacyTx.Hash, unmarshalledTx.Hash)
+}
+
+func TestRLPMarshall_And_Unmarshall_AccessListTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+	accessList := TxAccessList{
+		{
+			Address:     StringToAddress("12"),
+			StorageKeys: []Hash{StringToHash("1"), StringToHash("2")},
+		},
+		{
+			Address:     StringToAddress("13"),
+			StorageKeys: []Hash{},
+		},
+	}
+
+	for _, txType := range []TxType{AccessListTx, DynamicFeeTx} {
+		originalTx := &Transaction{
+			Type:       txType,
+			ChainID:    big.NewInt(100),
+			Nonce:      1,
+			GasPrice:   big.NewInt(0),
+			Gas:        21000,
+			To:         &addrTo,
+			Value:      big.NewInt(1),
+			Input:      []byte{1, 2},
+			AccessList: accessList,
+			V:          big.NewInt(1),
+			S:          big.NewInt(26),
+			R:          big.NewInt(27),
+		}
+
+		if txType == DynamicFeeTx {
+			originalTx.GasTipCap = big.NewInt(2)
+			originalTx.GasFeeCap = big.NewInt(10)
+		} else {
+			originalTx.GasPrice = big.NewInt(10)
+		}
+
+		originalTx.ComputeHash()
+
+		txRLP := originalTx.MarshalRLP()
+		assert.Equal(t, byte(txType), txRLP[0])
+
+		unmarshalledTx := new(Transaction)
+		assert.NoError(t, unmarshalledTx.UnmarshalRLP(txRLP))
+		assert.Equal(t, originalTx, unmarshalledTx)
+
+		// the access list is part of the transaction hash
+		noAccessListTx := originalTx.Copy()
+		noAccessListTx.AccessList = nil
+		noAccessListTx.ComputeHash()
+		assert.NotEqual(t, noAccessListTx.Hash, unmarshalledTx.Hash)
+	}
+}
+
+func TestRLPMarshall_And_Unmarshall_HeaderBaseFee(t *testing.T) {
+	h := &Header{
+		Number:  10,
+		BaseFee: 1000,
+	}
+	h.ComputeHash()
+
+	h2 := new(Header)
+	assert.NoError(t, h2.UnmarshalRLP(h.MarshalRLP()))
+	assert.Equal(t, h.BaseFee, h2.BaseFee)
+	assert.Equal(t, h.Hash, h2.Hash)
+
+	// the base fee is part of the header hash
+	h3 := h.Copy()
+	h3.BaseFee = 0
+	h3.ComputeHash()
+	assert.NotEqual(t, h.Hash, h3.Hash)
+}
+
 func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 	t.Parallel()
 
@@ -247,6 +352,14 @@ func TestRLPMarshall_And_Unmarshall_TxType(t *testing.T) {
 			name:   "LegacyTx",
 			txType: LegacyTx,
 		},
+		{
+			name:   "AccessListTx",
+			txType: AccessListTx,
+		},
+		{
+			name:   "DynamicFeeTx",
+			txType: DynamicFeeTx,
+		},
 		{
 			name:        "undefined type",
 			txType:      TxType(0x09),
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"math/big"
+
 	"github.com/umbracle/fastrlp"
 )
 
@@ -91,6 +93,11 @@ func (h *Header) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewBytes(h.MixHash.Bytes()))
 	vv.Set(arena.NewCopyBytes(h.Nonce[:]))
 
+	// base fee is only part of the post-EIP-1559 headers
+	if h.BaseFee != 0 {
+		vv.Set(arena.NewUint(h.BaseFee))
+	}
+
 	return vv
 }
 
@@ -186,6 +193,13 @@ func (t *Transaction) MarshalRLPTo(dst []byte) []byte {
 
 // MarshalRLPWith marshals the transaction to RLP with a specific fastrlp.Arena
 func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	switch t.Type {
+	case AccessListTx:
+		return t.marshalAccessListRLPWith(arena)
+	case DynamicFeeTx:
+		return t.marshalDynamicFeeRLPWith(arena)
+	}
+
 	vv := arena.NewArray()
 
 	vv.Set(arena.NewUint(t.Nonce))
@@ -213,3 +227,72 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 
 	return vv
 }
+
+// marshalAccessListRLPWith marshals the EIP-2930 transaction payload
+// (without the type prefix) to RLP with a specific fastrlp.Arena
+func (t *Transaction) marshalAccessListRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBigInt(bigOrZero(t.ChainID)))
+	vv.Set(arena.NewUint(t.Nonce))
+	vv.Set(arena.NewBigInt(bigOrZero(t.GasPrice)))
+	vv.Set(arena.NewUint(t.Gas))
+
+	// Address may be empty
+	if t.To != nil {
+		vv.Set(arena.NewBytes((*t.To).Bytes()))
+	} else {
+		vv.Set(arena.NewNull())
+	}
+
+	vv.Set(arena.NewBigInt(t.Value))
+	vv.Set(arena.NewCopyBytes(t.Input))
+	vv.Set(t.AccessList.MarshalRLPWith(arena))
+
+	// signature values
+	vv.Set(arena.NewBigInt(t.V))
+	vv.Set(arena.NewBigInt(t.R))
+	vv.Set(arena.NewBigInt(t.S))
+
+	return vv
+}
+
+// marshalDynamicFeeRLPWith marshals the EIP-1559 transaction payload
+// (without the type prefix) to RLP with a specific fastrlp.Arena
+func (t *Transaction) marshalDynamicFeeRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBigInt(bigOrZero(t.ChainID)))
+	vv.Set(arena.NewUint(t.Nonce))
+	vv.Set(arena.NewBigInt(bigOrZero(t.GasTipCap)))
+	vv.Set(arena.NewBigInt(bigOrZero(t.GasFeeCap)))
+	vv.Set(arena.NewUint(t.Gas))
+
+	// Address may be empty
+	if t.To != nil {
+		vv.Set(arena.NewBytes((*t.To).Bytes()))
+	} else {
+		vv.Set(arena.NewNull())
+	}
+
+	vv.Set(arena.NewBigInt(t.Value))
+	vv.Set(arena.NewCopyBytes(t.Input))
+
+	vv.Set(t.AccessList.MarshalRLPWith(arena))
+
+	// signature values
+	vv.Set(arena.NewBigInt(t.V))
+	vv.Set(arena.NewBigInt(t.R))
+	vv.Set(arena.NewBigInt(t.S))
+
+	return vv
+}
+
+// bigOrZero returns the given big integer or zero if it is nil
+func bigOrZero(b *big.Int) *big.Int {
+	if b == nil {
+		return new(big.Int)
+	}
+
+	return b
+}
@@ -218,6 +218,14 @@ func (h *Header) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
 
 	h.SetNonce(nonce)
 
+	// baseFee
+	// pre-EIP-1559 headers don't have it, so the length is checked before accessing the element
+	if len(elems) > 15 {
+		if h.BaseFee, err = elems[15].GetUint64(); err != nil {
+			return err
+		}
+	}
+
 	// compute the hash after the decoding
 	h.ComputeHash()
 
@@ -371,6 +379,13 @@ func (t *Transaction) UnmarshalRLP(input []byte) error {
 
 // unmarshalRLPFrom unmarshals a Transaction in RLP format
 func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	switch t.Type {
+	case AccessListTx:
+		return t.unmarshalAccessListRLPFrom(p, v)
+	case DynamicFeeTx:
+		return t.unmarshalDynamicFeeRLPFrom(p, v)
+	}
+
 	elems, err := v.GetElems()
 	if err != nil {
 		return err
@@ -454,3 +469,178 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 
 	return nil
 }
+
+// unmarshalAccessListRLPFrom unmarshals an EIP-2930 transaction payload in RLP format
+func (t *Transaction) unmarshalAccessListRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 11 {
+		return fmt.Errorf("incorrect number of elements to decode access list transaction, expected 11 but found %d", len(elems))
+	}
+
+	// chainID
+	t.ChainID = new(big.Int)
+	if err = elems[0].GetBigInt(t.ChainID); err != nil {
+		return err
+	}
+
+	// nonce
+	if t.Nonce, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	// gasPrice
+	t.GasPrice = new(big.Int)
+	if err = elems[2].GetBigInt(t.GasPrice); err != nil {
+		return err
+	}
+
+	// gas
+	if t.Gas, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+
+	// to
+	if vv, _ := elems[4].Bytes(); len(vv) == AddressLength {
+		// address
+		addr := BytesToAddress(vv)
+		t.To = &addr
+	} else {
+		// reset To
+		t.To = nil
+	}
+
+	// value
+	t.Value = new(big.Int)
+	if err = elems[5].GetBigInt(t.Value); err != nil {
+		return err
+	}
+
+	// input
+	if t.Input, err = elems[6].GetBytes(t.Input[:0]); err != nil {
+		return err
+	}
+
+	// access list
+	if err = t.AccessList.unmarshalRLPFrom(p, elems[7]); err != nil {
+		return err
+	}
+
+	// V
+	t.V = new(big.Int)
+	if err = elems[8].GetBigInt(t.V); err != nil {
+		return err
+	}
+
+	// R
+	t.R = new(big.Int)
+	if err = elems[9].GetBigInt(t.R); err != nil {
+		return err
+	}
+
+	// S
+	t.S = new(big.Int)
+	if err = elems[10].GetBigInt(t.S); err != nil {
+		return err
+	}
+
+	// typed transactions are hashed together with the type prefix
+	t.ComputeHash()
+
+	return nil
+}
+
+// unmarshalDynamicFeeRLPFrom unmarshals an EIP-1559 transaction payload in RLP format
+func (t *Transaction) unmarshalDynamicFeeRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 12 {
+		return fmt.Errorf("incorrect number of elements to decode dynamic fee transaction, expected 12 but found %d", len(elems))
+	}
+
+	// chainID
+	t.ChainID = new(big.Int)
+	if err = elems[0].GetBigInt(t.ChainID); err != nil {
+		return err
+	}
+
+	// nonce
+	if t.Nonce, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	// gasTipCap
+	t.GasTipCap = new(big.Int)
+	if err = elems[2].GetBigInt(t.GasTipCap); err != nil {
+		return err
+	}
+
+	// gasFeeCap
+	t.GasFeeCap = new(big.Int)
+	if err = elems[3].GetBigInt(t.GasFeeCap); err != nil {
+		return err
+	}
+
+	// gas price is not part of the dynamic fee transaction
+	t.GasPrice = new(big.Int)
+
+	// gas
+	if t.Gas, err = elems[4].GetUint64(); err != nil {
+		return err
+	}
+
+	// to
+	if vv, _ := elems[5].Bytes(); len(vv) == AddressLength {
+		// address
+		addr := BytesToAddress(vv)
+		t.To = &addr
+	} else {
+		// reset To
+		t.To = nil
+	}
+
+	// value
+	t.Value = new(big.Int)
+	if err = elems[6].GetBigInt(t.Value); err != nil {
+		return err
+	}
+
+	// input
+	if t.Input, err = elems[7].GetBytes(t.Input[:0]); err != nil {
+		return err
+	}
+
+	// access list
+	if err = t.AccessList.unmarshalRLPFrom(p, elems[8]); err != nil {
+		return err
+	}
+
+	// V
+	t.V = new(big.Int)
+	if err = elems[9].GetBigInt(t.V); err != nil {
+		return err
+	}
+
+	// R
+	t.R = new(big.Int)
+	if err = elems[10].GetBigInt(t.R); err != nil {
+		return err
+	}
+
+	// S
+	t.S = new(big.Int)
+	if err = elems[11].GetBigInt(t.S); err != nil {
+		return err
+	}
+
+	// typed transactions are hashed together with the type prefix
+	t.ComputeHash()
+
+	return nil
+}
@@ -11,8 +11,10 @@ import (
 type TxType byte
 
 const (
-	LegacyTx TxType = 0x0
-	StateTx  TxType = 0x7f
+	LegacyTx     TxType = 0x0
+	AccessListTx TxType = 0x01
+	DynamicFeeTx TxType = 0x02
+	StateTx      TxType = 0x7f
 
 	StateTransactionGasLimit = 1000000 // some arbitrary default gas limit for state transactions
 )
@@ -21,7 +23,7 @@ func txTypeFromByte(b byte) (TxType, error) {
 	tt := TxType(b)
 
 	switch tt {
-	case LegacyTx, StateTx:
+	case LegacyTx, AccessListTx, DynamicFeeTx, StateTx:
 		return tt, nil
 	default:
 		return tt, fmt.Errorf("unknown transaction type: %d", b)
@@ -32,6 +34,10 @@ func (t TxType) String() (s string) {
 	switch t {
 	case LegacyTx:
 		return "LegacyTx"
+	case AccessListTx:
+		return "AccessListTx"
+	case DynamicFeeTx:
+		return "DynamicFeeTx"
 	case StateTx:
 		return "StateTx"
 	}
@@ -40,19 +46,23 @@ func (t TxType) String() (s string) {
 }
 
 type Transaction struct {
-	Nonce    uint64
-	GasPrice *big.Int
-	Gas      uint64
-	To       *Address
-	Value    *big.Int
-	Input    []byte
-	V        *big.Int
-	R        *big.Int
-	S        *big.Int
-	Hash     Hash
-	From     Address
-
-	Type TxType
+	Nonce     uint64
+	GasPrice  *big.Int
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+	Gas       uint64
+	To        *Address
+	Value     *big.Int
+	Input     []byte
+	V         *big.Int
+	R         *big.Int
+	S         *big.Int
+	Hash      Hash
+	From      Address
+
+	Type       TxType
+	ChainID    *big.Int
+	AccessList TxAccessList
 
 	// Cache
 	size atomic.Value
@@ -68,6 +78,11 @@ func (t *Transaction) ComputeHash() *Transaction {
 	ar := marshalArenaPool.Get()
 	hash := keccak.DefaultKeccakPool.Get()
 
+	// EIP-2718 typed transactions are hashed together with the type prefix
+	if t.Type == AccessListTx || t.Type == DynamicFeeTx {
+		hash.Write([]byte{byte(t.Type)}) //nolint:errcheck
+	}
+
 	v := t.MarshalRLPWith(ar)
 	hash.WriteRlp(t.Hash[:0], v)
 
@@ -86,6 +101,18 @@ func (t *Transaction) Copy() *Transaction {
 		tt.GasPrice.Set(t.GasPrice)
 	}
 
+	if t.GasTipCap != nil {
+		tt.GasTipCap = new(big.Int).Set(t.GasTipCap)
+	}
+
+	if t.GasFeeCap != nil {
+		tt.GasFeeCap = new(big.Int).Set(t.GasFeeCap)
+	}
+
+	if t.ChainID != nil {
+		tt.ChainID = new(big.Int).Set(t.ChainID)
+	}
+
 	tt.Value = new(big.Int)
 	if t.Value != nil {
 		tt.Value.Set(t.Value)
@@ -104,17 +131,91 @@ func (t *Transaction) Copy() *Transaction {
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	tt.AccessList = t.AccessList.Copy()
+
 	return tt
 }
 
-// Cost returns gas * gasPrice + value
+// Cost returns gas * gasPrice + value.
+// For dynamic fee transactions the gas fee cap is used as the gas price
 func (t *Transaction) Cost() *big.Int {
-	total := new(big.Int).Mul(t.GasPrice, new(big.Int).SetUint64(t.Gas))
+	total := new(big.Int).Mul(t.GetGasFeeCap(), new(big.Int).SetUint64(t.Gas))
 	total.Add(total, t.Value)
 
 	return total
 }
 
+// GetGasFeeCap returns the maximum price per gas the sender is willing to pay.
+// It is the gas fee cap for dynamic fee transactions and the gas price otherwise
+func (t *Transaction) GetGasFeeCap() *big.Int {
+	if t.Type == DynamicFeeTx {
+		if t.GasFeeCap == nil {
+			return new(big.Int)
+		}
+
+		return t.GasFeeCap
+	}
+
+	if t.GasPrice == nil {
+		return new(big.Int)
+	}
+
+	return t.GasPrice
+}
+
+// GetGasTipCap returns the maximum tip per gas the sender is willing to pay to the block producer.
+// It is the gas tip cap for dynamic fee transactions and the gas price otherwise
+func (t *Transaction) GetGasTipCap() *big.Int {
+	if t.Type == DynamicFeeTx {
+		if t.GasTipCap == nil {
+			return new(big.Int)
+		}
+
+		return t.GasTipCap
+	}
+
+	if t.GasPrice == nil {
+		return new(big.Int)
+	}
+
+	return t.GasPrice
+}
+
+// EffectiveGasTip returns the tip per gas the block producer receives
+// for the given base fee, min(gasTipCap, gasFeeCap - baseFee).
+// The result is negative if the fee cap doesn't cover the base fee
+func (t *Transaction) EffectiveGasTip(baseFee *big.Int) *big.Int {
+	if baseFee == nil || baseFee.Sign() == 0 {
+		return new(big.Int).Set(t.GetGasTipCap())
+	}
+
+	tip := new(big.Int).Sub(t.GetGasFeeCap(), baseFee)
+	if gasTipCap := t.GetGasTipCap(); tip.Cmp(gasTipCap) > 0 {
+		tip.Set(gasTipCap)
+	}
+
+	return tip
+}
+
+// EffectiveGasPrice returns the price per gas actually paid by the sender
+// for the given base fee, min(gasTipCap + baseFee, gasFeeCap)
+func (t *Transaction) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	if t.Type != DynamicFeeTx {
+		return new(big.Int).Set(t.GetGasFeeCap())
+	}
+
+	if baseFee == nil {
+		baseFee = new(big.Int)
+	}
+
+	price := new(big.Int).Add(t.GetGasTipCap(), baseFee)
+	if gasFeeCap := t.GetGasFeeCap(); price.Cmp(gasFeeCap) > 0 {
+		price.Set(gasFeeCap)
+	}
+
+	return price
+}
+
 func (t *Transaction) Size() uint64 {
 	if size := t.size.Load(); size != nil {
 		sizeVal, ok := size.(uint64)
@@ -136,5 +237,5 @@ func (t *Transaction) ExceedsBlockGasLimit(blockGasLimit uint64) bool {
 }
 
 func (t *Transaction) IsUnderpriced(priceLimit uint64) bool {
-	return t.GasPrice.Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0
+	return t.GetGasFeeCap().Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0
 }
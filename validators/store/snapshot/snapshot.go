@@ -288,6 +288,14 @@ func (s *SnapshotValidatorStore) ProcessHeader(
 		return err
 	}
 
+	// The first header of the imported state snapshot has no parent in the chain,
+	// the validators of its parent are taken from the header
+	if s.isDetachedHeader(header) {
+		if err := s.addParentSnap(signer, header); err != nil {
+			return err
+		}
+	}
+
 	parentSnap := s.getSnapshot(header.Number - 1)
 	if parentSnap == nil {
 		return ErrSnapshotNotFound
@@ -425,6 +433,36 @@ func (s *SnapshotValidatorStore) addHeaderSnap(header *types.Header) error {
 	return nil
 }
 
+// isDetachedHeader returns true if the header doesn't follow the last processed one
+// and its parent is not in the chain
+func (s *SnapshotValidatorStore) isDetachedHeader(header *types.Header) bool {
+	if header.Number <= s.GetSnapshotMetadata().LastBlock+1 {
+		return false
+	}
+
+	_, ok := s.blockchain.GetHeaderByNumber(header.Number - 1)
+
+	return !ok
+}
+
+// addParentSnap adds the snapshot of the parent of the given header,
+// with the validators set in the header and without any votes
+func (s *SnapshotValidatorStore) addParentSnap(signer SignerInterface, header *types.Header) error {
+	validators, err := signer.GetValidators(header)
+	if err != nil {
+		return err
+	}
+
+	s.store.putByNumber(&Snapshot{
+		Hash:   header.ParentHash.String(),
+		Number: header.Number - 1,
+		Votes:  []*store.Vote{},
+		Set:    validators,
+	})
+
+	return nil
+}
+
 // getSnapshot returns a snapshot for specified height
 func (s *SnapshotValidatorStore) getSnapshot(height uint64) *Snapshot {
 	return s.store.find(height)
@@ -1626,7 +1626,10 @@ func TestSnapshotValidatorStoreProcessHeader(t *testing.T) {
 			t.Parallel()
 
 			snapshotStore := newTestSnapshotValidatorStore(
-				nil,
+				newMockBlockchain(initialLastHeight, map[uint64]*types.Header{
+					initialLastHeight: newTestHeader(initialLastHeight, nil, types.Nonce{}),
+					headerHeight1:     newTestHeader(headerHeight1, nil, types.Nonce{}),
+				}),
 				test.getSigner,
 				initialLastHeight,
 				test.initialSnapshots,
@@ -1657,6 +1660,79 @@ func TestSnapshotValidatorStoreProcessHeader(t *testing.T) {
 	}
 }
 
+func TestSnapshotValidatorStoreProcessHeaderWithoutParent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		epochSize uint64 = 10
+
+		// the first header of the imported state snapshot
+		header = newTestHeader(50, ecdsaValidator1.Address.Bytes(), nonceDropVote)
+
+		headerValidators = validators.NewECDSAValidatorSet(
+			ecdsaValidator1,
+			ecdsaValidator2,
+		)
+	)
+
+	newStore := func(proposer types.Address) *SnapshotValidatorStore {
+		return newTestSnapshotValidatorStore(
+			newMockBlockchain(0, map[uint64]*types.Header{
+				0:  newTestHeader(0, nil, types.Nonce{}),
+				50: header,
+			}),
+			func(height uint64) (SignerInterface, error) {
+				return &mockSigner{
+					TypeFn: func() validators.ValidatorType {
+						return validators.ECDSAValidatorType
+					},
+					EcrecoverFromHeaderFn: func(*types.Header) (types.Address, error) {
+						return proposer, nil
+					},
+					GetValidatorsFn: func(h *types.Header) (validators.Validators, error) {
+						assert.Equal(t, header, h)
+
+						return headerValidators, nil
+					},
+				}, nil
+			},
+			0,
+			[]*Snapshot{
+				{
+					Number: 0,
+					Set:    validators.NewECDSAValidatorSet(ecdsaValidator3),
+				},
+			},
+			[]*store.Candidate{},
+			epochSize,
+		)
+	}
+
+	t.Run("should take the validators of the parent from the header", func(t *testing.T) {
+		t.Parallel()
+
+		snapshotStore := newStore(ecdsaValidator1.Address)
+
+		assert.NoError(t, snapshotStore.ProcessHeader(header))
+
+		for _, height := range []uint64{49, 50} {
+			set, err := snapshotStore.GetValidatorsByHeight(height)
+			assert.NoError(t, err)
+			assert.Equal(t, headerValidators, set)
+		}
+
+		assert.Equal(t, uint64(50), snapshotStore.GetSnapshotMetadata().LastBlock)
+	})
+
+	t.Run("should reject the proposer not in the validators of the header", func(t *testing.T) {
+		t.Parallel()
+
+		snapshotStore := newStore(ecdsaValidator3.Address)
+
+		assert.ErrorIs(t, snapshotStore.ProcessHeader(header), ErrUnauthorizedProposer)
+	})
+}
+
 func TestSnapshotValidatorStorePropose(t *testing.T) {
 	t.Parallel()
 